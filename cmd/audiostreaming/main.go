@@ -26,6 +26,10 @@ func main() {
 
 	go func() { application.HTTPServer.MustRun(ctx) }()
 
+	go func() { application.TrendingWorker.MustRun(ctx) }()
+
+	go func() { application.BeatmakersWorker.MustRun(ctx) }()
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
@@ -34,4 +38,6 @@ func main() {
 	// Stopping server
 	application.GRPCServer.Stop(ctx)
 	application.HTTPServer.Stop(ctx)
+	application.TrendingWorker.Stop(ctx)
+	application.BeatmakersWorker.Stop(ctx)
 }
@@ -3,22 +3,34 @@ package app
 import (
 	"context"
 	"log/slog"
+	"os"
 
 	grpcapp "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/app/grpc"
 	httpapp "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/app/http"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/app/worker"
 	client "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/client"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/config"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	httprouter "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/http"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/agreement"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/minio"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/payment"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/postgres"
 	beat "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service"
 	beatstore "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/store"
 )
 
+// envProd is the environment of production, where the fake payment provider is refused.
+const envProd = "prod"
+
 type App struct {
-	GRPCServer *grpcapp.App
-	Pg         *postgres.Postgres
-	HTTPServer *httpapp.App
-	Mio        *minio.Minio
+	GRPCServer     *grpcapp.App
+	Pg             *postgres.Postgres
+	HTTPServer     *httpapp.App
+	Mio            *minio.Minio
+	TrendingWorker *worker.App
+	// BeatmakersWorker copies beatmaker names from the user service for suggestions.
+	BeatmakersWorker *worker.App
 }
 
 func New(ctx context.Context,
@@ -44,7 +56,58 @@ func New(ctx context.Context,
 	// Store
 	beatStore := beatstore.New(mio, pg, cfg.Minio.Bucket, log)
 
+	// gRPC client
+	gRPCUserClient, err := client.NewUserClient(ctx,
+		cfg.GrpcClient.Port,
+		cfg.GrpcClient.Timeout,
+		cfg.GrpcClient.Retries,
+		cfg.GrpcClient.CacheTTL,
+		cfg.GrpcClient.CacheSize,
+		cfg.GrpcClient.Concurrency,
+		log)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := gRPCUserClient.Health(ctx); err != nil {
+		panic(err)
+	}
+
+	// License agreements
+	templates := agreement.Templates()
+	if cfg.Agreements.TemplatesDir != "" {
+		templates = os.DirFS(cfg.Agreements.TemplatesDir)
+	}
+
+	tiers := make([]string, 0, len(model.LicenseTiers))
+	for _, t := range model.LicenseTiers {
+		tiers = append(tiers, string(t))
+	}
+
+	agreementRenderer, err := agreement.New(templates, tiers)
+	if err != nil {
+		panic(err)
+	}
+
 	// Service
+	agreementServiceConfig := beat.NewAgreementServiceConfig(cfg.Licenses.Currency, cfg.PublicURL)
+	agreementService := beat.NewAgreementService(
+		beatStore,
+		beatStore,
+		beatStore,
+		gRPCUserClient,
+		agreementRenderer,
+		agreementServiceConfig,
+		log)
+
+	downloadServiceConfig := beat.NewDownloadServiceConfig(cfg.Downloads.Limit, cfg.UrlTtl)
+	downloadService := beat.NewDownloadService(
+		beatStore,
+		beatStore,
+		beatStore,
+		downloadServiceConfig,
+		log)
+
 	beatServiceConfig := beat.NewBeatServiceConfig(
 		cfg.FileSizeLimit,
 		cfg.ArchiveSizeLimit,
@@ -57,33 +120,169 @@ func New(ctx context.Context,
 		beatStore,
 		beatStore,
 		beatStore,
+		downloadService,
+		agreementService,
 		beatServiceConfig,
 		log)
 
-	// gRPC client
-	gRPCUserClient, err := client.NewUserClient(ctx,
-		cfg.GrpcClient.Port,
-		cfg.GrpcClient.Timeout,
-		cfg.GrpcClient.Retries,
+	recommendationService := beat.NewRecommendationService(
+		beatStore,
+		model.SimilarityWeights{
+			Bpm:       cfg.Similarity.Bpm,
+			BpmRange:  cfg.Similarity.BpmRange,
+			Key:       cfg.Similarity.Key,
+			Genres:    cfg.Similarity.Genres,
+			Moods:     cfg.Similarity.Moods,
+			Tags:      cfg.Similarity.Tags,
+			Beatmaker: cfg.Similarity.Beatmaker,
+		},
 		log)
-	if err != nil {
-		panic(err)
+
+	trendingServiceConfig := beat.NewTrendingServiceConfig(
+		cfg.Trending.PlayWeight,
+		cfg.Trending.AcquisitionWeight,
+		cfg.Trending.HalfLife,
+		cfg.Trending.Window)
+	trendingService := beat.NewTrendingService(
+		beatStore,
+		trendingServiceConfig,
+		log)
+
+	listeningServiceConfig := beat.NewListeningServiceConfig(
+		cfg.Listening.QualifiedPlayThreshold,
+		cfg.Listening.HeartbeatSlack)
+	listeningService := beat.NewListeningService(
+		beatStore,
+		beatStore,
+		listeningServiceConfig,
+		log)
+
+	likeService := beat.NewLikeService(
+		beatStore,
+		beatStore,
+		log)
+
+	playlistServiceConfig := beat.NewPlaylistServiceConfig(cfg.Playlists.MaxBeats)
+	playlistService := beat.NewPlaylistService(
+		beatStore,
+		beatStore,
+		playlistServiceConfig,
+		log)
+
+	taxonomyService := beat.NewTaxonomyService(
+		beatStore,
+		beatStore,
+		log)
+
+	tagServiceConfig := beat.NewTagServiceConfig(cfg.Tags.MaxPerBeat)
+	tagService := beat.NewTagService(
+		beatStore,
+		beatStore,
+		tagServiceConfig,
+		log)
+
+	suggestServiceConfig := beat.NewSuggestServiceConfig(cfg.Suggest.Timeout)
+	suggestService := beat.NewSuggestService(
+		beatStore,
+		beatStore,
+		gRPCUserClient,
+		suggestServiceConfig,
+		log)
+
+	beatmakerServiceConfig := beat.NewBeatmakerServiceConfig(
+		cfg.Storefront.LatestBeats,
+		cfg.Storefront.MaxPinned)
+	beatmakerService := beat.NewBeatmakerService(
+		beatStore,
+		beatStore,
+		gRPCUserClient,
+		beatmakerServiceConfig,
+		log)
+
+	feedServiceConfig := beat.NewFeedServiceConfig(cfg.Feeds.Limit)
+	feedService := beat.NewFeedService(
+		beatStore,
+		gRPCUserClient,
+		feedServiceConfig,
+		log)
+
+	// Payments
+	var (
+		paymentProvider beat.PaymentProvider
+		fakePayments    httprouter.FakePayments
+	)
+	switch cfg.Payments.Provider {
+	case "":
+		log.Warn("no payment provider, orders are off")
+	case payment.FakeName:
+		if cfg.Env == envProd {
+			panic("the fake payment provider cannot be used in prod")
+		}
+		if cfg.Payments.WebhookSecret == "" {
+			panic("payments.webhook_secret is required with a payment provider")
+		}
+		fake := payment.NewFake(cfg.Payments.WebhookSecret, cfg.PublicURL)
+		paymentProvider = fake
+		if cfg.Payments.FakeCheckout {
+			fakePayments = fake
+		}
+	default:
+		panic("unknown payment provider: " + cfg.Payments.Provider)
 	}
 
-	if err := gRPCUserClient.Health(ctx); err != nil {
-		panic(err)
+	licenseServiceConfig := beat.NewLicenseServiceConfig(cfg.Licenses.Currency)
+	licenseService := beat.NewLicenseService(
+		beatStore,
+		beatStore,
+		gRPCUserClient,
+		paymentProvider,
+		licenseServiceConfig,
+		log)
+
+	var orderService httprouter.OrderProvider
+	if paymentProvider != nil {
+		orderServiceConfig := beat.NewOrderServiceConfig(cfg.Licenses.Currency, cfg.UrlTtl, cfg.Payments.CheckoutTTL)
+		orderService = beat.NewOrderService(
+			beatStore,
+			beatStore,
+			beatStore,
+			paymentProvider,
+			downloadService,
+			agreementService,
+			orderServiceConfig,
+			log)
 	}
 
+	salesServiceConfig := beat.NewSalesServiceConfig(cfg.Licenses.Currency, cfg.Downloads.Limit, cfg.UrlTtl)
+	salesService := beat.NewSalesService(
+		beatStore,
+		beatStore,
+		salesServiceConfig,
+		log)
+
+	collaboratorServiceConfig := beat.NewCollaboratorServiceConfig(cfg.Licenses.Currency)
+	collaboratorService := beat.NewCollaboratorService(
+		beatStore,
+		beatStore,
+		collaboratorServiceConfig,
+		log)
+
 	// gRPC server
 	gRPCApp := grpcapp.New(ctx, cfg, beatService, gRPCUserClient, log)
 
 	// HTTP server
-	httpApp := httpapp.New(ctx, cfg, beatService, gRPCUserClient, log)
+	httpApp := httpapp.New(ctx, cfg, beatService, recommendationService, listeningService, likeService, playlistService, taxonomyService, tagService, suggestService, beatmakerService, feedService, licenseService, orderService, salesService, downloadService, collaboratorService, fakePayments, gRPCUserClient, log)
+
+	// Workers
+	trendingWorker := worker.New("trending", cfg.Trending.RefreshInterval, trendingService.RefreshTrending, log)
+	beatmakersWorker := worker.New("beatmakers", cfg.Suggest.BeatmakersRefreshInterval, suggestService.RefreshBeatmakers, log)
 
 	return &App{
-		GRPCServer: gRPCApp,
-		Pg:         pg,
-		Mio:        mio,
-		HTTPServer: httpApp,
+		GRPCServer:       gRPCApp,
+		Pg:               pg,
+		Mio:              mio,
+		HTTPServer:       httpApp,
+		TrendingWorker:   trendingWorker,
+		BeatmakersWorker: beatmakersWorker,
 	}
 }
@@ -73,7 +73,7 @@ func New(
 	gRPCServer := grpc.NewServer(opts...)
 
 	// Register services
-	audio.Register(gRPCServer, beatService, beatService, beatService, grpcUserClient, log)
+	audio.Register(gRPCServer, beatService, beatService, beatService, grpcUserClient, cfg.Locale.Default, log)
 
 	return &App{
 		gRPCServer: gRPCServer,
@@ -16,6 +16,7 @@ import (
 	"github.com/rs/cors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 type App struct {
@@ -29,6 +30,21 @@ func New(
 	ctx context.Context,
 	cfg *config.Config,
 	beatService *beat.BeatService,
+	recommendationService *beat.RecommendationService,
+	listeningService *beat.ListeningService,
+	likeService *beat.LikeService,
+	playlistService *beat.PlaylistService,
+	taxonomyService *beat.TaxonomyService,
+	tagService *beat.TagService,
+	suggestService *beat.SuggestService,
+	beatmakerService *beat.BeatmakerService,
+	feedService *beat.FeedService,
+	licenseService *beat.LicenseService,
+	orderService router.OrderProvider,
+	salesService *beat.SalesService,
+	downloadService *beat.DownloadService,
+	collaboratorService *beat.CollaboratorService,
+	fakePayments router.FakePayments,
 	grpcUserClient *client.Client,
 	log *slog.Logger,
 ) *App {
@@ -42,8 +58,13 @@ func New(
 		panic(err)
 	}
 
-	gwmux := runtime.NewServeMux()
-	router.NewRouter(gwmux, beatService, beatService, log)
+	trustedProxies, err := router.ParseTrustedProxies(cfg.Downloads.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
+
+	gwmux := runtime.NewServeMux(runtime.WithMetadata(localeMetadata), runtime.WithMetadata(idempotencyKeyMetadata))
+	router.NewRouter(gwmux, beatService, beatService, recommendationService, listeningService, likeService, playlistService, taxonomyService, tagService, suggestService, beatmakerService, feedService, licenseService, orderService, salesService, downloadService, collaboratorService, fakePayments, grpcUserClient, cfg.JwtSecret, cfg.Locale.Default, cfg.PublicURL, trustedProxies, log)
 
 	// Register user
 	err = audiov1.RegisterBeatServiceHandler(ctx, gwmux, conn)
@@ -91,6 +112,24 @@ func (app *App) Stop(ctx context.Context) {
 	}
 }
 
+// localeMetadata passes the locale query parameter of gateway requests to the gRPC server,
+// which picks it over Accept-Language.
+func localeMetadata(ctx context.Context, req *http.Request) metadata.MD {
+	if l := req.URL.Query().Get("locale"); l != "" {
+		return metadata.Pairs("locale", l)
+	}
+	return nil
+}
+
+// idempotencyKeyMetadata passes the Idempotency-Key header of gateway requests to the gRPC
+// server, so retried purchases are not charged twice.
+func idempotencyKeyMetadata(ctx context.Context, req *http.Request) metadata.MD {
+	if k := req.Header.Get("Idempotency-Key"); k != "" {
+		return metadata.Pairs("idempotency-key", k)
+	}
+	return nil
+}
+
 func interceptorLogger(h http.Handler, log *slog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		log.DebugContext(req.Context(), req.URL.String())
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+)
+
+// Job is run by the worker on every tick. Errors are logged and the job is retried on the next tick.
+type Job func(ctx context.Context) error
+
+type App struct {
+	interval time.Duration
+	job      Job
+	stop     chan struct{}
+	done     chan struct{}
+	log      *slog.Logger
+}
+
+func New(name string, interval time.Duration, job Job, log *slog.Logger) *App {
+	return &App{
+		interval: interval,
+		job:      job,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		log:      log.With(slog.String("worker", name)),
+	}
+}
+
+func (a *App) MustRun(ctx context.Context) {
+	if err := a.Run(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Run runs the job right away and then every interval until Stop is called.
+func (a *App) Run(ctx context.Context) error {
+	defer close(a.done)
+
+	a.log.Info("worker started", slog.Duration("interval", a.interval))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		<-a.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.job(ctx); err != nil && ctx.Err() == nil {
+			a.log.Error("job failed", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *App) Stop(ctx context.Context) {
+	a.log.Info("stopping worker")
+
+	close(a.stop)
+
+	select {
+	case <-a.done:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/google/uuid"
+)
+
+type cacheEntry struct {
+	user    *userv1.GetUserResponse
+	expires time.Time
+}
+
+// userCache keeps user profiles for ttl. Expired entries are dropped when the cache
+// grows past size, so it holds at most size entries that are still fresh.
+type userCache struct {
+	mu      sync.RWMutex
+	entries map[uuid.UUID]cacheEntry
+	ttl     time.Duration
+	size    int
+	now     func() time.Time
+}
+
+func newUserCache(ttl time.Duration, size int) *userCache {
+	return &userCache{
+		entries: make(map[uuid.UUID]cacheEntry),
+		ttl:     ttl,
+		size:    size,
+		now:     time.Now,
+	}
+}
+
+func (c *userCache) get(id uuid.UUID) (*userv1.GetUserResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[id]
+	if !ok || c.now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.user, true
+}
+
+func (c *userCache) set(id uuid.UUID, user *userv1.GetUserResponse) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[id]; !ok && len(c.entries) >= c.size {
+		for k, v := range c.entries {
+			if now.After(v.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= c.size {
+			return
+		}
+	}
+
+	c.entries[id] = cacheEntry{user: user, expires: now.Add(c.ttl)}
+}
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"log/slog"
+	"sync"
 	"time"
 
 	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
@@ -16,14 +17,19 @@ import (
 )
 
 type Client struct {
-	api userv1.UserServiceClient
-	log *slog.Logger
+	api         userv1.UserServiceClient
+	cache       *userCache
+	concurrency int
+	log         *slog.Logger
 }
 
 func NewUserClient(ctx context.Context,
 	addr string,
 	timeout time.Duration,
 	retriesCount uint,
+	cacheTTL time.Duration,
+	cacheSize int,
+	concurrency int,
 	log *slog.Logger,
 ) (*Client, error) {
 	retryOpts := []retry.CallOption{
@@ -48,8 +54,10 @@ func NewUserClient(ctx context.Context,
 	}
 
 	return &Client{
-		api: userv1.NewUserServiceClient(cc),
-		log: log,
+		api:         userv1.NewUserServiceClient(cc),
+		cache:       newUserCache(cacheTTL, cacheSize),
+		concurrency: max(concurrency, 1),
+		log:         log,
 	}, nil
 }
 
@@ -77,11 +85,57 @@ func (c *Client) Health(ctx context.Context) error {
 }
 
 func (c *Client) GetUser(ctx context.Context, id uuid.UUID) (*userv1.GetUserResponse, error) {
+	if user, ok := c.cache.get(id); ok {
+		return user, nil
+	}
+
 	user, err := c.api.GetUser(ctx, &userv1.GetUserRequest{UserId: id.String()})
 	if err != nil {
 		c.log.Error("failed to get user", sl.Err(err))
 		return nil, err
 	}
 
+	c.cache.set(id, user)
 	return user, err
 }
+
+// GetUsers resolves the users by id. Every id is requested once, cached users are not
+// requested at all and the rest are requested concurrently. The user service has no
+// lookup by a list of ids, so a request per missing user is still made. Users that can
+// not be resolved are missing from the result instead of failing the whole batch.
+func (c *Client) GetUsers(ctx context.Context, ids []uuid.UUID) map[uuid.UUID]*userv1.GetUserResponse {
+	users := make(map[uuid.UUID]*userv1.GetUserResponse, len(ids))
+	missing := make(map[uuid.UUID]struct{})
+	for _, id := range ids {
+		if user, ok := c.cache.get(id); ok {
+			users[id] = user
+		} else {
+			missing[id] = struct{}{}
+		}
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, c.concurrency)
+	)
+	for id := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if user, err := c.GetUser(ctx, id); err == nil {
+				mu.Lock()
+				users[id] = user
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return users
+}
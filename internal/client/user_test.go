@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeUserService struct {
+	userv1.UserServiceClient
+	mu     sync.Mutex
+	calls  map[string]int
+	failed map[string]bool
+}
+
+func (f *fakeUserService) GetUser(ctx context.Context, in *userv1.GetUserRequest, opts ...grpc.CallOption) (*userv1.GetUserResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls[in.UserId]++
+	if f.failed[in.UserId] {
+		return nil, errors.New("unavailable")
+	}
+	return &userv1.GetUserResponse{UserId: in.UserId, Username: "user-" + in.UserId}, nil
+}
+
+func newTestClient(api userv1.UserServiceClient) *Client {
+	return &Client{
+		api:         api,
+		cache:       newUserCache(time.Minute, 100),
+		concurrency: 4,
+		log:         slogdiscard.NewDiscardLogger(),
+	}
+}
+
+func TestGetUsers_Deduplicates(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeUserService{calls: map[string]int{}}
+	c := newTestClient(api)
+
+	a, b := uuid.New(), uuid.New()
+
+	users := c.GetUsers(context.Background(), []uuid.UUID{a, b, a, a})
+	assert.Len(t, users, 2)
+	assert.Equal(t, a.String(), users[a].UserId)
+	assert.Equal(t, 1, api.calls[a.String()])
+	assert.Equal(t, 1, api.calls[b.String()])
+
+	users = c.GetUsers(context.Background(), []uuid.UUID{a, b})
+	assert.Len(t, users, 2)
+	assert.Equal(t, 1, api.calls[a.String()])
+	assert.Equal(t, 1, api.calls[b.String()])
+}
+
+func TestGetUsers_SkipsFailed(t *testing.T) {
+	t.Parallel()
+
+	a, b := uuid.New(), uuid.New()
+	api := &fakeUserService{calls: map[string]int{}, failed: map[string]bool{b.String(): true}}
+	c := newTestClient(api)
+
+	users := c.GetUsers(context.Background(), []uuid.UUID{a, b})
+	assert.Len(t, users, 1)
+	assert.Contains(t, users, a)
+
+	c.GetUsers(context.Background(), []uuid.UUID{b})
+	assert.Equal(t, 2, api.calls[b.String()])
+}
+
+func TestUserCache_Expires(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := newUserCache(time.Minute, 1)
+	c.now = func() time.Time { return now }
+
+	a, b := uuid.New(), uuid.New()
+
+	c.set(a, &userv1.GetUserResponse{})
+	_, ok := c.get(a)
+	assert.True(t, ok)
+
+	c.set(b, &userv1.GetUserResponse{})
+	_, ok = c.get(b)
+	assert.False(t, ok, "full cache must not grow")
+
+	now = now.Add(2 * time.Minute)
+	_, ok = c.get(a)
+	assert.False(t, ok)
+
+	c.set(b, &userv1.GetUserResponse{})
+	_, ok = c.get(b)
+	assert.True(t, ok, "expired entries must make room")
+}
@@ -15,6 +15,7 @@ type Config struct {
 	Tls                Tls        `yaml:"tls"`
 	GrpcPort           string     `yaml:"grpc_port" env-required:"true"`
 	HttpPort           string     `yaml:"http_port" env-required:"true"`
+	PublicURL          string     `yaml:"public_url" env:"PUBLIC_URL"`
 	VerificationSecret string     `yaml:"verification_secret" env-required:"true"`
 	UrlTtl             int        `yaml:"url_ttl" env-required:"true"`
 	FileSizeLimit      int64      `yaml:"file_size_limit" env-required:"true"`
@@ -22,6 +23,19 @@ type Config struct {
 	ImageSizeLimit     int64      `yaml:"image_size_limit" env-required:"true"`
 	Minio              Minio      `yaml:"minio" env-required:"true"`
 	GrpcClient         GrpcClient `yaml:"grpc_client" env-required:"true"`
+	Similarity         Similarity `yaml:"similarity"`
+	Trending           Trending   `yaml:"trending"`
+	Listening          Listening  `yaml:"listening"`
+	Playlists          Playlists  `yaml:"playlists"`
+	Locale             Locale     `yaml:"locale"`
+	Tags               Tags       `yaml:"tags"`
+	Suggest            Suggest    `yaml:"suggest"`
+	Storefront         Storefront `yaml:"storefront"`
+	Feeds              Feeds      `yaml:"feeds"`
+	Licenses           Licenses   `yaml:"licenses"`
+	Payments           Payments   `yaml:"payments"`
+	Agreements         Agreements `yaml:"agreements"`
+	Downloads          Downloads  `yaml:"downloads"`
 }
 
 type Tls struct {
@@ -39,9 +53,103 @@ type Minio struct {
 }
 
 type GrpcClient struct {
-	Retries uint          `yaml:"retries" env-required:"true"`
-	Timeout time.Duration `yaml:"timeout" env-required:"true"`
-	Port    string        `yaml:"port" env-required:"true"`
+	Retries     uint          `yaml:"retries" env-required:"true"`
+	Timeout     time.Duration `yaml:"timeout" env-required:"true"`
+	Port        string        `yaml:"port" env-required:"true"`
+	CacheTTL    time.Duration `yaml:"cache_ttl" env-default:"5m"`
+	CacheSize   int           `yaml:"cache_size" env-default:"10000"`
+	Concurrency int           `yaml:"concurrency" env-default:"8"`
+}
+
+// Similarity holds the weights of the similar beats score.
+type Similarity struct {
+	Bpm       float64 `yaml:"bpm" env-default:"1"`
+	BpmRange  int64   `yaml:"bpm_range" env-default:"20"`
+	Key       float64 `yaml:"key" env-default:"1"`
+	Genres    float64 `yaml:"genres" env-default:"1"`
+	Moods     float64 `yaml:"moods" env-default:"0.5"`
+	Tags      float64 `yaml:"tags" env-default:"0.5"`
+	Beatmaker float64 `yaml:"beatmaker" env-default:"0.25"`
+}
+
+// Trending holds the weights and decay of the trending score.
+type Trending struct {
+	PlayWeight        float64       `yaml:"play_weight" env-default:"1"`
+	AcquisitionWeight float64       `yaml:"acquisition_weight" env-default:"10"`
+	HalfLife          time.Duration `yaml:"half_life" env-default:"72h"`
+	Window            time.Duration `yaml:"window" env-default:"168h"`
+	RefreshInterval   time.Duration `yaml:"refresh_interval" env-default:"5m"`
+}
+
+// Listening holds the rules of qualified plays.
+type Listening struct {
+	QualifiedPlayThreshold time.Duration `yaml:"qualified_play_threshold" env-default:"30s"`
+	HeartbeatSlack         time.Duration `yaml:"heartbeat_slack" env-default:"10s"`
+}
+
+// Playlists holds the limits of user playlists.
+type Playlists struct {
+	MaxBeats int64 `yaml:"max_beats" env-default:"500"`
+}
+
+// Locale holds the locale of genre, tag, mood and note names for requests that do not
+// ask for one or ask for one without translations.
+type Locale struct {
+	Default string `yaml:"default" env-default:"en"`
+}
+
+// Tags holds the limits of free-form beat tags.
+type Tags struct {
+	MaxPerBeat int `yaml:"max_per_beat" env-default:"10"`
+}
+
+// Suggest holds the latency budget of search box suggestions and how often the beatmaker
+// names they are matched against are copied from the user service.
+type Suggest struct {
+	Timeout                   time.Duration `yaml:"timeout" env-default:"150ms"`
+	BeatmakersRefreshInterval time.Duration `yaml:"beatmakers_refresh_interval" env-default:"10m"`
+}
+
+// Storefront holds the limits of the beatmaker storefront.
+type Storefront struct {
+	LatestBeats uint64 `yaml:"latest_beats" env-default:"10"`
+	MaxPinned   int    `yaml:"max_pinned" env-default:"6"`
+}
+
+// Feeds holds the number of beats in the RSS and Atom feeds.
+type Feeds struct {
+	Limit uint64 `yaml:"limit" env-default:"50"`
+}
+
+// Licenses holds the currency of the license tier prices, which are in its minor units.
+type Licenses struct {
+	Currency string `yaml:"currency" env-default:"RUB"`
+}
+
+// Payments holds the payment provider orders are paid through and the secret of its
+// webhook signatures. Without a provider there are no orders, licenses are only sold
+// through AcquireBeat. Only the in-process fake provider is available, it is refused in
+// prod. FakeCheckout serves the checkout pages of the fake provider, for development only.
+// A pending order no longer holds back new orders of its tier after CheckoutTTL.
+type Payments struct {
+	Provider      string        `yaml:"provider" env:"PAYMENTS_PROVIDER"`
+	WebhookSecret string        `yaml:"webhook_secret" env:"PAYMENTS_WEBHOOK_SECRET"`
+	FakeCheckout  bool          `yaml:"fake_checkout" env-default:"false"`
+	CheckoutTTL   time.Duration `yaml:"checkout_ttl" env-default:"30m"`
+}
+
+// Agreements holds the directory of the license agreement templates: _common.tmpl and
+// a <tier>.tmpl per license tier. The built-in templates are used if it is empty.
+type Agreements struct {
+	TemplatesDir string `yaml:"templates_dir" env:"AGREEMENTS_TEMPLATES_DIR"`
+}
+
+// Downloads holds how many download links are issued per license, zero is unlimited.
+// Admins can reset the counter of a license. TrustedProxies are the addresses or CIDRs
+// of the proxies whose X-Forwarded-For names the client in the download audit log.
+type Downloads struct {
+	Limit          int32    `yaml:"limit" env-default:"5"`
+	TrustedProxies []string `yaml:"trusted_proxies" env:"DOWNLOADS_TRUSTED_PROXIES" env-separator:","`
 }
 
 func MustLoad() *Config {
@@ -12,6 +12,181 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BeatSignal string
+
+const (
+	BeatSignalPlay        BeatSignal = "play"
+	BeatSignalAcquisition BeatSignal = "acquisition"
+)
+
+func (e *BeatSignal) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BeatSignal(s)
+	case string:
+		*e = BeatSignal(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BeatSignal: %T", src)
+	}
+	return nil
+}
+
+type NullBeatSignal struct {
+	BeatSignal BeatSignal
+	Valid      bool // Valid is true if BeatSignal is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBeatSignal) Scan(value interface{}) error {
+	if value == nil {
+		ns.BeatSignal, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BeatSignal.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBeatSignal) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BeatSignal), nil
+}
+
+type CollaboratorRole string
+
+const (
+	CollaboratorRoleProducer   CollaboratorRole = "producer"
+	CollaboratorRoleCoProducer CollaboratorRole = "co_producer"
+	CollaboratorRoleComposer   CollaboratorRole = "composer"
+	CollaboratorRoleSongwriter CollaboratorRole = "songwriter"
+	CollaboratorRoleEngineer   CollaboratorRole = "engineer"
+	CollaboratorRoleFeatured   CollaboratorRole = "featured"
+)
+
+func (e *CollaboratorRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CollaboratorRole(s)
+	case string:
+		*e = CollaboratorRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CollaboratorRole: %T", src)
+	}
+	return nil
+}
+
+type NullCollaboratorRole struct {
+	CollaboratorRole CollaboratorRole
+	Valid            bool // Valid is true if CollaboratorRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCollaboratorRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.CollaboratorRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CollaboratorRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCollaboratorRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CollaboratorRole), nil
+}
+
+type LicenseAuditAction string
+
+const (
+	LicenseAuditActionDownload LicenseAuditAction = "download"
+	LicenseAuditActionReset    LicenseAuditAction = "reset"
+	LicenseAuditActionRevoke   LicenseAuditAction = "revoke"
+)
+
+func (e *LicenseAuditAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LicenseAuditAction(s)
+	case string:
+		*e = LicenseAuditAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LicenseAuditAction: %T", src)
+	}
+	return nil
+}
+
+type NullLicenseAuditAction struct {
+	LicenseAuditAction LicenseAuditAction
+	Valid              bool // Valid is true if LicenseAuditAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLicenseAuditAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.LicenseAuditAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LicenseAuditAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLicenseAuditAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LicenseAuditAction), nil
+}
+
+type LicenseTier string
+
+const (
+	LicenseTierMp3Lease  LicenseTier = "mp3_lease"
+	LicenseTierWavLease  LicenseTier = "wav_lease"
+	LicenseTierTrackout  LicenseTier = "trackout"
+	LicenseTierExclusive LicenseTier = "exclusive"
+)
+
+func (e *LicenseTier) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LicenseTier(s)
+	case string:
+		*e = LicenseTier(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LicenseTier: %T", src)
+	}
+	return nil
+}
+
+type NullLicenseTier struct {
+	LicenseTier LicenseTier
+	Valid       bool // Valid is true if LicenseTier is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLicenseTier) Scan(value interface{}) error {
+	if value == nil {
+		ns.LicenseTier, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LicenseTier.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLicenseTier) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LicenseTier), nil
+}
+
 type NoteScale string
 
 const (
@@ -54,6 +229,94 @@ func (ns NullNoteScale) Value() (driver.Value, error) {
 	return string(ns.NoteScale), nil
 }
 
+type OrderStatus string
+
+const (
+	OrderStatusPending  OrderStatus = "pending"
+	OrderStatusPaid     OrderStatus = "paid"
+	OrderStatusFailed   OrderStatus = "failed"
+	OrderStatusRejected OrderStatus = "rejected"
+	OrderStatusRefunded OrderStatus = "refunded"
+)
+
+func (e *OrderStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OrderStatus(s)
+	case string:
+		*e = OrderStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OrderStatus: %T", src)
+	}
+	return nil
+}
+
+type NullOrderStatus struct {
+	OrderStatus OrderStatus
+	Valid       bool // Valid is true if OrderStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOrderStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.OrderStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OrderStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOrderStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OrderStatus), nil
+}
+
+type PlaylistVisibility string
+
+const (
+	PlaylistVisibilityPrivate  PlaylistVisibility = "private"
+	PlaylistVisibilityUnlisted PlaylistVisibility = "unlisted"
+	PlaylistVisibilityPublic   PlaylistVisibility = "public"
+)
+
+func (e *PlaylistVisibility) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PlaylistVisibility(s)
+	case string:
+		*e = PlaylistVisibility(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PlaylistVisibility: %T", src)
+	}
+	return nil
+}
+
+type NullPlaylistVisibility struct {
+	PlaylistVisibility PlaylistVisibility
+	Valid              bool // Valid is true if PlaylistVisibility is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPlaylistVisibility) Scan(value interface{}) error {
+	if value == nil {
+		ns.PlaylistVisibility, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PlaylistVisibility.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPlaylistVisibility) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PlaylistVisibility), nil
+}
+
 type Beat struct {
 	ID                  uuid.UUID
 	BeatmakerID         uuid.UUID
@@ -73,6 +336,50 @@ type Beat struct {
 	Bpm                 int32
 }
 
+type BeatAcquisition struct {
+	UserID         uuid.UUID
+	IdempotencyKey string
+	BeatID         uuid.UUID
+	Tier           LicenseTier
+	CreatedAt      pgtype.Timestamp
+}
+
+type BeatLicense struct {
+	BeatID          uuid.UUID
+	UserID          uuid.UUID
+	Tier            LicenseTier
+	Price           int64
+	Deliverables    []string
+	StreamCap       *int32
+	DistributionCap *int32
+	CreatedAt       pgtype.Timestamp
+	Number          string
+	AgreementPath   *string
+	Downloads       int32
+	RevokedAt       pgtype.Timestamp
+}
+
+type Beatmaker struct {
+	ID        uuid.UUID
+	Username  string
+	Pseudonym string
+	UpdatedAt pgtype.Timestamp
+}
+
+type BeatmakersPinnedBeat struct {
+	BeatmakerID uuid.UUID
+	BeatID      uuid.UUID
+	Position    int32
+}
+
+type BeatsCollaborator struct {
+	BeatID    uuid.UUID
+	UserID    uuid.UUID
+	Role      CollaboratorRole
+	Share     int32
+	CreatedAt pgtype.Timestamp
+}
+
 type BeatsEvent struct {
 	EventTime pgtype.Timestamp
 	EventData []byte
@@ -84,6 +391,24 @@ type BeatsGenre struct {
 	GenreID uuid.UUID
 }
 
+type BeatsLicenseTier struct {
+	BeatID          uuid.UUID
+	Tier            LicenseTier
+	Price           int64
+	Deliverables    []string
+	StreamCap       *int32
+	DistributionCap *int32
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+	SalesCap        *int32
+}
+
+type BeatsLike struct {
+	BeatID    uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt pgtype.Timestamp
+}
+
 type BeatsMood struct {
 	ID     uuid.UUID
 	BeatID uuid.UUID
@@ -97,9 +422,11 @@ type BeatsNote struct {
 	Scale  NoteScale
 }
 
-type BeatsOwner struct {
-	BeatID uuid.UUID
-	UserID uuid.UUID
+type BeatsSignal struct {
+	ID        uuid.UUID
+	BeatID    uuid.UUID
+	Kind      BeatSignal
+	CreatedAt pgtype.Timestamp
 }
 
 type BeatsTag struct {
@@ -108,22 +435,139 @@ type BeatsTag struct {
 	TagID  uuid.UUID
 }
 
+type BeatsTrending struct {
+	BeatID    uuid.UUID
+	Score     float64
+	UpdatedAt pgtype.Timestamp
+}
+
 type Genre struct {
-	ID   uuid.UUID
-	Name string
+	ID         uuid.UUID
+	Name       string
+	Slug       string
+	Position   int32
+	IsArchived bool
+}
+
+type GenresTranslation struct {
+	GenreID uuid.UUID
+	Locale  string
+	Name    string
+}
+
+type LicenseAudit struct {
+	ID        uuid.UUID
+	BeatID    uuid.UUID
+	UserID    uuid.UUID
+	Tier      LicenseTier
+	Action    LicenseAuditAction
+	ActorID   pgtype.UUID
+	Ip        *string
+	UserAgent *string
+	Url       *string
+	ExpiresAt pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+	Reason    *string
+}
+
+type LicenseEarning struct {
+	BeatID         uuid.UUID
+	UserID         uuid.UUID
+	Tier           LicenseTier
+	CollaboratorID uuid.UUID
+	Role           CollaboratorRole
+	Share          int32
+	Amount         int64
+	CreatedAt      pgtype.Timestamp
+}
+
+type ListeningSession struct {
+	ID            uuid.UUID
+	BeatID        uuid.UUID
+	UserID        pgtype.UUID
+	Source        string
+	Client        string
+	SecondsPlayed int32
+	IsQualified   bool
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
 }
 
 type Mood struct {
-	ID   uuid.UUID
-	Name string
+	ID         uuid.UUID
+	Name       string
+	Slug       string
+	Position   int32
+	IsArchived bool
+}
+
+type MoodsTranslation struct {
+	MoodID uuid.UUID
+	Locale string
+	Name   string
 }
 
 type Note struct {
-	ID   uuid.UUID
-	Name string
+	ID         uuid.UUID
+	Name       string
+	Slug       string
+	Position   int32
+	IsArchived bool
+}
+
+type NotesTranslation struct {
+	NoteID uuid.UUID
+	Locale string
+	Name   string
+}
+
+type Order struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	BeatID          uuid.UUID
+	Tier            LicenseTier
+	Price           int64
+	Currency        string
+	Deliverables    []string
+	StreamCap       *int32
+	DistributionCap *int32
+	SalesCap        *int32
+	Status          OrderStatus
+	Provider        string
+	CheckoutID      *string
+	CheckoutUrl     *string
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+	PaidAt          pgtype.Timestamp
+}
+
+type Playlist struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Visibility PlaylistVisibility
+	ShareToken string
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+}
+
+type PlaylistsBeat struct {
+	PlaylistID uuid.UUID
+	BeatID     uuid.UUID
+	Position   int32
+	CreatedAt  pgtype.Timestamp
 }
 
 type Tag struct {
-	ID   uuid.UUID
-	Name string
+	ID         uuid.UUID
+	Name       string
+	Slug       string
+	Position   int32
+	IsArchived bool
+}
+
+type TagsTranslation struct {
+	TagID  uuid.UUID
+	Locale string
+	Name   string
 }
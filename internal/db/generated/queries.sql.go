@@ -9,8 +9,50 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countBeatLikes = `-- name: CountBeatLikes :one
+select count(*) from beats_likes where "beat_id" = $1
+`
+
+func (q *Queries) CountBeatLikes(ctx context.Context, beatID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countBeatLikes, beatID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countBeatmakerBeats = `-- name: CountBeatmakerBeats :one
+select count(*) from beats b
+where (b."beatmaker_id" = $1
+       or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = $1))
+  and b."id" = any($2::uuid[]) and b."is_deleted" = false
+`
+
+type CountBeatmakerBeatsParams struct {
+	BeatmakerID uuid.UUID
+	BeatIds     []uuid.UUID
+}
+
+func (q *Queries) CountBeatmakerBeats(ctx context.Context, arg CountBeatmakerBeatsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countBeatmakerBeats, arg.BeatmakerID, arg.BeatIds)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPlaylistBeats = `-- name: CountPlaylistBeats :one
+select count(*) from playlists_beats where "playlist_id" = $1
+`
+
+func (q *Queries) CountPlaylistBeats(ctx context.Context, playlistID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countPlaylistBeats, playlistID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteBeat = `-- name: DeleteBeat :exec
 update beats
 set "is_deleted" = true,
@@ -23,6 +65,15 @@ func (q *Queries) DeleteBeat(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteBeatCollaborators = `-- name: DeleteBeatCollaborators :exec
+delete from beats_collaborators where "beat_id" = $1
+`
+
+func (q *Queries) DeleteBeatCollaborators(ctx context.Context, beatID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteBeatCollaborators, beatID)
+	return err
+}
+
 const deleteBeatGenres = `-- name: DeleteBeatGenres :exec
 delete from beats_genres where beat_id = $1
 `
@@ -59,6 +110,87 @@ func (q *Queries) DeleteBeatTags(ctx context.Context, beatID uuid.UUID) error {
 	return err
 }
 
+const deleteLicenseTiers = `-- name: DeleteLicenseTiers :exec
+delete from beats_license_tiers where "beat_id" = $1
+`
+
+func (q *Queries) DeleteLicenseTiers(ctx context.Context, beatID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteLicenseTiers, beatID)
+	return err
+}
+
+const deleteLike = `-- name: DeleteLike :exec
+delete from beats_likes where "beat_id" = $1 and "user_id" = $2
+`
+
+type DeleteLikeParams struct {
+	BeatID uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) DeleteLike(ctx context.Context, arg DeleteLikeParams) error {
+	_, err := q.db.Exec(ctx, deleteLike, arg.BeatID, arg.UserID)
+	return err
+}
+
+const deletePinnedBeats = `-- name: DeletePinnedBeats :exec
+delete from beatmakers_pinned_beats where "beatmaker_id" = $1
+`
+
+func (q *Queries) DeletePinnedBeats(ctx context.Context, beatmakerID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePinnedBeats, beatmakerID)
+	return err
+}
+
+const deletePlaylist = `-- name: DeletePlaylist :exec
+delete from playlists where "id" = $1
+`
+
+func (q *Queries) DeletePlaylist(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePlaylist, id)
+	return err
+}
+
+const deletePlaylistBeat = `-- name: DeletePlaylistBeat :one
+delete from playlists_beats
+where "playlist_id" = $1 and "beat_id" = $2
+returning "position"
+`
+
+type DeletePlaylistBeatParams struct {
+	PlaylistID uuid.UUID
+	BeatID     uuid.UUID
+}
+
+func (q *Queries) DeletePlaylistBeat(ctx context.Context, arg DeletePlaylistBeatParams) (int32, error) {
+	row := q.db.QueryRow(ctx, deletePlaylistBeat, arg.PlaylistID, arg.BeatID)
+	var position int32
+	err := row.Scan(&position)
+	return position, err
+}
+
+const getAcquisition = `-- name: GetAcquisition :one
+select user_id, idempotency_key, beat_id, tier, created_at from beat_acquisitions where "user_id" = $1 and "idempotency_key" = $2
+`
+
+type GetAcquisitionParams struct {
+	UserID         uuid.UUID
+	IdempotencyKey string
+}
+
+func (q *Queries) GetAcquisition(ctx context.Context, arg GetAcquisitionParams) (BeatAcquisition, error) {
+	row := q.db.QueryRow(ctx, getAcquisition, arg.UserID, arg.IdempotencyKey)
+	var i BeatAcquisition
+	err := row.Scan(
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.BeatID,
+		&i.Tier,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getBeatByID = `-- name: GetBeatByID :one
 select id, beatmaker_id, file_path, image_path, archive_path, name, description, is_file_downloaded, is_image_downloaded, is_archive_downloaded, range_start, range_end, is_deleted, created_at, updated_at, bpm from beats where id = $1
 `
@@ -87,8 +219,68 @@ func (q *Queries) GetBeatByID(ctx context.Context, id uuid.UUID) (Beat, error) {
 	return i, err
 }
 
+const getBeatCollaborators = `-- name: GetBeatCollaborators :many
+select beat_id, user_id, role, share, created_at from beats_collaborators where "beat_id" = $1 order by "share" desc, "user_id"
+`
+
+func (q *Queries) GetBeatCollaborators(ctx context.Context, beatID uuid.UUID) ([]BeatsCollaborator, error) {
+	rows, err := q.db.Query(ctx, getBeatCollaborators, beatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BeatsCollaborator
+	for rows.Next() {
+		var i BeatsCollaborator
+		if err := rows.Scan(
+			&i.BeatID,
+			&i.UserID,
+			&i.Role,
+			&i.Share,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBeatForUpdate = `-- name: GetBeatForUpdate :one
+select id, beatmaker_id, file_path, image_path, archive_path, name, description, is_file_downloaded, is_image_downloaded, is_archive_downloaded, range_start, range_end, is_deleted, created_at, updated_at, bpm from beats where id = $1 for update
+`
+
+func (q *Queries) GetBeatForUpdate(ctx context.Context, id uuid.UUID) (Beat, error) {
+	row := q.db.QueryRow(ctx, getBeatForUpdate, id)
+	var i Beat
+	err := row.Scan(
+		&i.ID,
+		&i.BeatmakerID,
+		&i.FilePath,
+		&i.ImagePath,
+		&i.ArchivePath,
+		&i.Name,
+		&i.Description,
+		&i.IsFileDownloaded,
+		&i.IsImageDownloaded,
+		&i.IsArchiveDownloaded,
+		&i.RangeStart,
+		&i.RangeEnd,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Bpm,
+	)
+	return i, err
+}
+
 const getBeatGenreParams = `-- name: GetBeatGenreParams :many
-select id, name from genres
+select id, name, slug, position, is_archived from genres
+where "is_archived" = false
+order by "position", "name"
 `
 
 func (q *Queries) GetBeatGenreParams(ctx context.Context) ([]Genre, error) {
@@ -100,7 +292,50 @@ func (q *Queries) GetBeatGenreParams(ctx context.Context) ([]Genre, error) {
 	var items []Genre
 	for rows.Next() {
 		var i Genre
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.Position,
+			&i.IsArchived,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBeatLicenses = `-- name: GetBeatLicenses :many
+select beat_id, user_id, tier, price, deliverables, stream_cap, distribution_cap, created_at, number, agreement_path, downloads, revoked_at from beat_licenses where beat_id = $1
+`
+
+func (q *Queries) GetBeatLicenses(ctx context.Context, beatID uuid.UUID) ([]BeatLicense, error) {
+	rows, err := q.db.Query(ctx, getBeatLicenses, beatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BeatLicense
+	for rows.Next() {
+		var i BeatLicense
+		if err := rows.Scan(
+			&i.BeatID,
+			&i.UserID,
+			&i.Tier,
+			&i.Price,
+			&i.Deliverables,
+			&i.StreamCap,
+			&i.DistributionCap,
+			&i.CreatedAt,
+			&i.Number,
+			&i.AgreementPath,
+			&i.Downloads,
+			&i.RevokedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -112,7 +347,9 @@ func (q *Queries) GetBeatGenreParams(ctx context.Context) ([]Genre, error) {
 }
 
 const getBeatMoodParams = `-- name: GetBeatMoodParams :many
-select id, name from moods
+select id, name, slug, position, is_archived from moods
+where "is_archived" = false
+order by "position", "name"
 `
 
 func (q *Queries) GetBeatMoodParams(ctx context.Context) ([]Mood, error) {
@@ -124,7 +361,13 @@ func (q *Queries) GetBeatMoodParams(ctx context.Context) ([]Mood, error) {
 	var items []Mood
 	for rows.Next() {
 		var i Mood
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.Position,
+			&i.IsArchived,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -136,7 +379,9 @@ func (q *Queries) GetBeatMoodParams(ctx context.Context) ([]Mood, error) {
 }
 
 const getBeatNoteParams = `-- name: GetBeatNoteParams :many
-select id, name from notes
+select id, name, slug, position, is_archived from notes
+where "is_archived" = false
+order by "position", "name"
 `
 
 func (q *Queries) GetBeatNoteParams(ctx context.Context) ([]Note, error) {
@@ -148,7 +393,59 @@ func (q *Queries) GetBeatNoteParams(ctx context.Context) ([]Note, error) {
 	var items []Note
 	for rows.Next() {
 		var i Note
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.Position,
+			&i.IsArchived,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBeatPlays = `-- name: GetBeatPlays :many
+select b."id", b."name",
+       count(ls."id") filter (where ls."is_qualified") as "plays",
+       count(ls."id") as "sessions",
+       coalesce(sum(ls."seconds_played"), 0)::bigint as "seconds_played"
+from beats b
+left join listening_sessions ls on b."id" = ls."beat_id"
+where b."beatmaker_id" = $1 and b."is_deleted" = false
+group by b."id"
+order by "plays" desc, b."created_at" desc
+`
+
+type GetBeatPlaysRow struct {
+	ID            uuid.UUID
+	Name          string
+	Plays         int64
+	Sessions      int64
+	SecondsPlayed int64
+}
+
+func (q *Queries) GetBeatPlays(ctx context.Context, beatmakerID uuid.UUID) ([]GetBeatPlaysRow, error) {
+	rows, err := q.db.Query(ctx, getBeatPlays, beatmakerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBeatPlaysRow
+	for rows.Next() {
+		var i GetBeatPlaysRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Plays,
+			&i.Sessions,
+			&i.SecondsPlayed,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -160,7 +457,9 @@ func (q *Queries) GetBeatNoteParams(ctx context.Context) ([]Note, error) {
 }
 
 const getBeatTagParams = `-- name: GetBeatTagParams :many
-select id, name from tags
+select id, name, slug, position, is_archived from tags
+where "is_archived" = false
+order by "position", "name"
 `
 
 func (q *Queries) GetBeatTagParams(ctx context.Context) ([]Tag, error) {
@@ -172,7 +471,13 @@ func (q *Queries) GetBeatTagParams(ctx context.Context) ([]Tag, error) {
 	var items []Tag
 	for rows.Next() {
 		var i Tag
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.Position,
+			&i.IsArchived,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -183,113 +488,1487 @@ func (q *Queries) GetBeatTagParams(ctx context.Context) ([]Tag, error) {
 	return items, nil
 }
 
-const getOwnerByBeatID = `-- name: GetOwnerByBeatID :one
-select beat_id, user_id from beats_owners where beat_id = $1
+const getBeatmakerIDs = `-- name: GetBeatmakerIDs :many
+select distinct "beatmaker_id" from beats where "is_deleted" = false
 `
 
-func (q *Queries) GetOwnerByBeatID(ctx context.Context, beatID uuid.UUID) (BeatsOwner, error) {
-	row := q.db.QueryRow(ctx, getOwnerByBeatID, beatID)
-	var i BeatsOwner
-	err := row.Scan(&i.BeatID, &i.UserID)
-	return i, err
+func (q *Queries) GetBeatmakerIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getBeatmakerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var beatmaker_id uuid.UUID
+		if err := rows.Scan(&beatmaker_id); err != nil {
+			return nil, err
+		}
+		items = append(items, beatmaker_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const saveBeat = `-- name: SaveBeat :exec
-insert into beats ("id", "beatmaker_id", "bpm", "description", "name", "file_path", "image_path", "archive_path", "range_start", "range_end")
-values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+const getBeatmakerStats = `-- name: GetBeatmakerStats :one
+select (select count(*) from beats b
+        where (b."beatmaker_id" = $1
+               or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = $1))
+          and b."is_deleted" = false
+          and b."is_file_downloaded" and b."is_image_downloaded" and b."is_archive_downloaded") as "beats",
+       (select count(*) from listening_sessions ls join beats b on ls."beat_id" = b."id"
+        where (b."beatmaker_id" = $1
+               or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = $1))
+          and ls."is_qualified") as "plays",
+       (select count(*) from beat_licenses bl join beats b on bl."beat_id" = b."id"
+        where (b."beatmaker_id" = $1
+               or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = $1))
+          and bl."revoked_at" is null) as "sales"
 `
 
-type SaveBeatParams struct {
-	ID          uuid.UUID
-	BeatmakerID uuid.UUID
-	Bpm         int32
-	Description string
-	Name        string
-	FilePath    string
-	ImagePath   string
-	ArchivePath string
-	RangeStart  int64
-	RangeEnd    int64
+type GetBeatmakerStatsRow struct {
+	Beats int64
+	Plays int64
+	Sales int64
 }
 
-func (q *Queries) SaveBeat(ctx context.Context, arg SaveBeatParams) error {
-	_, err := q.db.Exec(ctx, saveBeat,
-		arg.ID,
-		arg.BeatmakerID,
-		arg.Bpm,
-		arg.Description,
-		arg.Name,
-		arg.FilePath,
-		arg.ImagePath,
-		arg.ArchivePath,
-		arg.RangeStart,
-		arg.RangeEnd,
-	)
-	return err
+func (q *Queries) GetBeatmakerStats(ctx context.Context, beatmakerID uuid.UUID) (GetBeatmakerStatsRow, error) {
+	row := q.db.QueryRow(ctx, getBeatmakerStats, beatmakerID)
+	var i GetBeatmakerStatsRow
+	err := row.Scan(&i.Beats, &i.Plays, &i.Sales)
+	return i, err
 }
 
-type SaveGenresParams struct {
-	BeatID  uuid.UUID
-	GenreID uuid.UUID
+const getCollaboratorEarnings = `-- name: GetCollaboratorEarnings :many
+select le."beat_id", b."name", le."tier", bl."number", le."role", le."share", bl."price", le."amount", le."created_at"
+from license_earnings le
+join beat_licenses bl on le."beat_id" = bl."beat_id" and le."user_id" = bl."user_id" and le."tier" = bl."tier"
+join beats b on le."beat_id" = b."id"
+where le."collaborator_id" = $1 and bl."revoked_at" is null
+order by le."created_at" desc, b."name"
+`
+
+type GetCollaboratorEarningsRow struct {
+	BeatID    uuid.UUID
+	Name      string
+	Tier      LicenseTier
+	Number    string
+	Role      CollaboratorRole
+	Share     int32
+	Price     int64
+	Amount    int64
+	CreatedAt pgtype.Timestamp
 }
 
-type SaveMoodsParams struct {
-	BeatID uuid.UUID
-	MoodID uuid.UUID
+func (q *Queries) GetCollaboratorEarnings(ctx context.Context, collaboratorID uuid.UUID) ([]GetCollaboratorEarningsRow, error) {
+	rows, err := q.db.Query(ctx, getCollaboratorEarnings, collaboratorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollaboratorEarningsRow
+	for rows.Next() {
+		var i GetCollaboratorEarningsRow
+		if err := rows.Scan(
+			&i.BeatID,
+			&i.Name,
+			&i.Tier,
+			&i.Number,
+			&i.Role,
+			&i.Share,
+			&i.Price,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const saveNote = `-- name: SaveNote :exec
-insert into beats_notes ("beat_id", "note_id", "scale")
-values ($1, $2, $3)
+const getLicenseAudit = `-- name: GetLicenseAudit :many
+select id, beat_id, user_id, tier, action, actor_id, ip, user_agent, url, expires_at, created_at, reason from license_audit
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3
+order by "created_at" desc
 `
 
-type SaveNoteParams struct {
+type GetLicenseAuditParams struct {
 	BeatID uuid.UUID
-	NoteID uuid.UUID
-	Scale  NoteScale
+	UserID uuid.UUID
+	Tier   LicenseTier
 }
 
-func (q *Queries) SaveNote(ctx context.Context, arg SaveNoteParams) error {
-	_, err := q.db.Exec(ctx, saveNote, arg.BeatID, arg.NoteID, arg.Scale)
-	return err
+func (q *Queries) GetLicenseAudit(ctx context.Context, arg GetLicenseAuditParams) ([]LicenseAudit, error) {
+	rows, err := q.db.Query(ctx, getLicenseAudit, arg.BeatID, arg.UserID, arg.Tier)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LicenseAudit
+	for rows.Next() {
+		var i LicenseAudit
+		if err := rows.Scan(
+			&i.ID,
+			&i.BeatID,
+			&i.UserID,
+			&i.Tier,
+			&i.Action,
+			&i.ActorID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.Url,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const saveOwner = `-- name: SaveOwner :exec
-insert into beats_owners ("beat_id", "user_id") values ($1, $2)
+const getLicenseByNumber = `-- name: GetLicenseByNumber :one
+select beat_id, user_id, tier, price, deliverables, stream_cap, distribution_cap, created_at, number, agreement_path, downloads, revoked_at from beat_licenses where "number" = $1
 `
 
-type SaveOwnerParams struct {
-	BeatID uuid.UUID
-	UserID uuid.UUID
+func (q *Queries) GetLicenseByNumber(ctx context.Context, number string) (BeatLicense, error) {
+	row := q.db.QueryRow(ctx, getLicenseByNumber, number)
+	var i BeatLicense
+	err := row.Scan(
+		&i.BeatID,
+		&i.UserID,
+		&i.Tier,
+		&i.Price,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.CreatedAt,
+		&i.Number,
+		&i.AgreementPath,
+		&i.Downloads,
+		&i.RevokedAt,
+	)
+	return i, err
 }
 
-func (q *Queries) SaveOwner(ctx context.Context, arg SaveOwnerParams) error {
-	_, err := q.db.Exec(ctx, saveOwner, arg.BeatID, arg.UserID)
-	return err
-}
+const getLicenseForUpdate = `-- name: GetLicenseForUpdate :one
+select beat_id, user_id, tier, price, deliverables, stream_cap, distribution_cap, created_at, number, agreement_path, downloads, revoked_at from beat_licenses where "beat_id" = $1 and "user_id" = $2 and "tier" = $3
+for update
+`
 
-type SaveTagsParams struct {
+type GetLicenseForUpdateParams struct {
 	BeatID uuid.UUID
-	TagID  uuid.UUID
+	UserID uuid.UUID
+	Tier   LicenseTier
 }
 
-const updateBeat = `-- name: UpdateBeat :one
-update beats
-set "name" = coalesce($1, "name"),
-    "bpm" = coalesce($2, "bpm"),
-    "description" = coalesce($3, "description"),
-    "range_start" = coalesce($4, "range_start"),
-    "range_end" = coalesce($5, "range_end"),
-    "is_image_downloaded" = coalesce($6, "is_image_downloaded"),
-    "is_file_downloaded" = coalesce($7, "is_file_downloaded"),
-    "is_archive_downloaded" = coalesce($8, "is_archive_downloaded"),
-    "updated_at" = now()
-where "id" = $9 and "is_deleted" = false
-returning id, beatmaker_id, file_path, image_path, archive_path, name, description, is_file_downloaded, is_image_downloaded, is_archive_downloaded, range_start, range_end, is_deleted, created_at, updated_at, bpm
+func (q *Queries) GetLicenseForUpdate(ctx context.Context, arg GetLicenseForUpdateParams) (BeatLicense, error) {
+	row := q.db.QueryRow(ctx, getLicenseForUpdate, arg.BeatID, arg.UserID, arg.Tier)
+	var i BeatLicense
+	err := row.Scan(
+		&i.BeatID,
+		&i.UserID,
+		&i.Tier,
+		&i.Price,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.CreatedAt,
+		&i.Number,
+		&i.AgreementPath,
+		&i.Downloads,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getLicenseTiers = `-- name: GetLicenseTiers :many
+select beat_id, tier, price, deliverables, stream_cap, distribution_cap, created_at, updated_at, sales_cap from beats_license_tiers where "beat_id" = $1 order by "tier"
 `
 
-type UpdateBeatParams struct {
-	Name                *string
+func (q *Queries) GetLicenseTiers(ctx context.Context, beatID uuid.UUID) ([]BeatsLicenseTier, error) {
+	rows, err := q.db.Query(ctx, getLicenseTiers, beatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BeatsLicenseTier
+	for rows.Next() {
+		var i BeatsLicenseTier
+		if err := rows.Scan(
+			&i.BeatID,
+			&i.Tier,
+			&i.Price,
+			&i.Deliverables,
+			&i.StreamCap,
+			&i.DistributionCap,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SalesCap,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLiveDownload = `-- name: GetLiveDownload :one
+select id, beat_id, user_id, tier, action, actor_id, ip, user_agent, url, expires_at, created_at, reason from license_audit
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3 and "action" = 'download' and "expires_at" > $4
+order by "created_at" desc
+limit 1
+`
+
+type GetLiveDownloadParams struct {
+	BeatID    uuid.UUID
+	UserID    uuid.UUID
+	Tier      LicenseTier
+	ExpiresAt pgtype.Timestamp
+}
+
+func (q *Queries) GetLiveDownload(ctx context.Context, arg GetLiveDownloadParams) (LicenseAudit, error) {
+	row := q.db.QueryRow(ctx, getLiveDownload,
+		arg.BeatID,
+		arg.UserID,
+		arg.Tier,
+		arg.ExpiresAt,
+	)
+	var i LicenseAudit
+	err := row.Scan(
+		&i.ID,
+		&i.BeatID,
+		&i.UserID,
+		&i.Tier,
+		&i.Action,
+		&i.ActorID,
+		&i.Ip,
+		&i.UserAgent,
+		&i.Url,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.Reason,
+	)
+	return i, err
+}
+
+const getOrderByCheckoutForUpdate = `-- name: GetOrderByCheckoutForUpdate :one
+select id, user_id, beat_id, tier, price, currency, deliverables, stream_cap, distribution_cap, sales_cap, status, provider, checkout_id, checkout_url, created_at, updated_at, paid_at from orders where "provider" = $1 and "checkout_id" = $2
+for update
+`
+
+type GetOrderByCheckoutForUpdateParams struct {
+	Provider   string
+	CheckoutID *string
+}
+
+func (q *Queries) GetOrderByCheckoutForUpdate(ctx context.Context, arg GetOrderByCheckoutForUpdateParams) (Order, error) {
+	row := q.db.QueryRow(ctx, getOrderByCheckoutForUpdate, arg.Provider, arg.CheckoutID)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.BeatID,
+		&i.Tier,
+		&i.Price,
+		&i.Currency,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.SalesCap,
+		&i.Status,
+		&i.Provider,
+		&i.CheckoutID,
+		&i.CheckoutUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidAt,
+	)
+	return i, err
+}
+
+const getOrderByID = `-- name: GetOrderByID :one
+select id, user_id, beat_id, tier, price, currency, deliverables, stream_cap, distribution_cap, sales_cap, status, provider, checkout_id, checkout_url, created_at, updated_at, paid_at from orders where "id" = $1
+`
+
+func (q *Queries) GetOrderByID(ctx context.Context, id uuid.UUID) (Order, error) {
+	row := q.db.QueryRow(ctx, getOrderByID, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.BeatID,
+		&i.Tier,
+		&i.Price,
+		&i.Currency,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.SalesCap,
+		&i.Status,
+		&i.Provider,
+		&i.CheckoutID,
+		&i.CheckoutUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidAt,
+	)
+	return i, err
+}
+
+const getPaidLicenseOrders = `-- name: GetPaidLicenseOrders :many
+select id, user_id, beat_id, tier, price, currency, deliverables, stream_cap, distribution_cap, sales_cap, status, provider, checkout_id, checkout_url, created_at, updated_at, paid_at from orders
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3 and "status" = 'paid'
+`
+
+type GetPaidLicenseOrdersParams struct {
+	BeatID uuid.UUID
+	UserID uuid.UUID
+	Tier   LicenseTier
+}
+
+func (q *Queries) GetPaidLicenseOrders(ctx context.Context, arg GetPaidLicenseOrdersParams) ([]Order, error) {
+	rows, err := q.db.Query(ctx, getPaidLicenseOrders, arg.BeatID, arg.UserID, arg.Tier)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.BeatID,
+			&i.Tier,
+			&i.Price,
+			&i.Currency,
+			&i.Deliverables,
+			&i.StreamCap,
+			&i.DistributionCap,
+			&i.SalesCap,
+			&i.Status,
+			&i.Provider,
+			&i.CheckoutID,
+			&i.CheckoutUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PaidAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingOrder = `-- name: GetPendingOrder :one
+select id, user_id, beat_id, tier, price, currency, deliverables, stream_cap, distribution_cap, sales_cap, status, provider, checkout_id, checkout_url, created_at, updated_at, paid_at from orders
+where "user_id" = $1 and "beat_id" = $2 and "tier" = $3 and "status" = 'pending' and "created_at" > $4
+order by "created_at" desc
+limit 1
+`
+
+type GetPendingOrderParams struct {
+	UserID    uuid.UUID
+	BeatID    uuid.UUID
+	Tier      LicenseTier
+	CreatedAt pgtype.Timestamp
+}
+
+func (q *Queries) GetPendingOrder(ctx context.Context, arg GetPendingOrderParams) (Order, error) {
+	row := q.db.QueryRow(ctx, getPendingOrder,
+		arg.UserID,
+		arg.BeatID,
+		arg.Tier,
+		arg.CreatedAt,
+	)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.BeatID,
+		&i.Tier,
+		&i.Price,
+		&i.Currency,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.SalesCap,
+		&i.Status,
+		&i.Provider,
+		&i.CheckoutID,
+		&i.CheckoutUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidAt,
+	)
+	return i, err
+}
+
+const getPlaylistBeatIDs = `-- name: GetPlaylistBeatIDs :many
+select "beat_id" from playlists_beats
+where "playlist_id" = $1
+order by "position"
+`
+
+func (q *Queries) GetPlaylistBeatIDs(ctx context.Context, playlistID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getPlaylistBeatIDs, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var beat_id uuid.UUID
+		if err := rows.Scan(&beat_id); err != nil {
+			return nil, err
+		}
+		items = append(items, beat_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlaylistByID = `-- name: GetPlaylistByID :one
+select id, user_id, name, visibility, share_token, created_at, updated_at from playlists where "id" = $1
+`
+
+func (q *Queries) GetPlaylistByID(ctx context.Context, id uuid.UUID) (Playlist, error) {
+	row := q.db.QueryRow(ctx, getPlaylistByID, id)
+	var i Playlist
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPlaylistByShareToken = `-- name: GetPlaylistByShareToken :one
+select id, user_id, name, visibility, share_token, created_at, updated_at from playlists where "share_token" = $1
+`
+
+func (q *Queries) GetPlaylistByShareToken(ctx context.Context, shareToken string) (Playlist, error) {
+	row := q.db.QueryRow(ctx, getPlaylistByShareToken, shareToken)
+	var i Playlist
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPlaylistForUpdate = `-- name: GetPlaylistForUpdate :one
+select id, user_id, name, visibility, share_token, created_at, updated_at from playlists where "id" = $1
+for update
+`
+
+func (q *Queries) GetPlaylistForUpdate(ctx context.Context, id uuid.UUID) (Playlist, error) {
+	row := q.db.QueryRow(ctx, getPlaylistForUpdate, id)
+	var i Playlist
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPlaylistsByUserID = `-- name: GetPlaylistsByUserID :many
+select id, user_id, name, visibility, share_token, created_at, updated_at from playlists where "user_id" = $1
+order by "created_at" desc
+`
+
+func (q *Queries) GetPlaylistsByUserID(ctx context.Context, userID uuid.UUID) ([]Playlist, error) {
+	rows, err := q.db.Query(ctx, getPlaylistsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Playlist
+	for rows.Next() {
+		var i Playlist
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Visibility,
+			&i.ShareToken,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSalesReport = `-- name: GetSalesReport :many
+select date_trunc($1::text, bl."created_at")::timestamp as "period",
+       b."id", b."name",
+       count(*) as "units",
+       sum(bl."price")::bigint as "gross"
+from beat_licenses bl
+join beats b on bl."beat_id" = b."id"
+where (b."beatmaker_id" = $2
+       or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = $2))
+  and bl."revoked_at" is null
+  and ($3::uuid is null or b."id" = $3)
+  and ($4::timestamp is null or bl."created_at" >= $4)
+  and ($5::timestamp is null or bl."created_at" < $5)
+group by 1, b."id"
+order by 1, "gross" desc, b."name"
+`
+
+type GetSalesReportParams struct {
+	Period      string
+	BeatmakerID uuid.UUID
+	BeatID      pgtype.UUID
+	From        pgtype.Timestamp
+	To          pgtype.Timestamp
+}
+
+type GetSalesReportRow struct {
+	Period pgtype.Timestamp
+	ID     uuid.UUID
+	Name   string
+	Units  int64
+	Gross  int64
+}
+
+func (q *Queries) GetSalesReport(ctx context.Context, arg GetSalesReportParams) ([]GetSalesReportRow, error) {
+	rows, err := q.db.Query(ctx, getSalesReport,
+		arg.Period,
+		arg.BeatmakerID,
+		arg.BeatID,
+		arg.From,
+		arg.To,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSalesReportRow
+	for rows.Next() {
+		var i GetSalesReportRow
+		if err := rows.Scan(
+			&i.Period,
+			&i.ID,
+			&i.Name,
+			&i.Units,
+			&i.Gross,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTagBySlugOrName = `-- name: GetTagBySlugOrName :one
+select id, name, slug, position, is_archived from tags
+where "slug" = $1 or lower("name") = lower($2)
+order by "slug" = $1 desc
+limit 1
+`
+
+type GetTagBySlugOrNameParams struct {
+	Slug string
+	Name string
+}
+
+func (q *Queries) GetTagBySlugOrName(ctx context.Context, arg GetTagBySlugOrNameParams) (Tag, error) {
+	row := q.db.QueryRow(ctx, getTagBySlugOrName, arg.Slug, arg.Name)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.Position,
+		&i.IsArchived,
+	)
+	return i, err
+}
+
+const getTagsByPrefix = `-- name: GetTagsByPrefix :many
+select t."id", t."name", t."slug", count(b."id") as "beats"
+from tags t
+left join beats_tags bt on t."id" = bt."tag_id"
+left join beats b on bt."beat_id" = b."id" and b."is_deleted" = false
+where t."is_archived" = false
+  and (lower(t."name") like $1 or t."slug" like $2)
+group by t."id"
+order by "beats" desc, t."name"
+limit $3
+`
+
+type GetTagsByPrefixParams struct {
+	NamePattern string
+	SlugPattern string
+	MaxTags     int32
+}
+
+type GetTagsByPrefixRow struct {
+	ID    uuid.UUID
+	Name  string
+	Slug  string
+	Beats int64
+}
+
+func (q *Queries) GetTagsByPrefix(ctx context.Context, arg GetTagsByPrefixParams) ([]GetTagsByPrefixRow, error) {
+	rows, err := q.db.Query(ctx, getTagsByPrefix, arg.NamePattern, arg.SlugPattern, arg.MaxTags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTagsByPrefixRow
+	for rows.Next() {
+		var i GetTagsByPrefixRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.Beats,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserPurchases = `-- name: GetUserPurchases :many
+select bl."beat_id", b."name", bl."tier", bl."number", bl."price", bl."deliverables", bl."agreement_path", bl."created_at",
+       b."file_path", b."archive_path", b."is_file_downloaded", b."is_archive_downloaded", bl."downloads", bl."revoked_at"
+from beat_licenses bl
+join beats b on bl."beat_id" = b."id"
+where bl."user_id" = $1
+order by bl."created_at" desc, b."name"
+`
+
+type GetUserPurchasesRow struct {
+	BeatID              uuid.UUID
+	Name                string
+	Tier                LicenseTier
+	Number              string
+	Price               int64
+	Deliverables        []string
+	AgreementPath       *string
+	CreatedAt           pgtype.Timestamp
+	FilePath            string
+	ArchivePath         string
+	IsFileDownloaded    bool
+	IsArchiveDownloaded bool
+	Downloads           int32
+	RevokedAt           pgtype.Timestamp
+}
+
+func (q *Queries) GetUserPurchases(ctx context.Context, userID uuid.UUID) ([]GetUserPurchasesRow, error) {
+	rows, err := q.db.Query(ctx, getUserPurchases, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserPurchasesRow
+	for rows.Next() {
+		var i GetUserPurchasesRow
+		if err := rows.Scan(
+			&i.BeatID,
+			&i.Name,
+			&i.Tier,
+			&i.Number,
+			&i.Price,
+			&i.Deliverables,
+			&i.AgreementPath,
+			&i.CreatedAt,
+			&i.FilePath,
+			&i.ArchivePath,
+			&i.IsFileDownloaded,
+			&i.IsArchiveDownloaded,
+			&i.Downloads,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hasArchivedTaxonomy = `-- name: HasArchivedTaxonomy :one
+select exists (select 1 from genres where "is_archived" and "id" = any($1::uuid[]))
+    or exists (select 1 from tags where "is_archived" and "id" = any($2::uuid[]))
+    or exists (select 1 from moods where "is_archived" and "id" = any($3::uuid[]))
+    or exists (select 1 from notes where "is_archived" and "id" = any($4::uuid[]))
+`
+
+type HasArchivedTaxonomyParams struct {
+	GenreIds []uuid.UUID
+	TagIds   []uuid.UUID
+	MoodIds  []uuid.UUID
+	NoteIds  []uuid.UUID
+}
+
+func (q *Queries) HasArchivedTaxonomy(ctx context.Context, arg HasArchivedTaxonomyParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasArchivedTaxonomy,
+		arg.GenreIds,
+		arg.TagIds,
+		arg.MoodIds,
+		arg.NoteIds,
+	)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const incrementLicenseDownloads = `-- name: IncrementLicenseDownloads :one
+update beat_licenses set "downloads" = "downloads" + 1
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3
+  and "revoked_at" is null
+  and ($4::int = 0 or "downloads" < $4::int)
+returning "downloads"
+`
+
+type IncrementLicenseDownloadsParams struct {
+	BeatID        uuid.UUID
+	UserID        uuid.UUID
+	Tier          LicenseTier
+	DownloadLimit int32
+}
+
+func (q *Queries) IncrementLicenseDownloads(ctx context.Context, arg IncrementLicenseDownloadsParams) (int32, error) {
+	row := q.db.QueryRow(ctx, incrementLicenseDownloads,
+		arg.BeatID,
+		arg.UserID,
+		arg.Tier,
+		arg.DownloadLimit,
+	)
+	var downloads int32
+	err := row.Scan(&downloads)
+	return downloads, err
+}
+
+const refreshTrending = `-- name: RefreshTrending :exec
+with scores as (
+    select "beat_id",
+           sum(case "kind" when 'acquisition' then $1::float8 else $2::float8 end
+               * power(0.5, extract(epoch from now()::timestamp - "created_at") / $3::float8)) as "score"
+    from beats_signals
+    where "created_at" > now()::timestamp - make_interval(secs => $4::float8)
+    group by "beat_id"
+), stale as (
+    delete from beats_trending where "beat_id" not in (select "beat_id" from scores)
+)
+insert into beats_trending ("beat_id", "score", "updated_at")
+select "beat_id", "score", now() from scores
+on conflict ("beat_id") do update
+set "score" = excluded."score",
+    "updated_at" = excluded."updated_at"
+`
+
+type RefreshTrendingParams struct {
+	AcquisitionWeight float64
+	PlayWeight        float64
+	HalfLife          float64
+	Window            float64
+}
+
+func (q *Queries) RefreshTrending(ctx context.Context, arg RefreshTrendingParams) error {
+	_, err := q.db.Exec(ctx, refreshTrending,
+		arg.AcquisitionWeight,
+		arg.PlayWeight,
+		arg.HalfLife,
+		arg.Window,
+	)
+	return err
+}
+
+const refundLicenseOrders = `-- name: RefundLicenseOrders :exec
+update orders set "status" = 'refunded', "updated_at" = now()
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3 and "status" = 'paid'
+`
+
+type RefundLicenseOrdersParams struct {
+	BeatID uuid.UUID
+	UserID uuid.UUID
+	Tier   LicenseTier
+}
+
+func (q *Queries) RefundLicenseOrders(ctx context.Context, arg RefundLicenseOrdersParams) error {
+	_, err := q.db.Exec(ctx, refundLicenseOrders, arg.BeatID, arg.UserID, arg.Tier)
+	return err
+}
+
+const reorderPlaylistBeats = `-- name: ReorderPlaylistBeats :exec
+update playlists_beats pb
+set "position" = o."position"
+from unnest($1::uuid[]) with ordinality as o("beat_id", "position")
+where pb."playlist_id" = $2 and pb."beat_id" = o."beat_id"
+`
+
+type ReorderPlaylistBeatsParams struct {
+	BeatIds    []uuid.UUID
+	PlaylistID uuid.UUID
+}
+
+func (q *Queries) ReorderPlaylistBeats(ctx context.Context, arg ReorderPlaylistBeatsParams) error {
+	_, err := q.db.Exec(ctx, reorderPlaylistBeats, arg.BeatIds, arg.PlaylistID)
+	return err
+}
+
+const resetLicenseDownloads = `-- name: ResetLicenseDownloads :exec
+update beat_licenses set "downloads" = 0
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3
+`
+
+type ResetLicenseDownloadsParams struct {
+	BeatID uuid.UUID
+	UserID uuid.UUID
+	Tier   LicenseTier
+}
+
+func (q *Queries) ResetLicenseDownloads(ctx context.Context, arg ResetLicenseDownloadsParams) error {
+	_, err := q.db.Exec(ctx, resetLicenseDownloads, arg.BeatID, arg.UserID, arg.Tier)
+	return err
+}
+
+const restoreBeat = `-- name: RestoreBeat :exec
+update beats
+set "is_deleted" = false,
+    "updated_at" = now()
+where "id" = $1
+`
+
+func (q *Queries) RestoreBeat(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, restoreBeat, id)
+	return err
+}
+
+const revokeLicense = `-- name: RevokeLicense :one
+update beat_licenses set "revoked_at" = now()
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3 and "revoked_at" is null
+returning beat_id, user_id, tier, price, deliverables, stream_cap, distribution_cap, created_at, number, agreement_path, downloads, revoked_at
+`
+
+type RevokeLicenseParams struct {
+	BeatID uuid.UUID
+	UserID uuid.UUID
+	Tier   LicenseTier
+}
+
+func (q *Queries) RevokeLicense(ctx context.Context, arg RevokeLicenseParams) (BeatLicense, error) {
+	row := q.db.QueryRow(ctx, revokeLicense, arg.BeatID, arg.UserID, arg.Tier)
+	var i BeatLicense
+	err := row.Scan(
+		&i.BeatID,
+		&i.UserID,
+		&i.Tier,
+		&i.Price,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.CreatedAt,
+		&i.Number,
+		&i.AgreementPath,
+		&i.Downloads,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const saveAcquisition = `-- name: SaveAcquisition :exec
+insert into beat_acquisitions ("user_id", "idempotency_key", "beat_id", "tier")
+values ($1, $2, $3, $4)
+on conflict do nothing
+`
+
+type SaveAcquisitionParams struct {
+	UserID         uuid.UUID
+	IdempotencyKey string
+	BeatID         uuid.UUID
+	Tier           LicenseTier
+}
+
+func (q *Queries) SaveAcquisition(ctx context.Context, arg SaveAcquisitionParams) error {
+	_, err := q.db.Exec(ctx, saveAcquisition,
+		arg.UserID,
+		arg.IdempotencyKey,
+		arg.BeatID,
+		arg.Tier,
+	)
+	return err
+}
+
+const saveBeat = `-- name: SaveBeat :exec
+insert into beats ("id", "beatmaker_id", "bpm", "description", "name", "file_path", "image_path", "archive_path", "range_start", "range_end")
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+type SaveBeatParams struct {
+	ID          uuid.UUID
+	BeatmakerID uuid.UUID
+	Bpm         int32
+	Description string
+	Name        string
+	FilePath    string
+	ImagePath   string
+	ArchivePath string
+	RangeStart  int64
+	RangeEnd    int64
+}
+
+func (q *Queries) SaveBeat(ctx context.Context, arg SaveBeatParams) error {
+	_, err := q.db.Exec(ctx, saveBeat,
+		arg.ID,
+		arg.BeatmakerID,
+		arg.Bpm,
+		arg.Description,
+		arg.Name,
+		arg.FilePath,
+		arg.ImagePath,
+		arg.ArchivePath,
+		arg.RangeStart,
+		arg.RangeEnd,
+	)
+	return err
+}
+
+type SaveGenresParams struct {
+	BeatID  uuid.UUID
+	GenreID uuid.UUID
+}
+
+type SaveMoodsParams struct {
+	BeatID uuid.UUID
+	MoodID uuid.UUID
+}
+
+const saveBeatCollaborator = `-- name: SaveBeatCollaborator :exec
+insert into beats_collaborators ("beat_id", "user_id", "role", "share")
+values ($1, $2, $3, $4)
+`
+
+type SaveBeatCollaboratorParams struct {
+	BeatID uuid.UUID
+	UserID uuid.UUID
+	Role   CollaboratorRole
+	Share  int32
+}
+
+func (q *Queries) SaveBeatCollaborator(ctx context.Context, arg SaveBeatCollaboratorParams) error {
+	_, err := q.db.Exec(ctx, saveBeatCollaborator,
+		arg.BeatID,
+		arg.UserID,
+		arg.Role,
+		arg.Share,
+	)
+	return err
+}
+
+const saveBeatmaker = `-- name: SaveBeatmaker :exec
+insert into beatmakers ("id", "username", "pseudonym")
+values ($1, $2, $3)
+on conflict ("id") do update
+set "username" = excluded."username",
+    "pseudonym" = excluded."pseudonym",
+    "updated_at" = now()
+`
+
+type SaveBeatmakerParams struct {
+	ID        uuid.UUID
+	Username  string
+	Pseudonym string
+}
+
+func (q *Queries) SaveBeatmaker(ctx context.Context, arg SaveBeatmakerParams) error {
+	_, err := q.db.Exec(ctx, saveBeatmaker, arg.ID, arg.Username, arg.Pseudonym)
+	return err
+}
+
+const saveLicense = `-- name: SaveLicense :one
+insert into beat_licenses ("beat_id", "user_id", "tier", "price", "deliverables", "stream_cap", "distribution_cap")
+values ($1, $2, $3, $4, $5, $6, $7)
+returning beat_id, user_id, tier, price, deliverables, stream_cap, distribution_cap, created_at, number, agreement_path, downloads, revoked_at
+`
+
+type SaveLicenseParams struct {
+	BeatID          uuid.UUID
+	UserID          uuid.UUID
+	Tier            LicenseTier
+	Price           int64
+	Deliverables    []string
+	StreamCap       *int32
+	DistributionCap *int32
+}
+
+func (q *Queries) SaveLicense(ctx context.Context, arg SaveLicenseParams) (BeatLicense, error) {
+	row := q.db.QueryRow(ctx, saveLicense,
+		arg.BeatID,
+		arg.UserID,
+		arg.Tier,
+		arg.Price,
+		arg.Deliverables,
+		arg.StreamCap,
+		arg.DistributionCap,
+	)
+	var i BeatLicense
+	err := row.Scan(
+		&i.BeatID,
+		&i.UserID,
+		&i.Tier,
+		&i.Price,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.CreatedAt,
+		&i.Number,
+		&i.AgreementPath,
+		&i.Downloads,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const saveLicenseAudit = `-- name: SaveLicenseAudit :exec
+insert into license_audit ("beat_id", "user_id", "tier", "action", "actor_id", "ip", "user_agent", "url", "expires_at", "reason")
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+type SaveLicenseAuditParams struct {
+	BeatID    uuid.UUID
+	UserID    uuid.UUID
+	Tier      LicenseTier
+	Action    LicenseAuditAction
+	ActorID   pgtype.UUID
+	Ip        *string
+	UserAgent *string
+	Url       *string
+	ExpiresAt pgtype.Timestamp
+	Reason    *string
+}
+
+func (q *Queries) SaveLicenseAudit(ctx context.Context, arg SaveLicenseAuditParams) error {
+	_, err := q.db.Exec(ctx, saveLicenseAudit,
+		arg.BeatID,
+		arg.UserID,
+		arg.Tier,
+		arg.Action,
+		arg.ActorID,
+		arg.Ip,
+		arg.UserAgent,
+		arg.Url,
+		arg.ExpiresAt,
+		arg.Reason,
+	)
+	return err
+}
+
+const saveLicenseEarning = `-- name: SaveLicenseEarning :exec
+insert into license_earnings ("beat_id", "user_id", "tier", "collaborator_id", "role", "share", "amount")
+values ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type SaveLicenseEarningParams struct {
+	BeatID         uuid.UUID
+	UserID         uuid.UUID
+	Tier           LicenseTier
+	CollaboratorID uuid.UUID
+	Role           CollaboratorRole
+	Share          int32
+	Amount         int64
+}
+
+func (q *Queries) SaveLicenseEarning(ctx context.Context, arg SaveLicenseEarningParams) error {
+	_, err := q.db.Exec(ctx, saveLicenseEarning,
+		arg.BeatID,
+		arg.UserID,
+		arg.Tier,
+		arg.CollaboratorID,
+		arg.Role,
+		arg.Share,
+		arg.Amount,
+	)
+	return err
+}
+
+const saveLicenseTier = `-- name: SaveLicenseTier :exec
+insert into beats_license_tiers ("beat_id", "tier", "price", "deliverables", "stream_cap", "distribution_cap", "sales_cap")
+values ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type SaveLicenseTierParams struct {
+	BeatID          uuid.UUID
+	Tier            LicenseTier
+	Price           int64
+	Deliverables    []string
+	StreamCap       *int32
+	DistributionCap *int32
+	SalesCap        *int32
+}
+
+func (q *Queries) SaveLicenseTier(ctx context.Context, arg SaveLicenseTierParams) error {
+	_, err := q.db.Exec(ctx, saveLicenseTier,
+		arg.BeatID,
+		arg.Tier,
+		arg.Price,
+		arg.Deliverables,
+		arg.StreamCap,
+		arg.DistributionCap,
+		arg.SalesCap,
+	)
+	return err
+}
+
+const saveLike = `-- name: SaveLike :exec
+insert into beats_likes ("beat_id", "user_id") values ($1, $2)
+on conflict ("beat_id", "user_id") do nothing
+`
+
+type SaveLikeParams struct {
+	BeatID uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) SaveLike(ctx context.Context, arg SaveLikeParams) error {
+	_, err := q.db.Exec(ctx, saveLike, arg.BeatID, arg.UserID)
+	return err
+}
+
+const saveListeningSession = `-- name: SaveListeningSession :exec
+insert into listening_sessions ("id", "beat_id", "user_id", "source", "client")
+values ($1, $2, $3, $4, $5)
+on conflict ("id") do nothing
+`
+
+type SaveListeningSessionParams struct {
+	ID     uuid.UUID
+	BeatID uuid.UUID
+	UserID pgtype.UUID
+	Source string
+	Client string
+}
+
+func (q *Queries) SaveListeningSession(ctx context.Context, arg SaveListeningSessionParams) error {
+	_, err := q.db.Exec(ctx, saveListeningSession,
+		arg.ID,
+		arg.BeatID,
+		arg.UserID,
+		arg.Source,
+		arg.Client,
+	)
+	return err
+}
+
+const saveNote = `-- name: SaveNote :exec
+insert into beats_notes ("beat_id", "note_id", "scale")
+values ($1, $2, $3)
+`
+
+type SaveNoteParams struct {
+	BeatID uuid.UUID
+	NoteID uuid.UUID
+	Scale  NoteScale
+}
+
+func (q *Queries) SaveNote(ctx context.Context, arg SaveNoteParams) error {
+	_, err := q.db.Exec(ctx, saveNote, arg.BeatID, arg.NoteID, arg.Scale)
+	return err
+}
+
+const saveOrder = `-- name: SaveOrder :one
+insert into orders ("user_id", "beat_id", "tier", "price", "currency", "deliverables", "stream_cap", "distribution_cap", "sales_cap", "provider")
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+returning id, user_id, beat_id, tier, price, currency, deliverables, stream_cap, distribution_cap, sales_cap, status, provider, checkout_id, checkout_url, created_at, updated_at, paid_at
+`
+
+type SaveOrderParams struct {
+	UserID          uuid.UUID
+	BeatID          uuid.UUID
+	Tier            LicenseTier
+	Price           int64
+	Currency        string
+	Deliverables    []string
+	StreamCap       *int32
+	DistributionCap *int32
+	SalesCap        *int32
+	Provider        string
+}
+
+func (q *Queries) SaveOrder(ctx context.Context, arg SaveOrderParams) (Order, error) {
+	row := q.db.QueryRow(ctx, saveOrder,
+		arg.UserID,
+		arg.BeatID,
+		arg.Tier,
+		arg.Price,
+		arg.Currency,
+		arg.Deliverables,
+		arg.StreamCap,
+		arg.DistributionCap,
+		arg.SalesCap,
+		arg.Provider,
+	)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.BeatID,
+		&i.Tier,
+		&i.Price,
+		&i.Currency,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.SalesCap,
+		&i.Status,
+		&i.Provider,
+		&i.CheckoutID,
+		&i.CheckoutUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidAt,
+	)
+	return i, err
+}
+
+const savePinnedBeats = `-- name: SavePinnedBeats :exec
+insert into beatmakers_pinned_beats ("beatmaker_id", "beat_id", "position")
+select $1::uuid, o."beat_id", o."position"
+from unnest($2::uuid[]) with ordinality as o("beat_id", "position")
+`
+
+type SavePinnedBeatsParams struct {
+	BeatmakerID uuid.UUID
+	BeatIds     []uuid.UUID
+}
+
+func (q *Queries) SavePinnedBeats(ctx context.Context, arg SavePinnedBeatsParams) error {
+	_, err := q.db.Exec(ctx, savePinnedBeats, arg.BeatmakerID, arg.BeatIds)
+	return err
+}
+
+const savePlaylist = `-- name: SavePlaylist :one
+insert into playlists ("user_id", "name", "visibility", "share_token")
+values ($1, $2, $3, $4)
+returning id, user_id, name, visibility, share_token, created_at, updated_at
+`
+
+type SavePlaylistParams struct {
+	UserID     uuid.UUID
+	Name       string
+	Visibility PlaylistVisibility
+	ShareToken string
+}
+
+func (q *Queries) SavePlaylist(ctx context.Context, arg SavePlaylistParams) (Playlist, error) {
+	row := q.db.QueryRow(ctx, savePlaylist,
+		arg.UserID,
+		arg.Name,
+		arg.Visibility,
+		arg.ShareToken,
+	)
+	var i Playlist
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const savePlaylistBeat = `-- name: SavePlaylistBeat :exec
+insert into playlists_beats ("playlist_id", "beat_id", "position")
+values ($1, $2, $3)
+`
+
+type SavePlaylistBeatParams struct {
+	PlaylistID uuid.UUID
+	BeatID     uuid.UUID
+	Position   int32
+}
+
+func (q *Queries) SavePlaylistBeat(ctx context.Context, arg SavePlaylistBeatParams) error {
+	_, err := q.db.Exec(ctx, savePlaylistBeat, arg.PlaylistID, arg.BeatID, arg.Position)
+	return err
+}
+
+const saveSignal = `-- name: SaveSignal :exec
+insert into beats_signals ("beat_id", "kind") values ($1, $2)
+`
+
+type SaveSignalParams struct {
+	BeatID uuid.UUID
+	Kind   BeatSignal
+}
+
+func (q *Queries) SaveSignal(ctx context.Context, arg SaveSignalParams) error {
+	_, err := q.db.Exec(ctx, saveSignal, arg.BeatID, arg.Kind)
+	return err
+}
+
+const saveTag = `-- name: SaveTag :exec
+insert into tags ("name", "slug", "position")
+values ($1, $2, (select coalesce(max("position"), 0) + 1 from tags))
+on conflict do nothing
+`
+
+type SaveTagParams struct {
+	Name string
+	Slug string
+}
+
+func (q *Queries) SaveTag(ctx context.Context, arg SaveTagParams) error {
+	_, err := q.db.Exec(ctx, saveTag, arg.Name, arg.Slug)
+	return err
+}
+
+const setLicenseAgreement = `-- name: SetLicenseAgreement :exec
+update beat_licenses set "agreement_path" = $4
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3
+`
+
+type SetLicenseAgreementParams struct {
+	BeatID        uuid.UUID
+	UserID        uuid.UUID
+	Tier          LicenseTier
+	AgreementPath *string
+}
+
+func (q *Queries) SetLicenseAgreement(ctx context.Context, arg SetLicenseAgreementParams) error {
+	_, err := q.db.Exec(ctx, setLicenseAgreement,
+		arg.BeatID,
+		arg.UserID,
+		arg.Tier,
+		arg.AgreementPath,
+	)
+	return err
+}
+
+const setOrderCheckout = `-- name: SetOrderCheckout :one
+update orders
+set "checkout_id" = $2, "checkout_url" = $3, "updated_at" = now()
+where "id" = $1
+returning id, user_id, beat_id, tier, price, currency, deliverables, stream_cap, distribution_cap, sales_cap, status, provider, checkout_id, checkout_url, created_at, updated_at, paid_at
+`
+
+type SetOrderCheckoutParams struct {
+	ID          uuid.UUID
+	CheckoutID  *string
+	CheckoutUrl *string
+}
+
+func (q *Queries) SetOrderCheckout(ctx context.Context, arg SetOrderCheckoutParams) (Order, error) {
+	row := q.db.QueryRow(ctx, setOrderCheckout, arg.ID, arg.CheckoutID, arg.CheckoutUrl)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.BeatID,
+		&i.Tier,
+		&i.Price,
+		&i.Currency,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.SalesCap,
+		&i.Status,
+		&i.Provider,
+		&i.CheckoutID,
+		&i.CheckoutUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidAt,
+	)
+	return i, err
+}
+
+const shiftPlaylistBeats = `-- name: ShiftPlaylistBeats :exec
+update playlists_beats
+set "position" = "position" + $1::int
+where "playlist_id" = $2 and "position" >= $3::int
+`
+
+type ShiftPlaylistBeatsParams struct {
+	Delta        int32
+	PlaylistID   uuid.UUID
+	FromPosition int32
+}
+
+func (q *Queries) ShiftPlaylistBeats(ctx context.Context, arg ShiftPlaylistBeatsParams) error {
+	_, err := q.db.Exec(ctx, shiftPlaylistBeats, arg.Delta, arg.PlaylistID, arg.FromPosition)
+	return err
+}
+
+type SaveTagsParams struct {
+	BeatID uuid.UUID
+	TagID  uuid.UUID
+}
+
+const updateBeat = `-- name: UpdateBeat :one
+update beats
+set "name" = coalesce($1, "name"),
+    "bpm" = coalesce($2, "bpm"),
+    "description" = coalesce($3, "description"),
+    "range_start" = coalesce($4, "range_start"),
+    "range_end" = coalesce($5, "range_end"),
+    "is_image_downloaded" = coalesce($6, "is_image_downloaded"),
+    "is_file_downloaded" = coalesce($7, "is_file_downloaded"),
+    "is_archive_downloaded" = coalesce($8, "is_archive_downloaded"),
+    "updated_at" = now()
+where "id" = $9 and "is_deleted" = false
+returning id, beatmaker_id, file_path, image_path, archive_path, name, description, is_file_downloaded, is_image_downloaded, is_archive_downloaded, range_start, range_end, is_deleted, created_at, updated_at, bpm
+`
+
+type UpdateBeatParams struct {
+	Name                *string
 	Bpm                 *int32
 	Description         *string
 	RangeStart          *int64
@@ -333,3 +2012,114 @@ func (q *Queries) UpdateBeat(ctx context.Context, arg UpdateBeatParams) (Beat, e
 	)
 	return i, err
 }
+
+const updateListeningSession = `-- name: UpdateListeningSession :one
+with prev as (
+    select "id", "is_qualified" from listening_sessions
+    where "id" = $1 and "beat_id" = $2
+    for update
+)
+update listening_sessions ls
+set "seconds_played" = greatest(ls."seconds_played", least($3::int, extract(epoch from now()::timestamp - ls."created_at")::int + $4::int)),
+    "is_qualified" = ls."is_qualified" or greatest(ls."seconds_played", least($3::int, extract(epoch from now()::timestamp - ls."created_at")::int + $4::int)) >= $5::int,
+    "updated_at" = now()
+from prev
+where ls."id" = prev."id"
+returning ls."seconds_played", ls."is_qualified", prev."is_qualified" as "was_qualified"
+`
+
+type UpdateListeningSessionParams struct {
+	ID            uuid.UUID
+	BeatID        uuid.UUID
+	SecondsPlayed int32
+	Slack         int32
+	Threshold     int32
+}
+
+type UpdateListeningSessionRow struct {
+	SecondsPlayed int32
+	IsQualified   bool
+	WasQualified  bool
+}
+
+func (q *Queries) UpdateListeningSession(ctx context.Context, arg UpdateListeningSessionParams) (UpdateListeningSessionRow, error) {
+	row := q.db.QueryRow(ctx, updateListeningSession,
+		arg.ID,
+		arg.BeatID,
+		arg.SecondsPlayed,
+		arg.Slack,
+		arg.Threshold,
+	)
+	var i UpdateListeningSessionRow
+	err := row.Scan(&i.SecondsPlayed, &i.IsQualified, &i.WasQualified)
+	return i, err
+}
+
+const updateOrderStatus = `-- name: UpdateOrderStatus :one
+update orders
+set "status" = $1,
+    "paid_at" = case when $1 = 'paid'::order_status then now() else "paid_at" end,
+    "updated_at" = now()
+where "id" = $2
+returning id, user_id, beat_id, tier, price, currency, deliverables, stream_cap, distribution_cap, sales_cap, status, provider, checkout_id, checkout_url, created_at, updated_at, paid_at
+`
+
+type UpdateOrderStatusParams struct {
+	Status OrderStatus
+	ID     uuid.UUID
+}
+
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
+	row := q.db.QueryRow(ctx, updateOrderStatus, arg.Status, arg.ID)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.BeatID,
+		&i.Tier,
+		&i.Price,
+		&i.Currency,
+		&i.Deliverables,
+		&i.StreamCap,
+		&i.DistributionCap,
+		&i.SalesCap,
+		&i.Status,
+		&i.Provider,
+		&i.CheckoutID,
+		&i.CheckoutUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidAt,
+	)
+	return i, err
+}
+
+const updatePlaylist = `-- name: UpdatePlaylist :one
+update playlists
+set "name" = coalesce($1, "name"),
+    "visibility" = coalesce($2, "visibility"),
+    "updated_at" = now()
+where "id" = $3
+returning id, user_id, name, visibility, share_token, created_at, updated_at
+`
+
+type UpdatePlaylistParams struct {
+	Name       *string
+	Visibility NullPlaylistVisibility
+	ID         uuid.UUID
+}
+
+func (q *Queries) UpdatePlaylist(ctx context.Context, arg UpdatePlaylistParams) (Playlist, error) {
+	row := q.db.QueryRow(ctx, updatePlaylist, arg.Name, arg.Visibility, arg.ID)
+	var i Playlist
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Visibility,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,3 @@
+drop table if exists "beats_trending" cascade;
+drop table if exists "beats_signals" cascade;
+drop type if exists "beat_signal" cascade;
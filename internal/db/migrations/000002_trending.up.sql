@@ -0,0 +1,18 @@
+create type "beat_signal" as enum ('play', 'acquisition');
+
+create table if not exists "beats_signals" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "beat_id" uuid not null references "beats" ("id"),
+    "kind" beat_signal not null,
+    "created_at" timestamp not null default current_timestamp
+);
+
+create index on "beats_signals" ("created_at");
+
+create table if not exists "beats_trending" (
+    "beat_id" uuid primary key references "beats" ("id"),
+    "score" double precision not null,
+    "updated_at" timestamp not null default current_timestamp
+);
+
+create index on "beats_trending" ("score");
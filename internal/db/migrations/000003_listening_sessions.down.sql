@@ -0,0 +1 @@
+drop table if exists "listening_sessions" cascade;
@@ -0,0 +1,14 @@
+create table if not exists "listening_sessions" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "beat_id" uuid not null references "beats" ("id"),
+    "user_id" uuid,
+    "source" varchar(64) not null,
+    "client" varchar(256) not null,
+    "seconds_played" integer not null default 0,
+    "is_qualified" boolean not null default false,
+    "created_at" timestamp not null default current_timestamp,
+    "updated_at" timestamp not null default current_timestamp
+);
+
+create index on "listening_sessions" ("beat_id");
+create index on "listening_sessions" ("user_id");
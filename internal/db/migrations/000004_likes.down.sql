@@ -0,0 +1 @@
+drop table if exists "beats_likes" cascade;
@@ -0,0 +1,8 @@
+create table if not exists "beats_likes" (
+    "beat_id" uuid not null references "beats" ("id"),
+    "user_id" uuid not null,
+    "created_at" timestamp not null default current_timestamp,
+    primary key ("beat_id", "user_id")
+);
+
+create index on "beats_likes" ("user_id");
@@ -0,0 +1,3 @@
+drop table if exists "playlists_beats" cascade;
+drop table if exists "playlists" cascade;
+drop type if exists "playlist_visibility" cascade;
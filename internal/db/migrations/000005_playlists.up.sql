@@ -0,0 +1,23 @@
+create type "playlist_visibility" as enum ('private', 'unlisted', 'public');
+
+create table if not exists "playlists" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "user_id" uuid not null,
+    "name" varchar(128) not null,
+    "visibility" playlist_visibility not null default 'private',
+    "share_token" varchar(32) not null unique,
+    "created_at" timestamp not null default current_timestamp,
+    "updated_at" timestamp not null default current_timestamp
+);
+
+create index on "playlists" ("user_id");
+
+create table if not exists "playlists_beats" (
+    "playlist_id" uuid not null references "playlists" ("id") on delete cascade,
+    "beat_id" uuid not null references "beats" ("id"),
+    "position" integer not null,
+    "created_at" timestamp not null default current_timestamp,
+    primary key ("playlist_id", "beat_id")
+);
+
+create index on "playlists_beats" ("playlist_id", "position");
@@ -0,0 +1,18 @@
+drop index if exists "beats_genres_genre_id_idx";
+drop index if exists "beats_tags_tag_id_idx";
+drop index if exists "beats_moods_mood_id_idx";
+drop index if exists "beats_notes_note_id_idx";
+
+drop index if exists "genres_slug_key";
+drop index if exists "genres_name_key";
+drop index if exists "tags_slug_key";
+drop index if exists "tags_name_key";
+drop index if exists "moods_slug_key";
+drop index if exists "moods_name_key";
+drop index if exists "notes_slug_key";
+drop index if exists "notes_name_key";
+
+alter table "genres" drop column if exists "slug", drop column if exists "position", drop column if exists "is_archived";
+alter table "tags" drop column if exists "slug", drop column if exists "position", drop column if exists "is_archived";
+alter table "moods" drop column if exists "slug", drop column if exists "position", drop column if exists "is_archived";
+alter table "notes" drop column if exists "slug", drop column if exists "position", drop column if exists "is_archived";
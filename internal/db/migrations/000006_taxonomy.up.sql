@@ -0,0 +1,71 @@
+alter table "genres"
+    add column "slug" varchar(64),
+    add column "position" integer not null default 0,
+    add column "is_archived" boolean not null default false;
+
+alter table "tags"
+    add column "slug" varchar(64),
+    add column "position" integer not null default 0,
+    add column "is_archived" boolean not null default false;
+
+alter table "moods"
+    add column "slug" varchar(64),
+    add column "position" integer not null default 0,
+    add column "is_archived" boolean not null default false;
+
+alter table "notes"
+    add column "slug" varchar(64),
+    add column "position" integer not null default 0,
+    add column "is_archived" boolean not null default false;
+
+update "genres" set "slug" = trim(both '-' from lower(regexp_replace("name", '[^a-zA-Z0-9]+', '-', 'g')));
+update "tags" set "slug" = trim(both '-' from lower(regexp_replace("name", '[^a-zA-Z0-9]+', '-', 'g')));
+update "notes" set "slug" = lower(replace("name", '#', '-sharp'));
+
+update "moods" set "slug" = case "name"
+    when 'качовый' then 'kachovyi'
+    when 'темный' then 'temnyi'
+    when 'меланхоличный' then 'melankholichnyi'
+    when 'лиричный' then 'lirichnyi'
+    when 'спокойный' then 'spokoinyi'
+    when 'агрессивный' then 'agressivnyi'
+    when 'грустный' then 'grustnyi'
+    when 'депрессивный' then 'depressivnyi'
+    when 'энергичный' then 'energichnyi'
+    else "id"::text
+end;
+
+update "genres" g set "position" = o."position"
+from (select "id", row_number() over (order by "name") as "position" from "genres") o
+where g."id" = o."id";
+
+update "tags" t set "position" = o."position"
+from (select "id", row_number() over (order by "name") as "position" from "tags") o
+where t."id" = o."id";
+
+update "moods" m set "position" = o."position"
+from (select "id", row_number() over (order by "name") as "position" from "moods") o
+where m."id" = o."id";
+
+update "notes" n set "position" = o."position"
+from (select "id", row_number() over (order by "name") as "position" from "notes") o
+where n."id" = o."id";
+
+alter table "genres" alter column "slug" set not null;
+alter table "tags" alter column "slug" set not null;
+alter table "moods" alter column "slug" set not null;
+alter table "notes" alter column "slug" set not null;
+
+create unique index "genres_slug_key" on "genres" ("slug");
+create unique index "genres_name_key" on "genres" (lower("name"));
+create unique index "tags_slug_key" on "tags" ("slug");
+create unique index "tags_name_key" on "tags" (lower("name"));
+create unique index "moods_slug_key" on "moods" ("slug");
+create unique index "moods_name_key" on "moods" (lower("name"));
+create unique index "notes_slug_key" on "notes" ("slug");
+create unique index "notes_name_key" on "notes" (lower("name"));
+
+create index on "beats_genres" ("genre_id");
+create index on "beats_tags" ("tag_id");
+create index on "beats_moods" ("mood_id");
+create index on "beats_notes" ("note_id");
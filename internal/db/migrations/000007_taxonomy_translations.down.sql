@@ -0,0 +1,4 @@
+drop table if exists "genres_translations";
+drop table if exists "tags_translations";
+drop table if exists "moods_translations";
+drop table if exists "notes_translations";
@@ -0,0 +1,82 @@
+create table if not exists "genres_translations" (
+    "genre_id" uuid not null references "genres" ("id") on delete cascade,
+    "locale" varchar(16) not null,
+    "name" varchar(64) not null,
+    primary key ("genre_id", "locale")
+);
+
+create table if not exists "tags_translations" (
+    "tag_id" uuid not null references "tags" ("id") on delete cascade,
+    "locale" varchar(16) not null,
+    "name" varchar(64) not null,
+    primary key ("tag_id", "locale")
+);
+
+create table if not exists "moods_translations" (
+    "mood_id" uuid not null references "moods" ("id") on delete cascade,
+    "locale" varchar(16) not null,
+    "name" varchar(64) not null,
+    primary key ("mood_id", "locale")
+);
+
+create table if not exists "notes_translations" (
+    "note_id" uuid not null references "notes" ("id") on delete cascade,
+    "locale" varchar(16) not null,
+    "name" varchar(64) not null,
+    primary key ("note_id", "locale")
+);
+
+insert into "moods_translations" ("mood_id", "locale", "name")
+select m."id", t."locale", t."name"
+from "moods" m
+join (values
+    ('качовый', 'en', 'Bouncy'),
+    ('темный', 'en', 'Dark'),
+    ('меланхоличный', 'en', 'Melancholic'),
+    ('лиричный', 'en', 'Lyrical'),
+    ('спокойный', 'en', 'Calm'),
+    ('агрессивный', 'en', 'Aggressive'),
+    ('грустный', 'en', 'Sad'),
+    ('депрессивный', 'en', 'Depressive'),
+    ('энергичный', 'en', 'Energetic')
+) t ("mood", "locale", "name") on m."name" = t."mood"
+on conflict do nothing;
+
+insert into "genres_translations" ("genre_id", "locale", "name")
+select g."id", t."locale", t."name"
+from "genres" g
+join (values
+    ('Hip-hop', 'ru', 'Хип-хоп'),
+    ('Trap', 'ru', 'Трэп'),
+    ('Rnb', 'ru', 'R&B'),
+    ('Pop', 'ru', 'Поп'),
+    ('Electronic', 'ru', 'Электроника'),
+    ('House', 'ru', 'Хаус'),
+    ('Lo-Fi', 'ru', 'Лоу-фай'),
+    ('Drill', 'ru', 'Дрилл'),
+    ('Techno', 'ru', 'Техно'),
+    ('UK Garage', 'ru', 'UK гэридж'),
+    ('Drum and Bass', 'ru', 'Драм-н-бейс'),
+    ('Jungle', 'ru', 'Джангл'),
+    ('Hyperpop', 'ru', 'Гиперпоп')
+) t ("genre", "locale", "name") on g."name" = t."genre"
+on conflict do nothing;
+
+insert into "notes_translations" ("note_id", "locale", "name")
+select n."id", t."locale", t."name"
+from "notes" n
+join (values
+    ('C', 'ru', 'До'),
+    ('C#', 'ru', 'До-диез'),
+    ('D', 'ru', 'Ре'),
+    ('D#', 'ru', 'Ре-диез'),
+    ('E', 'ru', 'Ми'),
+    ('F', 'ru', 'Фа'),
+    ('F#', 'ru', 'Фа-диез'),
+    ('G', 'ru', 'Соль'),
+    ('G#', 'ru', 'Соль-диез'),
+    ('A', 'ru', 'Ля'),
+    ('A#', 'ru', 'Ля-диез'),
+    ('B', 'ru', 'Си')
+) t ("note", "locale", "name") on n."name" = t."note"
+on conflict do nothing;
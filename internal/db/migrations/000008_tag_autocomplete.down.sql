@@ -0,0 +1,2 @@
+drop index if exists "tags_name_prefix_idx";
+drop index if exists "tags_slug_prefix_idx";
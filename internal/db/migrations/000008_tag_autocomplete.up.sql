@@ -0,0 +1,2 @@
+create index if not exists "tags_name_prefix_idx" on "tags" (lower("name") text_pattern_ops);
+create index if not exists "tags_slug_prefix_idx" on "tags" ("slug" text_pattern_ops);
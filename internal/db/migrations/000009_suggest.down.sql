@@ -0,0 +1,7 @@
+drop index if exists "tags_translations_name_prefix_idx";
+drop index if exists "genres_translations_name_prefix_idx";
+drop index if exists "genres_slug_prefix_idx";
+drop index if exists "genres_name_prefix_idx";
+drop index if exists "beats_name_prefix_idx";
+
+drop table if exists "beatmakers";
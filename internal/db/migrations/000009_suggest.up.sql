@@ -0,0 +1,16 @@
+create table if not exists "beatmakers" (
+    "id" uuid primary key,
+    "username" varchar(64) not null,
+    "pseudonym" varchar(64) not null,
+    "updated_at" timestamp not null default current_timestamp
+);
+
+create index if not exists "beatmakers_pseudonym_prefix_idx" on "beatmakers" (lower("pseudonym") text_pattern_ops);
+create index if not exists "beatmakers_username_prefix_idx" on "beatmakers" (lower("username") text_pattern_ops);
+
+create index if not exists "beats_name_prefix_idx" on "beats" (lower("name") text_pattern_ops) where "is_deleted" = false;
+
+create index if not exists "genres_name_prefix_idx" on "genres" (lower("name") text_pattern_ops);
+create index if not exists "genres_slug_prefix_idx" on "genres" ("slug" text_pattern_ops);
+create index if not exists "genres_translations_name_prefix_idx" on "genres_translations" (lower("name") text_pattern_ops);
+create index if not exists "tags_translations_name_prefix_idx" on "tags_translations" (lower("name") text_pattern_ops);
@@ -0,0 +1 @@
+drop table if exists "beatmakers_pinned_beats" cascade;
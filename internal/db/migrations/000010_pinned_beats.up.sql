@@ -0,0 +1,8 @@
+create table if not exists "beatmakers_pinned_beats" (
+    "beatmaker_id" uuid not null,
+    "beat_id" uuid not null references "beats" ("id") on delete cascade,
+    "position" integer not null,
+    primary key ("beatmaker_id", "beat_id")
+);
+
+create index on "beatmakers_pinned_beats" ("beatmaker_id", "position");
@@ -0,0 +1,8 @@
+delete from "beats_owners" where "tier" <> 'exclusive';
+alter table "beats_owners" drop constraint "beats_owners_pkey";
+alter table "beats_owners" add primary key ("beat_id");
+alter table "beats_owners" drop column if exists "created_at";
+alter table "beats_owners" drop column if exists "tier";
+
+drop table if exists "beats_license_tiers" cascade;
+drop type if exists "license_tier" cascade;
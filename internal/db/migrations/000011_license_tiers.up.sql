@@ -0,0 +1,22 @@
+create type "license_tier" as enum ('mp3_lease', 'wav_lease', 'trackout', 'exclusive');
+
+-- Prices are in minor units of the currency from the config.
+-- Deliverables are the media of the beat the buyer can download.
+-- Null caps are unlimited.
+create table if not exists "beats_license_tiers" (
+    "beat_id" uuid not null references "beats" ("id") on delete cascade,
+    "tier" license_tier not null,
+    "price" bigint not null check ("price" >= 0),
+    "deliverables" text[] not null check (cardinality("deliverables") > 0 and "deliverables" <@ array['file', 'archive']),
+    "stream_cap" integer check ("stream_cap" > 0),
+    "distribution_cap" integer check ("distribution_cap" > 0),
+    "created_at" timestamp not null default current_timestamp,
+    "updated_at" timestamp not null default current_timestamp,
+    primary key ("beat_id", "tier")
+);
+
+-- Sales before tiers were exclusive.
+alter table "beats_owners" add column "tier" license_tier not null default 'exclusive';
+alter table "beats_owners" add column "created_at" timestamp not null default current_timestamp;
+alter table "beats_owners" drop constraint "beats_owners_pkey";
+alter table "beats_owners" add primary key ("beat_id", "user_id", "tier");
@@ -0,0 +1,14 @@
+create table "beats_owners" (
+    "beat_id" uuid not null references "beats" ("id"),
+    "user_id" uuid not null,
+    "tier" license_tier not null default 'exclusive',
+    "created_at" timestamp not null default current_timestamp,
+    primary key ("beat_id", "user_id", "tier")
+);
+
+insert into "beats_owners" ("beat_id", "user_id", "tier", "created_at")
+select "beat_id", "user_id", "tier", "created_at" from "beat_licenses";
+
+drop table if exists "beat_licenses" cascade;
+
+alter table "beats_license_tiers" drop column if exists "sales_cap";
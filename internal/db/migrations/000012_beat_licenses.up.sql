@@ -0,0 +1,24 @@
+-- Null is unlimited, exclusive tiers are sold once.
+alter table "beats_license_tiers" add column "sales_cap" integer check ("sales_cap" > 0);
+
+-- A license keeps the terms of its tier at the time of the sale.
+create table if not exists "beat_licenses" (
+    "beat_id" uuid not null references "beats" ("id"),
+    "user_id" uuid not null,
+    "tier" license_tier not null,
+    "price" bigint not null,
+    "deliverables" text[] not null,
+    "stream_cap" integer,
+    "distribution_cap" integer,
+    "created_at" timestamp not null default current_timestamp,
+    primary key ("beat_id", "user_id", "tier")
+);
+
+create index on "beat_licenses" ("user_id");
+
+insert into "beat_licenses" ("beat_id", "user_id", "tier", "price", "deliverables", "stream_cap", "distribution_cap", "created_at")
+select o."beat_id", o."user_id", o."tier", coalesce(t."price", 0), coalesce(t."deliverables", array['archive']), t."stream_cap", t."distribution_cap", o."created_at"
+from "beats_owners" o
+left join "beats_license_tiers" t on t."beat_id" = o."beat_id" and t."tier" = o."tier";
+
+drop table if exists "beats_owners" cascade;
@@ -0,0 +1,10 @@
+-- Idempotency keys of the purchases of beat licenses, a retried purchase with the key of
+-- a recorded one gives its license again.
+create table if not exists "beat_acquisitions" (
+    "user_id" uuid not null,
+    "idempotency_key" text not null,
+    "beat_id" uuid not null references "beats" ("id"),
+    "tier" license_tier not null,
+    "created_at" timestamp not null default current_timestamp,
+    primary key ("user_id", "idempotency_key")
+);
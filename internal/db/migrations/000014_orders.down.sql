@@ -0,0 +1,3 @@
+drop table if exists "orders";
+
+drop type if exists "order_status";
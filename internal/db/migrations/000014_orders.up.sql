@@ -0,0 +1,27 @@
+create type "order_status" as enum ('pending', 'paid', 'failed', 'rejected');
+
+-- Orders of beat licenses. An order quotes the terms of the tier when it is created, the
+-- license is sold under them once the payment provider confirms the payment. Paid orders
+-- the license can no longer be sold for are rejected.
+create table if not exists "orders" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "user_id" uuid not null,
+    "beat_id" uuid not null references "beats" ("id"),
+    "tier" license_tier not null,
+    "price" bigint not null check ("price" >= 0),
+    "currency" varchar(3) not null,
+    "deliverables" text[] not null,
+    "stream_cap" integer,
+    "distribution_cap" integer,
+    "sales_cap" integer,
+    "status" order_status not null default 'pending',
+    "provider" varchar(32) not null,
+    "checkout_id" text,
+    "checkout_url" text,
+    "created_at" timestamp not null default current_timestamp,
+    "updated_at" timestamp not null default current_timestamp,
+    "paid_at" timestamp
+);
+
+create index on "orders" ("user_id");
+create unique index on "orders" ("provider", "checkout_id");
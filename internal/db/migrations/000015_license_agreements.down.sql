@@ -0,0 +1,3 @@
+alter table "beat_licenses"
+    drop column if exists "agreement_path",
+    drop column if exists "number";
@@ -0,0 +1,6 @@
+-- Every license gets a unique number, printed on its agreement, and the path of the
+-- agreement PDF in the bucket once it is rendered.
+alter table "beat_licenses"
+    add column "number" varchar(16) not null unique
+        default 'BF-' || upper(substr(md5(random()::text || clock_timestamp()::text), 1, 12)),
+    add column "agreement_path" text;
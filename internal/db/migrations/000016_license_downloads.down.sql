@@ -0,0 +1,5 @@
+drop table if exists "license_audit";
+
+alter table "beat_licenses" drop column if exists "downloads";
+
+drop type if exists "license_audit_action";
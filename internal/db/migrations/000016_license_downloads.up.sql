@@ -0,0 +1,24 @@
+create type "license_audit_action" as enum ('download', 'reset');
+
+-- Every license counts the download links issued for its deliverable, up to the download
+-- limit of the service.
+alter table "beat_licenses" add column "downloads" integer not null default 0;
+
+-- The audit log of the licenses: every issued download link, with the client it was
+-- issued to, and every reset of the download counter by an admin.
+create table if not exists "license_audit" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "beat_id" uuid not null,
+    "user_id" uuid not null,
+    "tier" license_tier not null,
+    "action" license_audit_action not null,
+    "actor_id" uuid,
+    "ip" varchar(64),
+    "user_agent" text,
+    "url" text,
+    "expires_at" timestamp,
+    "created_at" timestamp not null default current_timestamp,
+    foreign key ("beat_id", "user_id", "tier") references "beat_licenses" ("beat_id", "user_id", "tier")
+);
+
+create index on "license_audit" ("beat_id", "user_id", "tier", "created_at");
@@ -0,0 +1,19 @@
+alter table "license_audit" drop column if exists "reason";
+
+alter table "beat_licenses" drop column if exists "revoked_at";
+
+delete from "license_audit" where "action" = 'revoke';
+
+alter type "license_audit_action" rename to "license_audit_action_old";
+create type "license_audit_action" as enum ('download', 'reset');
+alter table "license_audit" alter column "action" type "license_audit_action" using "action"::text::"license_audit_action";
+drop type "license_audit_action_old";
+
+update "orders" set "status" = 'paid' where "status" = 'refunded';
+
+alter type "order_status" rename to "order_status_old";
+create type "order_status" as enum ('pending', 'paid', 'failed', 'rejected');
+alter table "orders" alter column "status" drop default;
+alter table "orders" alter column "status" type "order_status" using "status"::text::"order_status";
+alter table "orders" alter column "status" set default 'pending';
+drop type "order_status_old";
@@ -0,0 +1,10 @@
+alter type "order_status" add value if not exists 'refunded';
+
+alter type "license_audit_action" add value if not exists 'revoke';
+
+-- A license revoked by an admin after a refund or chargeback stays on record but is no
+-- longer valid: it delivers nothing and does not count against the sales of its beat.
+alter table "beat_licenses" add column "revoked_at" timestamp;
+
+-- The reason an admin gave for the action, for revocations.
+alter table "license_audit" add column "reason" text;
@@ -0,0 +1,3 @@
+drop table if exists "license_earnings" cascade;
+drop table if exists "beats_collaborators" cascade;
+drop type if exists "collaborator_role" cascade;
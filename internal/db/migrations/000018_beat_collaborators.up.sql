@@ -0,0 +1,37 @@
+create type "collaborator_role" as enum ('producer', 'co_producer', 'composer', 'songwriter', 'engineer', 'featured');
+
+-- The co-producers of a beat with their percent of its revenue. The shares of a beat add
+-- up to 100. A beat without collaborators earns its beatmaker everything.
+create table if not exists "beats_collaborators" (
+    "beat_id" uuid not null references "beats" ("id") on delete cascade,
+    "user_id" uuid not null,
+    "role" collaborator_role not null,
+    "share" integer not null check ("share" > 0 and "share" <= 100),
+    "created_at" timestamp not null default current_timestamp,
+    primary key ("beat_id", "user_id")
+);
+
+create index on "beats_collaborators" ("user_id");
+
+-- The part of the price of a license every collaborator earned by its sale, in minor
+-- units of the currency, with the role and share they had at the time.
+create table if not exists "license_earnings" (
+    "beat_id" uuid not null,
+    "user_id" uuid not null,
+    "tier" license_tier not null,
+    "collaborator_id" uuid not null,
+    "role" collaborator_role not null,
+    "share" integer not null,
+    "amount" bigint not null,
+    "created_at" timestamp not null default current_timestamp,
+    primary key ("beat_id", "user_id", "tier", "collaborator_id"),
+    foreign key ("beat_id", "user_id", "tier") references "beat_licenses" ("beat_id", "user_id", "tier")
+);
+
+create index on "license_earnings" ("collaborator_id", "created_at");
+
+-- Licenses sold before collaborators earned their beatmaker everything.
+insert into "license_earnings" ("beat_id", "user_id", "tier", "collaborator_id", "role", "share", "amount", "created_at")
+select bl."beat_id", bl."user_id", bl."tier", b."beatmaker_id", 'producer', 100, bl."price", bl."created_at"
+from "beat_licenses" bl
+join "beats" b on bl."beat_id" = b."id";
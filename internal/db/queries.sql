@@ -21,17 +21,28 @@ values ($1, $2, $3);
 -- name: GetBeatByID :one
 select * from beats where id = $1;
 
+-- name: GetBeatForUpdate :one
+select * from beats where id = $1 for update;
+
 -- name: GetBeatGenreParams :many
-select * from genres;
+select * from genres
+where "is_archived" = false
+order by "position", "name";
 
 -- name: GetBeatTagParams :many
-select * from tags;
+select * from tags
+where "is_archived" = false
+order by "position", "name";
 
 -- name: GetBeatMoodParams :many
-select * from moods;
+select * from moods
+where "is_archived" = false
+order by "position", "name";
 
 -- name: GetBeatNoteParams :many
-select * from notes;
+select * from notes
+where "is_archived" = false
+order by "position", "name";
 
 -- name: UpdateBeat :one
 update beats
@@ -65,8 +76,348 @@ set "is_deleted" = true,
     "updated_at" = now()
 where id = $1;
 
--- name: SaveOwner :exec
-insert into beats_owners ("beat_id", "user_id") values ($1, $2);
+-- name: SaveLicense :one
+insert into beat_licenses ("beat_id", "user_id", "tier", "price", "deliverables", "stream_cap", "distribution_cap")
+values ($1, $2, $3, $4, $5, $6, $7)
+returning *;
+
+-- name: GetBeatLicenses :many
+select * from beat_licenses where beat_id = $1;
+
+-- name: SaveSignal :exec
+insert into beats_signals ("beat_id", "kind") values ($1, $2);
+
+-- name: RefreshTrending :exec
+with scores as (
+    select "beat_id",
+           sum(case "kind" when 'acquisition' then @acquisition_weight::float8 else @play_weight::float8 end
+               * power(0.5, extract(epoch from now()::timestamp - "created_at") / @half_life::float8)) as "score"
+    from beats_signals
+    where "created_at" > now()::timestamp - make_interval(secs => @window::float8)
+    group by "beat_id"
+), stale as (
+    delete from beats_trending where "beat_id" not in (select "beat_id" from scores)
+)
+insert into beats_trending ("beat_id", "score", "updated_at")
+select "beat_id", "score", now() from scores
+on conflict ("beat_id") do update
+set "score" = excluded."score",
+    "updated_at" = excluded."updated_at";
+
+-- name: SaveListeningSession :exec
+insert into listening_sessions ("id", "beat_id", "user_id", "source", "client")
+values ($1, $2, $3, $4, $5)
+on conflict ("id") do nothing;
+
+-- name: UpdateListeningSession :one
+with prev as (
+    select "id", "is_qualified" from listening_sessions
+    where "id" = @id and "beat_id" = @beat_id
+    for update
+)
+update listening_sessions ls
+set "seconds_played" = greatest(ls."seconds_played", least(@seconds_played::int, extract(epoch from now()::timestamp - ls."created_at")::int + @slack::int)),
+    "is_qualified" = ls."is_qualified" or greatest(ls."seconds_played", least(@seconds_played::int, extract(epoch from now()::timestamp - ls."created_at")::int + @slack::int)) >= @threshold::int,
+    "updated_at" = now()
+from prev
+where ls."id" = prev."id"
+returning ls."seconds_played", ls."is_qualified", prev."is_qualified" as "was_qualified";
+
+-- name: GetBeatPlays :many
+select b."id", b."name",
+       count(ls."id") filter (where ls."is_qualified") as "plays",
+       count(ls."id") as "sessions",
+       coalesce(sum(ls."seconds_played"), 0)::bigint as "seconds_played"
+from beats b
+left join listening_sessions ls on b."id" = ls."beat_id"
+where b."beatmaker_id" = $1 and b."is_deleted" = false
+group by b."id"
+order by "plays" desc, b."created_at" desc;
+
+-- name: SaveLike :exec
+insert into beats_likes ("beat_id", "user_id") values ($1, $2)
+on conflict ("beat_id", "user_id") do nothing;
+
+-- name: DeleteLike :exec
+delete from beats_likes where "beat_id" = $1 and "user_id" = $2;
+
+-- name: CountBeatLikes :one
+select count(*) from beats_likes where "beat_id" = $1;
+
+-- name: SavePlaylist :one
+insert into playlists ("user_id", "name", "visibility", "share_token")
+values ($1, $2, $3, $4)
+returning *;
+
+-- name: GetPlaylistByID :one
+select * from playlists where "id" = $1;
+
+-- name: GetPlaylistByShareToken :one
+select * from playlists where "share_token" = $1;
+
+-- name: GetPlaylistsByUserID :many
+select * from playlists where "user_id" = $1
+order by "created_at" desc;
+
+-- name: GetPlaylistForUpdate :one
+select * from playlists where "id" = $1
+for update;
+
+-- name: UpdatePlaylist :one
+update playlists
+set "name" = coalesce(sqlc.narg('name'), "name"),
+    "visibility" = coalesce(sqlc.narg('visibility'), "visibility"),
+    "updated_at" = now()
+where "id" = sqlc.arg('id')
+returning *;
+
+-- name: DeletePlaylist :exec
+delete from playlists where "id" = $1;
+
+-- name: CountPlaylistBeats :one
+select count(*) from playlists_beats where "playlist_id" = $1;
+
+-- name: ShiftPlaylistBeats :exec
+update playlists_beats
+set "position" = "position" + @delta::int
+where "playlist_id" = @playlist_id and "position" >= @from_position::int;
+
+-- name: SavePlaylistBeat :exec
+insert into playlists_beats ("playlist_id", "beat_id", "position")
+values ($1, $2, $3);
+
+-- name: DeletePlaylistBeat :one
+delete from playlists_beats
+where "playlist_id" = $1 and "beat_id" = $2
+returning "position";
+
+-- name: GetPlaylistBeatIDs :many
+select "beat_id" from playlists_beats
+where "playlist_id" = $1
+order by "position";
+
+-- name: ReorderPlaylistBeats :exec
+update playlists_beats pb
+set "position" = o."position"
+from unnest(@beat_ids::uuid[]) with ordinality as o("beat_id", "position")
+where pb."playlist_id" = @playlist_id and pb."beat_id" = o."beat_id";
+
+-- name: HasArchivedTaxonomy :one
+select exists (select 1 from genres where "is_archived" and "id" = any(@genre_ids::uuid[]))
+    or exists (select 1 from tags where "is_archived" and "id" = any(@tag_ids::uuid[]))
+    or exists (select 1 from moods where "is_archived" and "id" = any(@mood_ids::uuid[]))
+    or exists (select 1 from notes where "is_archived" and "id" = any(@note_ids::uuid[]));
+
+-- name: SaveTag :exec
+insert into tags ("name", "slug", "position")
+values (@name, @slug, (select coalesce(max("position"), 0) + 1 from tags))
+on conflict do nothing;
+
+-- name: GetTagBySlugOrName :one
+select * from tags
+where "slug" = @slug or lower("name") = lower(@name)
+order by "slug" = @slug desc
+limit 1;
+
+-- name: GetTagsByPrefix :many
+select t."id", t."name", t."slug", count(b."id") as "beats"
+from tags t
+left join beats_tags bt on t."id" = bt."tag_id"
+left join beats b on bt."beat_id" = b."id" and b."is_deleted" = false
+where t."is_archived" = false
+  and (lower(t."name") like @name_pattern or t."slug" like @slug_pattern)
+group by t."id"
+order by "beats" desc, t."name"
+limit @max_tags;
+
+-- name: GetBeatmakerIDs :many
+select distinct "beatmaker_id" from beats where "is_deleted" = false;
+
+-- name: SaveBeatmaker :exec
+insert into beatmakers ("id", "username", "pseudonym")
+values (@id, @username, @pseudonym)
+on conflict ("id") do update
+set "username" = excluded."username",
+    "pseudonym" = excluded."pseudonym",
+    "updated_at" = now();
+
+-- name: GetBeatmakerStats :one
+select (select count(*) from beats b
+        where (b."beatmaker_id" = @beatmaker_id
+               or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = @beatmaker_id))
+          and b."is_deleted" = false
+          and b."is_file_downloaded" and b."is_image_downloaded" and b."is_archive_downloaded") as "beats",
+       (select count(*) from listening_sessions ls join beats b on ls."beat_id" = b."id"
+        where (b."beatmaker_id" = @beatmaker_id
+               or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = @beatmaker_id))
+          and ls."is_qualified") as "plays",
+       (select count(*) from beat_licenses bl join beats b on bl."beat_id" = b."id"
+        where (b."beatmaker_id" = @beatmaker_id
+               or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = @beatmaker_id))
+          and bl."revoked_at" is null) as "sales";
+
+-- name: CountBeatmakerBeats :one
+select count(*) from beats b
+where (b."beatmaker_id" = @beatmaker_id
+       or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = @beatmaker_id))
+  and b."id" = any(@beat_ids::uuid[]) and b."is_deleted" = false;
+
+-- name: DeletePinnedBeats :exec
+delete from beatmakers_pinned_beats where "beatmaker_id" = $1;
+
+-- name: SavePinnedBeats :exec
+insert into beatmakers_pinned_beats ("beatmaker_id", "beat_id", "position")
+select @beatmaker_id::uuid, o."beat_id", o."position"
+from unnest(@beat_ids::uuid[]) with ordinality as o("beat_id", "position");
+
+-- name: GetLicenseTiers :many
+select * from beats_license_tiers where "beat_id" = $1 order by "tier";
+
+-- name: DeleteLicenseTiers :exec
+delete from beats_license_tiers where "beat_id" = $1;
+
+-- name: SaveLicenseTier :exec
+insert into beats_license_tiers ("beat_id", "tier", "price", "deliverables", "stream_cap", "distribution_cap", "sales_cap")
+values ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetAcquisition :one
+select * from beat_acquisitions where "user_id" = $1 and "idempotency_key" = $2;
+
+-- name: SaveAcquisition :exec
+insert into beat_acquisitions ("user_id", "idempotency_key", "beat_id", "tier")
+values ($1, $2, $3, $4)
+on conflict do nothing;
+
+-- name: SaveOrder :one
+insert into orders ("user_id", "beat_id", "tier", "price", "currency", "deliverables", "stream_cap", "distribution_cap", "sales_cap", "provider")
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+returning *;
+
+-- name: GetOrderByID :one
+select * from orders where "id" = $1;
+
+-- name: GetPendingOrder :one
+select * from orders
+where "user_id" = $1 and "beat_id" = $2 and "tier" = $3 and "status" = 'pending' and "created_at" > $4
+order by "created_at" desc
+limit 1;
+
+-- name: GetOrderByCheckoutForUpdate :one
+select * from orders where "provider" = $1 and "checkout_id" = $2
+for update;
+
+-- name: SetOrderCheckout :one
+update orders
+set "checkout_id" = $2, "checkout_url" = $3, "updated_at" = now()
+where "id" = $1
+returning *;
+
+-- name: UpdateOrderStatus :one
+update orders
+set "status" = @status,
+    "paid_at" = case when @status = 'paid'::order_status then now() else "paid_at" end,
+    "updated_at" = now()
+where "id" = @id
+returning *;
+
+-- name: SetLicenseAgreement :exec
+update beat_licenses set "agreement_path" = $4
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3;
+
+-- name: GetLicenseByNumber :one
+select * from beat_licenses where "number" = $1;
+
+-- name: GetLicenseForUpdate :one
+select * from beat_licenses where "beat_id" = $1 and "user_id" = $2 and "tier" = $3
+for update;
+
+-- name: GetUserPurchases :many
+select bl."beat_id", b."name", bl."tier", bl."number", bl."price", bl."deliverables", bl."agreement_path", bl."created_at",
+       b."file_path", b."archive_path", b."is_file_downloaded", b."is_archive_downloaded", bl."downloads", bl."revoked_at"
+from beat_licenses bl
+join beats b on bl."beat_id" = b."id"
+where bl."user_id" = $1
+order by bl."created_at" desc, b."name";
+
+-- name: GetSalesReport :many
+select date_trunc(@period::text, bl."created_at")::timestamp as "period",
+       b."id", b."name",
+       count(*) as "units",
+       sum(bl."price")::bigint as "gross"
+from beat_licenses bl
+join beats b on bl."beat_id" = b."id"
+where (b."beatmaker_id" = @beatmaker_id
+       or exists (select 1 from beats_collaborators bc where bc."beat_id" = b."id" and bc."user_id" = @beatmaker_id))
+  and bl."revoked_at" is null
+  and (sqlc.narg('beat_id')::uuid is null or b."id" = sqlc.narg('beat_id'))
+  and (sqlc.narg('from')::timestamp is null or bl."created_at" >= sqlc.narg('from'))
+  and (sqlc.narg('to')::timestamp is null or bl."created_at" < sqlc.narg('to'))
+group by 1, b."id"
+order by 1, "gross" desc, b."name";
+
+-- name: IncrementLicenseDownloads :one
+update beat_licenses set "downloads" = "downloads" + 1
+where "beat_id" = @beat_id and "user_id" = @user_id and "tier" = @tier
+  and "revoked_at" is null
+  and (@download_limit::int = 0 or "downloads" < @download_limit::int)
+returning "downloads";
+
+-- name: ResetLicenseDownloads :exec
+update beat_licenses set "downloads" = 0
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3;
+
+-- name: SaveLicenseAudit :exec
+insert into license_audit ("beat_id", "user_id", "tier", "action", "actor_id", "ip", "user_agent", "url", "expires_at", "reason")
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetLiveDownload :one
+select * from license_audit
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3 and "action" = 'download' and "expires_at" > $4
+order by "created_at" desc
+limit 1;
+
+-- name: GetLicenseAudit :many
+select * from license_audit
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3
+order by "created_at" desc;
+
+-- name: RevokeLicense :one
+update beat_licenses set "revoked_at" = now()
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3 and "revoked_at" is null
+returning *;
+
+-- name: GetPaidLicenseOrders :many
+select * from orders
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3 and "status" = 'paid';
+
+-- name: RefundLicenseOrders :exec
+update orders set "status" = 'refunded', "updated_at" = now()
+where "beat_id" = $1 and "user_id" = $2 and "tier" = $3 and "status" = 'paid';
+
+-- name: RestoreBeat :exec
+update beats
+set "is_deleted" = false,
+    "updated_at" = now()
+where "id" = $1;
+
+-- name: GetBeatCollaborators :many
+select * from beats_collaborators where "beat_id" = $1 order by "share" desc, "user_id";
+
+-- name: DeleteBeatCollaborators :exec
+delete from beats_collaborators where "beat_id" = $1;
+
+-- name: SaveBeatCollaborator :exec
+insert into beats_collaborators ("beat_id", "user_id", "role", "share")
+values ($1, $2, $3, $4);
+
+-- name: SaveLicenseEarning :exec
+insert into license_earnings ("beat_id", "user_id", "tier", "collaborator_id", "role", "share", "amount")
+values ($1, $2, $3, $4, $5, $6, $7);
 
--- name: GetOwnerByBeatID :one
-select * from beats_owners where beat_id = $1;
\ No newline at end of file
+-- name: GetCollaboratorEarnings :many
+select le."beat_id", b."name", le."tier", bl."number", le."role", le."share", bl."price", le."amount", le."created_at"
+from license_earnings le
+join beat_licenses bl on le."beat_id" = bl."beat_id" and le."user_id" = bl."user_id" and le."tier" = bl."tier"
+join beats b on le."beat_id" = b."id"
+where le."collaborator_id" = $1 and bl."revoked_at" is null
+order by le."created_at" desc, b."name";
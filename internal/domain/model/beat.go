@@ -10,6 +10,30 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// OrderByTrending sorts beats by their trending score. Unlike the column sort keys
+// it is not part of the GetBeats proto.
+const OrderByTrending = "trending"
+
+type MatchMode string
+
+const (
+	MatchAny  MatchMode = "any"
+	MatchAll  MatchMode = "all"
+	MatchNone MatchMode = "none"
+)
+
+// ParseMatchMode parses the match mode of a genre, tag or mood filter.
+// Empty string means MatchAny.
+func ParseMatchMode(s string) (MatchMode, error) {
+	switch mode := MatchMode(s); mode {
+	case "":
+		return MatchAny, nil
+	case MatchAny, MatchAll, MatchNone:
+		return mode, nil
+	}
+	return "", NewErr(ErrValidationFailed, "match mode must be one of any, all or none")
+}
+
 type (
 	Beat struct {
 		ID                  uuid.UUID
@@ -29,6 +53,8 @@ type (
 		Moods               []string
 		NoteName            *string
 		NoteScale           *string
+		Likes               int64
+		Collaborators       []Collaborator
 	}
 
 	BeatsNote struct {
@@ -44,8 +70,11 @@ type (
 	GetBeatsParams struct {
 		BeatID       *uuid.UUID
 		Genre        []string
+		GenreMatch   MatchMode
 		Mood         []string
+		MoodMatch    MatchMode
 		Tag          []string
+		TagMatch     MatchMode
 		Note         *BeatsNote
 		BeatmakerID  *uuid.UUID
 		BeatName     *string
@@ -54,6 +83,56 @@ type (
 		Limit        uint64
 		Offset       uint64
 		IsDownloaded *bool
+		Filter       *string
+		Trending     bool
+		LikedBy      *uuid.UUID
+		PlaylistID   *uuid.UUID
+		// PinnedBy selects the beats pinned by the beatmaker in the order they were pinned.
+		PinnedBy *uuid.UUID
+		// Locales are the locales of genre, tag, mood and note names in order of
+		// preference. Names without a translation in any of them are returned as is.
+		Locales []string
+	}
+
+	// SimilarityWeights weigh the parts of the similarity score of two beats.
+	// Every part is in [0, 1] before weighting, BpmRange is the bpm difference
+	// at which bpm closeness drops to zero.
+	SimilarityWeights struct {
+		Bpm       float64
+		BpmRange  int64
+		Key       float64
+		Genres    float64
+		Moods     float64
+		Tags      float64
+		Beatmaker float64
+	}
+
+	// FacetCount counts the beats with the attribute Value. Label is its localized name.
+	FacetCount struct {
+		Value string
+		Label string
+		Count uint64
+	}
+
+	NoteFacetCount struct {
+		Name  string
+		Label string
+		Scale string
+		Count uint64
+	}
+
+	BpmFacetCount struct {
+		From  int64
+		To    int64
+		Count uint64
+	}
+
+	Facets struct {
+		Genres []FacetCount
+		Moods  []FacetCount
+		Tags   []FacetCount
+		Notes  []NoteFacetCount
+		Bpm    []BpmFacetCount
 	}
 
 	BeatAttributes struct {
@@ -215,11 +294,29 @@ func ToDomainGetBeatsParams(params *audiov1.GetBeatsRequest) (*GetBeatsParams, e
 	return &res, nil
 }
 
-func ToGetBeatsResponse(beats []Beat, users []*userv1.GetUserResponse, total uint64, params GetBeatsParams) *audiov1.GetBeatsResponse {
-	var res audiov1.GetBeatsResponse
+// unknownBeatmaker stands in for beatmakers whose profile could not be resolved.
+var unknownBeatmaker = &userv1.GetUserResponse{
+	Username:  "unknown",
+	Pseudonym: "Unknown beatmaker",
+}
+
+// BeatmakerIDs returns the beatmaker and the collaborators of every beat in beats.
+func BeatmakerIDs(beats []Beat) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(beats))
 	for i := range beats {
-		res.Beats = append(res.Beats, toResponseBeat(beats[i], users[i]))
+		ids = append(ids, beats[i].BeatmakerID)
+		for _, c := range beats[i].Collaborators {
+			ids = append(ids, c.UserID)
+		}
 	}
+	return ids
+}
+
+// ToGetBeatsResponse converts beats with their beatmakers from users. Beats whose
+// beatmaker is missing from users get placeholder beatmaker data.
+func ToGetBeatsResponse(beats []Beat, users map[uuid.UUID]*userv1.GetUserResponse, total uint64, params GetBeatsParams) *audiov1.GetBeatsResponse {
+	var res audiov1.GetBeatsResponse
+	res.Beats = ToResponseBeats(beats, users)
 	res.Pagination = &audiov1.Pagination{}
 	res.Pagination.Records = total
 	res.Pagination.RecordsPerPage = params.Limit
@@ -228,6 +325,19 @@ func ToGetBeatsResponse(beats []Beat, users []*userv1.GetUserResponse, total uin
 	return &res
 }
 
+// ToResponseBeats converts beats like ToGetBeatsResponse does, without pagination.
+func ToResponseBeats(beats []Beat, users map[uuid.UUID]*userv1.GetUserResponse) []*audiov1.Beat {
+	res := make([]*audiov1.Beat, 0, len(beats))
+	for i := range beats {
+		user, ok := users[beats[i].BeatmakerID]
+		if !ok {
+			user = unknownBeatmaker
+		}
+		res = append(res, toResponseBeat(beats[i], user))
+	}
+	return res
+}
+
 func toResponseBeat(b Beat, u *userv1.GetUserResponse) *audiov1.Beat {
 	var res audiov1.Beat
 	res.Note = &audiov1.GetBeatsNote{}
@@ -331,8 +441,8 @@ func ToDomainUpdateBeat(req *audiov1.UpdateBeatRequest) (*UpdateBeat, error) {
 	return &res, nil
 }
 
-func ToDomainSaveOwnerParams(req *audiov1.AcquireBeatRequest) (*generated.SaveOwnerParams, error) {
-	var res generated.SaveOwnerParams
+func ToDomainAcquireBeat(req *audiov1.AcquireBeatRequest) (*AcquireBeat, error) {
+	var res AcquireBeat
 	beatID, err := uuid.Parse(req.BeatId)
 	if err != nil {
 		return nil, NewErr(ErrInvalidID, "beat id must be uuid")
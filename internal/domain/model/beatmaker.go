@@ -0,0 +1,18 @@
+package model
+
+import (
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+)
+
+// Storefront is the public page of a beatmaker. Profile is nil if the user service could
+// not be reached, the rest comes from this service.
+type Storefront struct {
+	Profile *userv1.GetUserResponse
+	// Stats counts the published beats, the qualified plays and the sales of the beatmaker.
+	Stats generated.GetBeatmakerStatsRow
+	// Genres counts the published beats of the beatmaker per genre, most used first.
+	Genres []FacetCount
+	Latest []Beat
+	Pinned []Beat
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/google/uuid"
+)
+
+type (
+	// Collaborator is a co-producer of a beat with their role and their percent of the
+	// revenue of the beat. The shares of a beat add up to 100.
+	Collaborator struct {
+		UserID uuid.UUID
+		Role   generated.CollaboratorRole
+		Share  int32
+	}
+
+	// Earning is the part of the price of a license the collaborator earned by its sale,
+	// with the role and share they had at the time.
+	Earning struct {
+		BeatID uuid.UUID
+		Beat   string
+		Tier   generated.LicenseTier
+		Number string
+		Role   generated.CollaboratorRole
+		Share  int32
+		Price  int64
+		Amount int64
+		SoldAt time.Time
+	}
+
+	// Earnings holds the earnings of a collaborator, the latest first, and their total in
+	// minor units of Currency. Revoked licenses earn nothing.
+	Earnings struct {
+		Currency string
+		Entries  []Earning
+		Total    int64
+	}
+)
+
+// CollaboratorRoles are the roles a collaborator can have on a beat.
+var CollaboratorRoles = []generated.CollaboratorRole{
+	generated.CollaboratorRoleProducer,
+	generated.CollaboratorRoleCoProducer,
+	generated.CollaboratorRoleComposer,
+	generated.CollaboratorRoleSongwriter,
+	generated.CollaboratorRoleEngineer,
+	generated.CollaboratorRoleFeatured,
+}
+
+func ParseCollaboratorRole(v string) (generated.CollaboratorRole, error) {
+	for _, role := range CollaboratorRoles {
+		if string(role) == v {
+			return role, nil
+		}
+	}
+
+	roles := make([]string, 0, len(CollaboratorRoles))
+	for _, role := range CollaboratorRoles {
+		roles = append(roles, string(role))
+	}
+	return "", NewErr(ErrValidationFailed, fmt.Sprintf("collaborator role must be one of %s, got %q", strings.Join(roles, ", "), v))
+}
+
+// SplitEarnings splits price by shares that add up to 100. Every share gets its part
+// rounded down, the minor units left over go one by one to the first shares, so the
+// parts add up to price.
+func SplitEarnings(price int64, shares []int32) []int64 {
+	res := make([]int64, len(shares))
+	rest := price
+	for i, share := range shares {
+		res[i] = price * int64(share) / 100
+		rest -= res[i]
+	}
+	for i := 0; rest > 0 && len(res) > 0; i = (i + 1) % len(res) {
+		res[i]++
+		rest--
+	}
+	return res
+}
@@ -6,20 +6,47 @@ import (
 )
 
 var (
-	ErrBeatNotFound       = errors.New("beat not found")
-	ErrInvalidRangeHeader = errors.New("invalid range header")
-	ErrBeatAlreadyExists  = errors.New("beat already exists")
-	ErrValidationFailed   = errors.New("validation failed")
-	ErrInvalidMediaType   = errors.New("invalid media type: must be one of file, archive or image")
-	ErrSizeExceeded       = errors.New("size exceeded")
-	ErrInvalidHash        = errors.New("invalid hash")
-	ErrInvalidExpiry      = errors.New("invalid expiry")
-	ErrURLExpired         = errors.New("url expired")
-	ErrArchiveNotFound    = errors.New("archive not found")
-	ErrOwnerNotFound      = errors.New("owner not found")
-	ErrInvalidOwner       = errors.New("invalid owner")
-	ErrUnauthorized       = errors.New("unauthorized")
-	ErrInvalidID          = errors.New("invalid id")
+	ErrBeatNotFound        = errors.New("beat not found")
+	ErrInvalidRangeHeader  = errors.New("invalid range header")
+	ErrBeatAlreadyExists   = errors.New("beat already exists")
+	ErrValidationFailed    = errors.New("validation failed")
+	ErrInvalidMediaType    = errors.New("invalid media type: must be one of file, archive or image")
+	ErrSizeExceeded        = errors.New("size exceeded")
+	ErrInvalidHash         = errors.New("invalid hash")
+	ErrInvalidExpiry       = errors.New("invalid expiry")
+	ErrURLExpired          = errors.New("url expired")
+	ErrArchiveNotFound     = errors.New("archive not found")
+	ErrOwnerNotFound       = errors.New("owner not found")
+	ErrInvalidOwner        = errors.New("invalid owner")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrInvalidID           = errors.New("invalid id")
+	ErrInvalidFilter       = errors.New("invalid filter")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrPlaylistNotFound    = errors.New("playlist not found")
+	ErrNotPlaylistOwner    = errors.New("not playlist owner")
+	ErrPlaylistFull        = errors.New("playlist is full")
+	ErrBeatInPlaylist      = errors.New("beat already in playlist")
+	ErrBeatNotInPlaylist   = errors.New("beat not in playlist")
+	ErrTaxonomyNotFound    = errors.New("taxonomy entry not found")
+	ErrTaxonomyExists      = errors.New("taxonomy entry already exists")
+	ErrTaxonomyInUse       = errors.New("taxonomy entry is in use")
+	ErrTaxonomyArchived    = errors.New("taxonomy entry is archived")
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrNotBeatOwner        = errors.New("not beat owner")
+	ErrBeatmakerNotFound   = errors.New("beatmaker not found")
+	ErrLicenseTierNotFound = errors.New("license tier not found")
+	ErrLicenseSoldOut      = errors.New("license tier sold out")
+	ErrIdempotencyKeyUsed  = errors.New("idempotency key used for another purchase")
+	ErrAcquisitionNotFound = errors.New("acquisition not found")
+	ErrLicenseOwned        = errors.New("license already acquired")
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrNotOrderOwner       = errors.New("not order owner")
+	ErrProviderNotFound    = errors.New("payment provider not found")
+	ErrInvalidWebhook      = errors.New("invalid webhook")
+	ErrLicenseNotFound     = errors.New("license not found")
+	ErrDownloadLimit       = errors.New("download limit reached")
+	ErrLicenseRevoked      = errors.New("license revoked")
+	ErrOrderPending        = errors.New("order already pending")
 )
 
 type ModelError struct {
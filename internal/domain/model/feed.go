@@ -0,0 +1,25 @@
+package model
+
+import (
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/google/uuid"
+)
+
+type (
+	// GetFeedParams selects the beats of a feed: the beats of the beatmaker, the beats of
+	// the genre, which is its id, slug or name, or the whole catalog if both are nil.
+	GetFeedParams struct {
+		BeatmakerID *uuid.UUID
+		Genre       *string
+		Locales     []string
+	}
+
+	// Feed holds the newest published beats, newest first, with their beatmakers.
+	// Beatmaker is set for beatmaker feeds and Genre for genre feeds.
+	Feed struct {
+		Beatmaker *userv1.GetUserResponse
+		Genre     *TaxonomyEntry
+		Beats     []Beat
+		Users     map[uuid.UUID]*userv1.GetUserResponse
+	}
+)
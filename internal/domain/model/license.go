@@ -0,0 +1,149 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/google/uuid"
+)
+
+type (
+	// LicenseTier is one of the ways a beat is sold. Price is in minor units of Currency,
+	// the deliverables are the media of the beat the buyer can download and nil caps are
+	// unlimited. SalesCap limits the number of licenses sold under a lease tier.
+	LicenseTier struct {
+		Tier            generated.LicenseTier
+		Price           int64
+		Currency        string
+		Deliverables    []MediaType
+		StreamCap       *int32
+		DistributionCap *int32
+		SalesCap        *int32
+	}
+
+	// AcquireBeat is the purchase of a license of the tier of the beat by the user. Retries
+	// of a purchase give the same IdempotencyKey. The download link is issued to Client.
+	AcquireBeat struct {
+		BeatID         uuid.UUID
+		UserID         uuid.UUID
+		Tier           generated.LicenseTier
+		IdempotencyKey string
+		Client         DownloadClient
+	}
+
+	// DownloadClient is the client a download link is issued to, as recorded in the audit
+	// log of the license.
+	DownloadClient struct {
+		IP        string
+		UserAgent string
+	}
+
+	// IssueDownload records the download link of Audit against the download counter of its
+	// license. It fails if the license has Limit downloads, zero is unlimited. A link issued
+	// before that is valid past LiveUntil is given out instead, without counting.
+	IssueDownload struct {
+		Audit     generated.SaveLicenseAuditParams
+		Limit     int32
+		LiveUntil time.Time
+	}
+
+	// LicenseDownloads holds the download counter of the license, its limit and the audit
+	// log of the license, the latest entry first.
+	LicenseDownloads struct {
+		License generated.BeatLicense
+		Limit   int32
+		Audit   []generated.LicenseAudit
+	}
+
+	// RevokeLicense is the revocation of the license of Audit by an admin, recorded with
+	// its reason. Its paid orders are refunded. RestoreBeat returns the beat of an
+	// exclusive license to the catalog.
+	RevokeLicense struct {
+		Audit       generated.SaveLicenseAuditParams
+		RestoreBeat bool
+	}
+
+	// BeatArchive holds the download URLs of a license: of the deliverable of its tier and
+	// of its agreement, nil if the agreement could not be rendered.
+	BeatArchive struct {
+		ArchiveURL   string
+		AgreementURL *string
+	}
+
+	// LicenseVerification is the public record of a license, shown to anyone who has its
+	// number. It names the licensee by their public name only and holds no price. A revoked
+	// license is not valid since RevokedAt.
+	LicenseVerification struct {
+		Number          string
+		BeatID          uuid.UUID
+		Beat            string
+		Beatmaker       string
+		Tier            generated.LicenseTier
+		Licensee        string
+		IssuedAt        time.Time
+		Valid           bool
+		RevokedAt       *time.Time
+		StreamCap       *int32
+		DistributionCap *int32
+	}
+
+	// SaveLicense is the sale of License. It fails if the tier has sold SalesCap licenses.
+	SaveLicense struct {
+		License        generated.SaveLicenseParams
+		SalesCap       *int32
+		IdempotencyKey string
+	}
+)
+
+// LicenseTiers are the license tiers from the cheapest lease to the exclusive rights.
+var LicenseTiers = []generated.LicenseTier{
+	generated.LicenseTierMp3Lease,
+	generated.LicenseTierWavLease,
+	generated.LicenseTierTrackout,
+	generated.LicenseTierExclusive,
+}
+
+func ParseLicenseTier(v string) (generated.LicenseTier, error) {
+	for _, tier := range LicenseTiers {
+		if string(tier) == v {
+			return tier, nil
+		}
+	}
+	return "", NewErr(ErrValidationFailed, fmt.Sprintf("license tier must be one of mp3_lease, wav_lease, trackout or exclusive, got %q", v))
+}
+
+// LicenseVerificationPath is the path of the public license verification, its number
+// query parameter is the license number.
+const LicenseVerificationPath = "/v1/licenses/verify"
+
+var licenseNumberRe = regexp.MustCompile(`^BF-[0-9A-F]{12}$`)
+
+// LicenseVerificationURL is the URL of the verification of the license, printed on its
+// agreement and encoded in its QR code.
+func LicenseVerificationURL(publicURL, number string) string {
+	return strings.TrimRight(publicURL, "/") + LicenseVerificationPath + "?number=" + url.QueryEscape(number)
+}
+
+// ParseLicenseNumber returns the license number of payload: the number itself, in any
+// case, or the verification URL of the license as scanned from its QR code.
+func ParseLicenseNumber(payload string) (string, error) {
+	payload = strings.TrimSpace(payload)
+	if u, err := url.Parse(payload); err == nil && u.Path != "" && strings.HasSuffix(u.Path, LicenseVerificationPath) {
+		payload = u.Query().Get("number")
+	}
+
+	number := strings.ToUpper(strings.TrimSpace(payload))
+	if !licenseNumberRe.MatchString(number) {
+		return "", NewErr(ErrValidationFailed, "license number must look like BF-0123456789AB")
+	}
+	return number, nil
+}
+
+// IsLease reports whether the tier can be sold to many buyers.
+func IsLease(tier generated.LicenseTier) bool {
+	return tier != generated.LicenseTierExclusive
+}
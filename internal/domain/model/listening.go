@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+type (
+	ListeningSession struct {
+		ID     uuid.UUID
+		BeatID uuid.UUID
+		UserID *uuid.UUID
+		Source string
+		Client string
+	}
+
+	Heartbeat struct {
+		SessionID     uuid.UUID
+		BeatID        uuid.UUID
+		SecondsPlayed int32
+	}
+
+	ListeningProgress struct {
+		SecondsPlayed int32
+		IsQualified   bool
+	}
+
+	BeatPlays struct {
+		BeatID        uuid.UUID
+		Name          string
+		Plays         int64
+		Sessions      int64
+		SecondsPlayed int64
+	}
+)
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/google/uuid"
+)
+
+type (
+	// Order is the purchase of a license of the tier of the beat by the user at Price, in
+	// minor units of Currency. The buyer pays it at CheckoutURL, the license is sold once
+	// the order is paid.
+	Order struct {
+		ID          uuid.UUID
+		UserID      uuid.UUID
+		BeatID      uuid.UUID
+		Tier        generated.LicenseTier
+		Price       int64
+		Currency    string
+		Status      generated.OrderStatus
+		CheckoutURL string
+		CreatedAt   time.Time
+		PaidAt      *time.Time
+	}
+
+	// CreateOrder is the order of a license of the tier of the beat by the user.
+	CreateOrder struct {
+		UserID uuid.UUID
+		BeatID uuid.UUID
+		Tier   generated.LicenseTier
+	}
+
+	// ConfirmOrder is the outcome of the checkout of an order, reported by the payment
+	// provider.
+	ConfirmOrder struct {
+		Provider   string
+		CheckoutID string
+		Paid       bool
+	}
+)
+
+func ToDomainOrder(o generated.Order) Order {
+	res := Order{
+		ID:        o.ID,
+		UserID:    o.UserID,
+		BeatID:    o.BeatID,
+		Tier:      o.Tier,
+		Price:     o.Price,
+		Currency:  o.Currency,
+		Status:    o.Status,
+		CreatedAt: o.CreatedAt.Time,
+	}
+	if o.CheckoutUrl != nil {
+		res.CheckoutURL = *o.CheckoutUrl
+	}
+	if o.PaidAt.Valid {
+		res.PaidAt = &o.PaidAt.Time
+	}
+	return res
+}
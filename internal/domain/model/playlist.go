@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/google/uuid"
+)
+
+type PlaylistVisibility string
+
+const (
+	PlaylistVisibilityPrivate  PlaylistVisibility = "private"
+	PlaylistVisibilityUnlisted PlaylistVisibility = "unlisted"
+	PlaylistVisibilityPublic   PlaylistVisibility = "public"
+)
+
+// ParsePlaylistVisibility parses the visibility of a playlist.
+// Empty string means PlaylistVisibilityPrivate.
+func ParsePlaylistVisibility(s string) (PlaylistVisibility, error) {
+	switch v := PlaylistVisibility(s); v {
+	case "":
+		return PlaylistVisibilityPrivate, nil
+	case PlaylistVisibilityPrivate, PlaylistVisibilityUnlisted, PlaylistVisibilityPublic:
+		return v, nil
+	}
+	return "", NewErr(ErrValidationFailed, "visibility must be one of private, unlisted or public")
+}
+
+type (
+	Playlist struct {
+		ID         uuid.UUID
+		UserID     uuid.UUID
+		Name       string
+		Visibility PlaylistVisibility
+		ShareToken string
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+	}
+
+	UpdatePlaylist struct {
+		ID         uuid.UUID
+		Name       *string
+		Visibility *PlaylistVisibility
+	}
+
+	// AddPlaylistBeat adds the beat at Position, counting from 1, or to the end of
+	// the playlist if Position is nil. MaxBeats limits the length of the playlist.
+	AddPlaylistBeat struct {
+		PlaylistID uuid.UUID
+		BeatID     uuid.UUID
+		Position   *int32
+		MaxBeats   int64
+	}
+)
+
+func ToDomainPlaylist(p generated.Playlist) Playlist {
+	return Playlist{
+		ID:         p.ID,
+		UserID:     p.UserID,
+		Name:       p.Name,
+		Visibility: PlaylistVisibility(p.Visibility),
+		ShareToken: p.ShareToken,
+		CreatedAt:  p.CreatedAt.Time,
+		UpdatedAt:  p.UpdatedAt.Time,
+	}
+}
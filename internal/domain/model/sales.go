@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/google/uuid"
+)
+
+type (
+	// Purchase is a license bought by the user, with the URL of its agreement, nil if it
+	// is not rendered yet. Download links of the deliverable are issued one by one against
+	// the download limit, zero is unlimited. Available is false if the deliverable is not
+	// uploaded or the license is revoked.
+	Purchase struct {
+		BeatID        uuid.UUID
+		Beat          string
+		Tier          generated.LicenseTier
+		Number        string
+		Price         int64
+		Currency      string
+		PurchasedAt   time.Time
+		Available     bool
+		Revoked       bool
+		Downloads     int32
+		DownloadLimit int32
+		AgreementURL  *string
+	}
+
+	// SalesPeriod is the length of the periods a sales report is split into.
+	SalesPeriod string
+
+	// GetSalesReportParams selects the sales of the beats of the beatmaker, or of one of
+	// them, from From up to To. Nil bounds are open.
+	GetSalesReportParams struct {
+		BeatmakerID uuid.UUID
+		BeatID      *uuid.UUID
+		From        *time.Time
+		To          *time.Time
+		Period      SalesPeriod
+	}
+
+	// SalesReportRow holds the licenses of the beat sold in the period starting at Period.
+	// Gross is the sum of their prices in minor units of the report currency.
+	SalesReportRow struct {
+		Period time.Time
+		BeatID uuid.UUID
+		Beat   string
+		Units  int64
+		Gross  int64
+	}
+
+	// SalesReport holds the sales of a beatmaker by period and beat, and their totals.
+	SalesReport struct {
+		Currency string
+		Rows     []SalesReportRow
+		Units    int64
+		Gross    int64
+	}
+)
+
+const (
+	SalesPeriodDay   SalesPeriod = "day"
+	SalesPeriodWeek  SalesPeriod = "week"
+	SalesPeriodMonth SalesPeriod = "month"
+	SalesPeriodYear  SalesPeriod = "year"
+)
+
+func ParseSalesPeriod(v string) (SalesPeriod, error) {
+	switch p := SalesPeriod(v); p {
+	case SalesPeriodDay, SalesPeriodWeek, SalesPeriodMonth, SalesPeriodYear:
+		return p, nil
+	case "":
+		return SalesPeriodMonth, nil
+	}
+	return "", NewErr(ErrValidationFailed, fmt.Sprintf("period must be one of day, week, month or year, got %q", v))
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+type (
+	// GetSuggestionsParams holds like patterns of the typed prefix. NamePattern is matched
+	// against lowercased names and SlugPattern against slugs, so a prefix typed in cyrillic
+	// also finds the entries whose slug is its transliteration.
+	GetSuggestionsParams struct {
+		NamePattern string
+		SlugPattern string
+		Limit       uint64
+		Locales     []string
+	}
+
+	Suggestions struct {
+		Beats      []BeatSuggestion
+		Beatmakers []BeatmakerSuggestion
+		Genres     []TaxonomySuggestion
+		Tags       []TaxonomySuggestion
+	}
+
+	BeatSuggestion struct {
+		ID   uuid.UUID
+		Name string
+	}
+
+	BeatmakerSuggestion struct {
+		ID        uuid.UUID
+		Username  string
+		Pseudonym string
+	}
+
+	// TaxonomySuggestion is a genre or tag with its name in the first of the requested locales.
+	TaxonomySuggestion struct {
+		ID   uuid.UUID
+		Name string
+		Slug string
+	}
+)
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// TaxonomyKind is one of the beat attribute dictionaries. Its value is the name
+// of the dictionary table.
+type TaxonomyKind string
+
+const (
+	TaxonomyGenres TaxonomyKind = "genres"
+	TaxonomyTags   TaxonomyKind = "tags"
+	TaxonomyMoods  TaxonomyKind = "moods"
+	TaxonomyNotes  TaxonomyKind = "notes"
+)
+
+func ParseTaxonomyKind(s string) (TaxonomyKind, error) {
+	switch kind := TaxonomyKind(s); kind {
+	case TaxonomyGenres, TaxonomyTags, TaxonomyMoods, TaxonomyNotes:
+		return kind, nil
+	}
+	return "", NewErr(ErrValidationFailed, "taxonomy must be one of genres, tags, moods or notes")
+}
+
+type (
+	TaxonomyEntry struct {
+		ID         uuid.UUID
+		Name       string
+		Slug       string
+		Position   int32
+		IsArchived bool
+		Beats      int64
+		// Translations maps locales to the translated names of the entry.
+		Translations map[string]string
+	}
+
+	// SaveTaxonomyEntry adds an entry at Position, or after the last entry if Position is nil.
+	SaveTaxonomyEntry struct {
+		Name     string
+		Slug     string
+		Position *int32
+	}
+
+	UpdateTaxonomyEntry struct {
+		ID         uuid.UUID
+		Name       *string
+		Slug       *string
+		Position   *int32
+		IsArchived *bool
+	}
+)
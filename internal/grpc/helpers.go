@@ -3,13 +3,17 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
-	"github.com/golang-jwt/jwt"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/auth"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/locale"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -24,18 +28,12 @@ func AuthMiddleware(secret string, requireAdmin map[string]bool) grpc.UnaryServe
 			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), "token not provided")
 		}
 
-		data := strings.Fields(md.Get("authorization")[0])
-		if len(data) < 2 || strings.ToLower(data[0]) != "bearer" {
-			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), "invalid header format")
-		}
-
-		token := data[1]
-		admin, err := validateToken(token, secret)
+		claims, err := auth.FromHeader(md.Get("authorization")[0], secret)
 		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), err.Error())
+			return nil, status.Error(codes.Unauthenticated, err.Error())
 		}
 
-		if model.AdminScale(*admin) != model.AdminScaleMinor && model.AdminScale(*admin) != model.AdminScaleMajor {
+		if !claims.IsAdmin() {
 			return nil, status.Errorf(codes.PermissionDenied, "%s: %s", model.ErrUnauthorized, "must be admin")
 		}
 
@@ -43,22 +41,90 @@ func AuthMiddleware(secret string, requireAdmin map[string]bool) grpc.UnaryServe
 	}
 }
 
-func validateToken(token, secret string) (*string, error) {
-	data, err := jwt.Parse(token, func(t *jwt.Token) (any, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "unexpected signing method")
-		}
+// locales picks the locales of the request from the locale metadata, then from Accept-Language
+// sent directly or through the gateway, and falls back to def.
+func locales(ctx context.Context, def string) []string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var candidates []string
+	for _, key := range []string{"locale", "accept-language", "grpcgateway-accept-language"} {
+		candidates = append(candidates, md.Get(key)...)
+	}
+
+	return locale.Resolve(def, candidates...)
+}
+
+// licenseTier picks the license tier of an acquisition from the license-tier metadata, sent
+// through the gateway as the Grpc-Metadata-License-Tier header. It is exclusive if none is given.
+func licenseTier(ctx context.Context) (generated.LicenseTier, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	v := md.Get("license-tier")
+	if len(v) == 0 || v[0] == "" {
+		return generated.LicenseTierExclusive, nil
+	}
+
+	return model.ParseLicenseTier(v[0])
+}
+
+// maxIdempotencyKeyLen is the longest idempotency key an acquisition can be sent with.
+const maxIdempotencyKeyLen = 255
 
-		return []byte(secret), nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", model.ErrUnauthorized, err)
+// idempotencyKey returns the idempotency key of an acquisition from the idempotency-key
+// metadata, sent through the gateway as the Idempotency-Key header. Retries of a purchase
+// with the same key get the result of the first one. It is empty if none is given.
+func idempotencyKey(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	v := md.Get("idempotency-key")
+	if len(v) == 0 {
+		return "", nil
+	}
+
+	if len(v[0]) > maxIdempotencyKeyLen {
+		return "", model.NewErr(model.ErrValidationFailed, fmt.Sprintf("idempotency key must be at most %d characters", maxIdempotencyKeyLen))
 	}
 
-	if claims, ok := data.Claims.(jwt.MapClaims); ok && data.Valid {
-		admin, _ := claims["admin"].(string)
-		return &admin, nil
+	return v[0], nil
+}
+
+// downloadClient returns the client of the request for the audit log of downloads. The
+// gateway appends the address of the HTTP client to x-forwarded-for and sends its user
+// agent as grpcgateway-user-agent, direct gRPC clients are taken from the connection.
+// Only the last address of x-forwarded-for is taken, the ones before it come from the
+// client and can be forged.
+func downloadClient(ctx context.Context) model.DownloadClient {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var client model.DownloadClient
+	if v := md.Get("x-forwarded-for"); len(v) > 0 {
+		fwd := v[len(v)-1]
+		client.IP = strings.TrimSpace(fwd[strings.LastIndex(fwd, ",")+1:])
+	} else if p, ok := peer.FromContext(ctx); ok {
+		client.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(client.IP); err == nil {
+			client.IP = host
+		}
 	}
 
-	return nil, model.ErrUnauthorized
+	if v := md.Get("grpcgateway-user-agent"); len(v) > 0 {
+		client.UserAgent = v[0]
+	} else if v := md.Get("user-agent"); len(v) > 0 {
+		client.UserAgent = v[0]
+	}
+
+	return client
+}
+
+// collaboratorsMetadata returns the collaborators of beats as beat-collaborators metadata,
+// one "<beat id>;<user id>;<role>;<share>" value per collaborator. Beats without
+// collaborators have none.
+func collaboratorsMetadata(beats []model.Beat) metadata.MD {
+	md := metadata.MD{}
+	for i := range beats {
+		for _, c := range beats[i].Collaborators {
+			md.Append("beat-collaborators", fmt.Sprintf("%s;%s;%s;%d", beats[i].ID, c.UserID, c.Role, c.Share))
+		}
+	}
+	return md
 }
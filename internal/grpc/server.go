@@ -7,13 +7,13 @@ import (
 
 	audiov1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/audio"
 	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
-	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
 	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
 	"github.com/bufbuild/protovalidate-go"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -25,15 +25,15 @@ type BeatModifier interface {
 
 type BeatProvider interface {
 	GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
-	GetBeatParams(ctx context.Context) (params *model.BeatAttributes, err error)
+	GetBeatParams(ctx context.Context, locales []string) (params *model.BeatAttributes, err error)
 }
 
 type URLProvider interface {
-	GetBeatArchive(ctx context.Context, params generated.SaveOwnerParams) (*string, error)
+	GetBeatArchive(ctx context.Context, params model.AcquireBeat) (*model.BeatArchive, error)
 }
 
 type UserProvider interface {
-	GetUser(ctx context.Context, id uuid.UUID) (*userv1.GetUserResponse, error)
+	GetUsers(ctx context.Context, ids []uuid.UUID) map[uuid.UUID]*userv1.GetUserResponse
 }
 
 type server struct {
@@ -42,7 +42,10 @@ type server struct {
 	beatProvider BeatProvider
 	urlProvider  URLProvider
 	userProvider UserProvider
-	log          *slog.Logger
+	// defaultLocale is the locale of the names of the requests that ask for none
+	// or for a locale without translations.
+	defaultLocale string
+	log           *slog.Logger
 }
 
 func Register(
@@ -51,12 +54,20 @@ func Register(
 	beatProvider BeatProvider,
 	urlProvider URLProvider,
 	userProvider UserProvider,
+	defaultLocale string,
 	log *slog.Logger) {
-	audiov1.RegisterBeatServiceServer(gRPCServer, &server{beatModifier: beatSaver, beatProvider: beatProvider, urlProvider: urlProvider, userProvider: userProvider, log: log})
+	audiov1.RegisterBeatServiceServer(gRPCServer, &server{
+		beatModifier:  beatSaver,
+		beatProvider:  beatProvider,
+		urlProvider:   urlProvider,
+		userProvider:  userProvider,
+		defaultLocale: defaultLocale,
+		log:           log,
+	})
 }
 
 func (s *server) GetBeatParams(ctx context.Context, req *audiov1.GetBeatParamsRequest) (*audiov1.GetBeatParamsResponse, error) {
-	beat, err := s.beatProvider.GetBeatParams(ctx)
+	beat, err := s.beatProvider.GetBeatParams(ctx, locales(ctx, s.defaultLocale))
 	if err != nil {
 		s.log.Error("internal error", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
@@ -74,6 +85,7 @@ func (s *server) GetBeats(ctx context.Context, req *audiov1.GetBeatsRequest) (*a
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	params.Locales = locales(ctx, s.defaultLocale)
 
 	beats, total, err := s.beatProvider.GetBeats(ctx, *params)
 	if err != nil {
@@ -81,16 +93,16 @@ func (s *server) GetBeats(ctx context.Context, req *audiov1.GetBeatsRequest) (*a
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	var users []*userv1.GetUserResponse
-	for i := range beats {
-		user, err := s.userProvider.GetUser(ctx, beats[i].BeatmakerID)
-		if err != nil {
-			s.log.Error("internal error", sl.Err(err))
-			return nil, status.Error(codes.Internal, err.Error())
+	// The proto beat has no field for collaborators, they are sent as header metadata,
+	// which the gateway passes on as Grpc-Metadata-Beat-Collaborators headers.
+	if md := collaboratorsMetadata(beats); md.Len() > 0 {
+		if err := grpc.SetHeader(ctx, md); err != nil {
+			s.log.Error("failed to set header", sl.Err(err))
 		}
-		users = append(users, user)
 	}
 
+	users := s.userProvider.GetUsers(ctx, model.BeatmakerIDs(beats))
+
 	return model.ToGetBeatsResponse(beats, users, *total, *params), nil
 }
 
@@ -175,16 +187,32 @@ func (s *server) AcquireBeat(ctx context.Context, req *audiov1.AcquireBeatReques
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	params, err := model.ToDomainSaveOwnerParams(req)
+	params, err := model.ToDomainAcquireBeat(req)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	url, err := s.urlProvider.GetBeatArchive(ctx, *params)
+	if params.Tier, err = licenseTier(ctx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if params.IdempotencyKey, err = idempotencyKey(ctx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	params.Client = downloadClient(ctx)
+
+	archive, err := s.urlProvider.GetBeatArchive(ctx, *params)
 	if err != nil {
 		var modelErr *model.ModelError
-		if errors.Is(err, model.ErrArchiveNotFound) {
+		if errors.Is(err, model.ErrArchiveNotFound) || errors.Is(err, model.ErrLicenseTierNotFound) || errors.Is(err, model.ErrBeatNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
+		} else if errors.Is(err, model.ErrIdempotencyKeyUsed) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		} else if errors.Is(err, model.ErrLicenseSoldOut) || errors.Is(err, model.ErrInvalidOwner) || errors.Is(err, model.ErrLicenseRevoked) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		} else if errors.Is(err, model.ErrDownloadLimit) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
 		} else if errors.As(err, &modelErr) {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
@@ -192,8 +220,16 @@ func (s *server) AcquireBeat(ctx context.Context, req *audiov1.AcquireBeatReques
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	// The response has no field for the agreement, it is sent as header metadata, which
+	// the gateway passes on as the Grpc-Metadata-License-Agreement-Url header.
+	if archive.AgreementURL != nil {
+		if err := grpc.SetHeader(ctx, metadata.Pairs("license-agreement-url", *archive.AgreementURL)); err != nil {
+			s.log.Error("failed to set header", sl.Err(err))
+		}
+	}
+
 	return &audiov1.AcquireBeatResponse{
-		ArchiveDownloadUrl: *url,
+		ArchiveDownloadUrl: archive.ArchiveURL,
 	}, nil
 }
 
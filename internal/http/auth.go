@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/auth"
+	"github.com/google/uuid"
+)
+
+// authenticate returns the claims of the request token, or nil if the request has none.
+func (r *Router) authenticate(req *http.Request) (*auth.Claims, error) {
+	header := req.Header.Get("Authorization")
+	if header == "" {
+		return nil, nil
+	}
+
+	return auth.FromHeader(header, r.jwtSecret)
+}
+
+// requireClaims returns the claims of the request token or writes 401.
+func (r *Router) requireClaims(w http.ResponseWriter, req *http.Request) (*auth.Claims, bool) {
+	claims, err := r.authenticate(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	if claims == nil {
+		r.errorResponse(w, model.NewErr(model.ErrUnauthorized, "token not provided"), http.StatusUnauthorized)
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// requireUser returns the id of the authenticated user or writes 401.
+func (r *Router) requireUser(w http.ResponseWriter, req *http.Request) (uuid.UUID, bool) {
+	claims, err := r.authenticate(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	if claims == nil || claims.UserID == uuid.Nil {
+		r.errorResponse(w, model.NewErr(model.ErrUnauthorized, "token not provided"), http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	return claims.UserID, true
+}
+
+// requireAdmin checks that the request is made by an admin or writes 401 or 403.
+func (r *Router) requireAdmin(w http.ResponseWriter, req *http.Request) bool {
+	claims, err := r.authenticate(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusUnauthorized)
+		return false
+	}
+
+	if claims == nil {
+		r.errorResponse(w, model.NewErr(model.ErrUnauthorized, "token not provided"), http.StatusUnauthorized)
+		return false
+	}
+
+	if !claims.IsAdmin() {
+		r.errorResponse(w, model.NewErr(model.ErrUnauthorized, "must be admin"), http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+type beatmakerProfileResponse struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	Pseudonym string `json:"pseudonym"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+type beatmakerStatsResponse struct {
+	Beats int64 `json:"beats"`
+	Plays int64 `json:"plays"`
+	Sales int64 `json:"sales"`
+}
+
+type beatmakerResponse struct {
+	Profile *beatmakerProfileResponse `json:"profile"`
+	Stats   beatmakerStatsResponse    `json:"stats"`
+	Genres  []facetCount              `json:"genres"`
+	Latest  []json.RawMessage         `json:"latest"`
+	Pinned  []json.RawMessage         `json:"pinned"`
+}
+
+// beatmaker returns the storefront of a beatmaker. The profile is null if the user
+// service is unavailable, the beats have the shape of the beats of /v1/beats.
+func (r *Router) beatmaker(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	ctx := req.Context()
+
+	beatmakerID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beatmaker id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	storefront, err := r.beatmakerProvider.GetBeatmaker(ctx, beatmakerID, r.locales(req))
+	if err != nil {
+		if errors.Is(err, model.ErrBeatmakerNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := beatmakerResponse{
+		Stats: beatmakerStatsResponse{
+			Beats: storefront.Stats.Beats,
+			Plays: storefront.Stats.Plays,
+			Sales: storefront.Stats.Sales,
+		},
+		Genres: toFacetCounts(storefront.Genres),
+	}
+
+	users := r.userProvider.GetUsers(ctx, model.BeatmakerIDs(slices.Concat(storefront.Latest, storefront.Pinned)))
+	if p := storefront.Profile; p != nil {
+		users[beatmakerID] = p
+		res.Profile = &beatmakerProfileResponse{
+			ID:        beatmakerID.String(),
+			Username:  p.GetUsername(),
+			Pseudonym: p.GetPseudonym(),
+			FirstName: p.GetFirstName(),
+			LastName:  p.GetLastName(),
+		}
+	}
+
+	if res.Latest, err = r.rawBeats(req, storefront.Latest, users); err == nil {
+		res.Pinned, err = r.rawBeats(req, storefront.Pinned, users)
+	}
+	if err != nil {
+		r.log.Error("marshal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	r.jsonResponse(w, res)
+}
+
+// rawBeats marshals beats the way beatsResponse does, likes and collaborators included.
+func (r *Router) rawBeats(req *http.Request, beats []model.Beat, users map[uuid.UUID]*userv1.GetUserResponse) ([]json.RawMessage, error) {
+	_, outbound := runtime.MarshalerForRequest(r.app, req)
+
+	fields := beatFields(beats, users)
+	res := make([]json.RawMessage, 0, len(beats))
+	for i, b := range model.ToResponseBeats(beats, users) {
+		data, err := outbound.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = mergeJSON(data, fields[i], nil); err != nil {
+			return nil, err
+		}
+		res = append(res, data)
+	}
+
+	return res, nil
+}
+
+type setPinnedBeatsRequest struct {
+	BeatIDs []string `json:"beatIds"`
+}
+
+// setPinnedBeats replaces the beats featured on the storefront of the authenticated
+// beatmaker with the beats of the request in their order.
+func (r *Router) setPinnedBeats(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in setPinnedBeatsRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	beatIDs := make([]uuid.UUID, 0, len(in.BeatIDs))
+	for _, v := range in.BeatIDs {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+			return
+		}
+		beatIDs = append(beatIDs, id)
+	}
+
+	if err := r.beatmakerProvider.SetPinnedBeats(req.Context(), userID, beatIDs); err != nil {
+		var modelErr *model.ModelError
+		switch {
+		case errors.Is(err, model.ErrNotBeatOwner):
+			r.errorResponse(w, err, http.StatusForbidden)
+		case errors.As(err, &modelErr):
+			r.errorResponse(w, err, http.StatusBadRequest)
+		default:
+			r.log.Error("internal error", sl.Err(err))
+			r.errorResponse(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,339 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	audiov1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/audio"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/locale"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/proto"
+)
+
+// locales picks the locales of the request from the locale query parameter, then from
+// the Accept-Language header, and falls back to the default locale.
+func (r *Router) locales(req *http.Request) []string {
+	return locale.Resolve(r.defaultLocale, req.URL.Query().Get("locale"), req.Header.Get("Accept-Language"))
+}
+
+// parseGetBeatsParams reads the query parameters of GetBeats the same way the gateway
+// does for /v1/beats, plus the parameters that only the search endpoint understands.
+func (r *Router) parseGetBeatsParams(req *http.Request) (*model.GetBeatsParams, error) {
+	query := req.URL.Query()
+
+	orderBy, err := extractOrderBy(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var in audiov1.GetBeatsRequest
+	if err := runtime.PopulateQueryParameters(&in, query, utilities.NewDoubleArray(nil)); err != nil {
+		return nil, model.NewErr(model.ErrValidationFailed, err.Error())
+	}
+
+	if err := protovalidate.Validate(&in); err != nil {
+		return nil, model.NewErr(model.ErrValidationFailed, err.Error())
+	}
+
+	params, err := model.ToDomainGetBeatsParams(&in)
+	if err != nil {
+		return nil, err
+	}
+
+	if orderBy != nil {
+		params.OrderBy = orderBy
+	}
+
+	for key, mode := range map[string]*model.MatchMode{
+		"genre_match": &params.GenreMatch,
+		"mood_match":  &params.MoodMatch,
+		"tag_match":   &params.TagMatch,
+	} {
+		if *mode, err = model.ParseMatchMode(query.Get(key)); err != nil {
+			return nil, err
+		}
+	}
+
+	if query.Has("filter") {
+		filter := query.Get("filter")
+		params.Filter = &filter
+	}
+
+	params.Locales = r.locales(req)
+
+	return params, nil
+}
+
+// setDefaultLimit sets the limit query parameter of listings that do not require one.
+func setDefaultLimit(req *http.Request, limit int) {
+	query := req.URL.Query()
+	if !query.Has("limit") {
+		query.Set("limit", strconv.Itoa(limit))
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// extractOrderBy removes the sort keys that are not part of the proto from query, so that
+// the proto validation does not reject them, and returns them as model.OrderBy.
+func extractOrderBy(query url.Values) (*model.OrderBy, error) {
+	for _, prefix := range []string{"order_by", "orderBy"} {
+		field := query.Get(prefix + ".field")
+		if field != model.OrderByTrending {
+			continue
+		}
+
+		order := query.Get(prefix + ".order")
+		if order == "" {
+			order = "desc"
+		} else if order != "asc" && order != "desc" {
+			return nil, model.NewErr(model.ErrValidationFailed, "order must be one of asc or desc")
+		}
+
+		query.Del(prefix + ".field")
+		query.Del(prefix + ".order")
+		return &model.OrderBy{Field: field, Order: order}, nil
+	}
+	return nil, nil
+}
+
+type facetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count uint64 `json:"count"`
+}
+
+type noteFacetCount struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Scale string `json:"scale"`
+	Count uint64 `json:"count"`
+}
+
+type bpmFacetCount struct {
+	From  int64  `json:"from"`
+	To    int64  `json:"to"`
+	Count uint64 `json:"count"`
+}
+
+type facetsResponse struct {
+	Genres []facetCount     `json:"genres"`
+	Moods  []facetCount     `json:"moods"`
+	Tags   []facetCount     `json:"tags"`
+	Notes  []noteFacetCount `json:"notes"`
+	Bpm    []bpmFacetCount  `json:"bpm"`
+}
+
+func toFacetCounts(counts []model.FacetCount) []facetCount {
+	res := make([]facetCount, 0, len(counts))
+	for _, v := range counts {
+		res = append(res, facetCount(v))
+	}
+	return res
+}
+
+func toFacetsResponse(f model.Facets) facetsResponse {
+	res := facetsResponse{
+		Genres: toFacetCounts(f.Genres),
+		Moods:  toFacetCounts(f.Moods),
+		Tags:   toFacetCounts(f.Tags),
+		Notes:  make([]noteFacetCount, 0, len(f.Notes)),
+		Bpm:    make([]bpmFacetCount, 0, len(f.Bpm)),
+	}
+	for _, v := range f.Notes {
+		res.Notes = append(res.Notes, noteFacetCount(v))
+	}
+	for _, v := range f.Bpm {
+		res.Bpm = append(res.Bpm, bpmFacetCount(v))
+	}
+	return res
+}
+
+func (r *Router) searchBeats(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	ctx := req.Context()
+
+	p, err := r.parseGetBeatsParams(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	withFacets := false
+	if v := req.URL.Query().Get("facets"); v != "" {
+		if withFacets, err = strconv.ParseBool(v); err != nil {
+			r.errorResponse(w, model.NewErr(model.ErrValidationFailed, "facets must be boolean"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	beats, total, err := r.beatProvider.GetBeats(ctx, *p)
+	if err != nil {
+		var modelErr *model.ModelError
+		if errors.As(err, &modelErr) {
+			r.errorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	extra := map[string]any{}
+	if withFacets {
+		facets, err := r.beatProvider.GetBeatFacets(ctx, *p)
+		if err != nil {
+			r.log.Error("internal error", sl.Err(err))
+			r.errorResponse(w, err, http.StatusInternalServerError)
+			return
+		}
+		extra["facets"] = toFacetsResponse(*facets)
+	}
+
+	r.beatsResponse(w, req, beats, *total, *p, extra)
+}
+
+const defaultTrendingBeatsLimit = 20
+
+// trendingBeats lists the beats with a trending score, hottest first. It takes the same
+// filters as searchBeats, e.g. genre.
+func (r *Router) trendingBeats(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	ctx := req.Context()
+
+	setDefaultLimit(req, defaultTrendingBeatsLimit)
+
+	p, err := r.parseGetBeatsParams(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+	p.Trending = true
+	p.OrderBy = &model.OrderBy{Field: model.OrderByTrending, Order: "desc"}
+
+	beats, total, err := r.beatProvider.GetBeats(ctx, *p)
+	if err != nil {
+		var modelErr *model.ModelError
+		if errors.As(err, &modelErr) {
+			r.errorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	r.beatsResponse(w, req, beats, *total, *p, nil)
+}
+
+const (
+	defaultSimilarBeatsLimit = 10
+	maxSimilarBeatsLimit     = 50
+)
+
+func (r *Router) similarBeats(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	ctx := req.Context()
+
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	limit := uint64(defaultSimilarBeatsLimit)
+	if v := req.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.ParseUint(v, 10, 64); err != nil || limit == 0 || limit > maxSimilarBeatsLimit {
+			r.errorResponse(w, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("limit must be in [1, %d]", maxSimilarBeatsLimit)), http.StatusBadRequest)
+			return
+		}
+	}
+
+	beats, err := r.similarBeatsProvider.GetSimilarBeats(ctx, beatID, limit, r.locales(req))
+	if err != nil {
+		if errors.Is(err, model.ErrBeatNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	r.beatsResponse(w, req, beats, uint64(len(beats)), model.GetBeatsParams{Limit: limit}, nil)
+}
+
+// beatsResponse writes beats in the shape of GetBeatsResponse with their beatmakers resolved.
+// The fields that the proto beat lacks, like the like count and the collaborators, are
+// added to every beat.
+func (r *Router) beatsResponse(w http.ResponseWriter, req *http.Request, beats []model.Beat, total uint64, params model.GetBeatsParams, extra map[string]any) {
+	users := r.userProvider.GetUsers(req.Context(), model.BeatmakerIDs(beats))
+
+	r.protoResponse(w, req, model.ToGetBeatsResponse(beats, users, total, params), extra, beatFields(beats, users))
+}
+
+// protoResponse writes m with the marshaler the gateway uses for the generated endpoints,
+// so that the custom handlers return the same JSON as /v1/beats. Fields from extra are
+// added to the top level object and fields from beatFields to the elements of its beats.
+func (r *Router) protoResponse(w http.ResponseWriter, req *http.Request, m proto.Message, extra map[string]any, beatFields []map[string]any) {
+	_, outbound := runtime.MarshalerForRequest(r.app, req)
+
+	data, err := outbound.Marshal(m)
+	if err == nil && (len(extra) > 0 || len(beatFields) > 0) {
+		data, err = mergeJSON(data, extra, beatFields)
+	}
+	if err != nil {
+		r.log.Error("marshal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", outbound.ContentType(m))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		r.log.Error("write error", sl.Err(err))
+	}
+}
+
+func mergeJSON(data []byte, extra map[string]any, beatFields []map[string]any) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	if raw, ok := obj["beats"]; ok && len(beatFields) > 0 {
+		var beats []json.RawMessage
+		if err := json.Unmarshal(raw, &beats); err != nil {
+			return nil, err
+		}
+		for i := range beats {
+			if i >= len(beatFields) {
+				break
+			}
+			merged, err := mergeJSON(beats[i], beatFields[i], nil)
+			if err != nil {
+				return nil, err
+			}
+			beats[i] = merged
+		}
+		var err error
+		if obj["beats"], err = json.Marshal(beats); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range extra {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		obj[k] = raw
+	}
+
+	return json.Marshal(obj)
+}
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type collaboratorRequest struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role"`
+	Share  int32  `json:"share"`
+}
+
+type setCollaboratorsRequest struct {
+	Collaborators []collaboratorRequest `json:"collaborators"`
+}
+
+type collaboratorResponse struct {
+	UserID    string `json:"userId"`
+	Username  string `json:"username"`
+	Pseudonym string `json:"pseudonym"`
+	Role      string `json:"role"`
+	Share     int32  `json:"share"`
+}
+
+type collaboratorsResponse struct {
+	Collaborators []collaboratorResponse `json:"collaborators"`
+}
+
+type earningResponse struct {
+	BeatID string    `json:"beatId"`
+	Beat   string    `json:"beat"`
+	Tier   string    `json:"tier"`
+	Number string    `json:"number"`
+	Role   string    `json:"role"`
+	Share  int32     `json:"share"`
+	Price  int64     `json:"price"`
+	Amount int64     `json:"amount"`
+	SoldAt time.Time `json:"soldAt"`
+}
+
+type earningsResponse struct {
+	Currency string            `json:"currency"`
+	Total    int64             `json:"total"`
+	Earnings []earningResponse `json:"earnings"`
+}
+
+// toCollaboratorsResponse converts collaborators with their names from users, empty if
+// the user service could not resolve them.
+func toCollaboratorsResponse(collaborators []model.Collaborator, users map[uuid.UUID]*userv1.GetUserResponse) []collaboratorResponse {
+	res := make([]collaboratorResponse, 0, len(collaborators))
+	for _, c := range collaborators {
+		user := users[c.UserID]
+		res = append(res, collaboratorResponse{
+			UserID:    c.UserID.String(),
+			Username:  user.GetUsername(),
+			Pseudonym: user.GetPseudonym(),
+			Role:      string(c.Role),
+			Share:     c.Share,
+		})
+	}
+	return res
+}
+
+// beatFields returns the fields of beats that the proto beat lacks, see protoResponse.
+func beatFields(beats []model.Beat, users map[uuid.UUID]*userv1.GetUserResponse) []map[string]any {
+	res := make([]map[string]any, len(beats))
+	for i := range beats {
+		res[i] = map[string]any{
+			"likes":         beats[i].Likes,
+			"collaborators": toCollaboratorsResponse(beats[i].Collaborators, users),
+		}
+	}
+	return res
+}
+
+// beatCollaborators returns the collaborators of a beat with their roles and shares of
+// its revenue. A beat without collaborators earns its beatmaker everything.
+func (r *Router) beatCollaborators(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	ctx := req.Context()
+
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	collaborators, err := r.collaboratorProvider.GetBeatCollaborators(ctx, beatID)
+	if err != nil {
+		if errors.Is(err, model.ErrBeatNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	r.collaboratorsResponse(w, req, collaborators)
+}
+
+// setBeatCollaborators replaces the collaborators of a beat. Their shares must add up to
+// 100. Only its beatmaker or an admin can set them.
+func (r *Router) setBeatCollaborators(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	claims, ok := r.requireClaims(w, req)
+	if !ok {
+		return
+	}
+
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in setCollaboratorsRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	collaborators := make([]model.Collaborator, 0, len(in.Collaborators))
+	for _, c := range in.Collaborators {
+		userID, err := uuid.Parse(c.UserID)
+		if err != nil {
+			r.errorResponse(w, model.NewErr(model.ErrInvalidID, "collaborator user id must be uuid"), http.StatusBadRequest)
+			return
+		}
+
+		role, err := model.ParseCollaboratorRole(c.Role)
+		if err != nil {
+			r.errorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		collaborators = append(collaborators, model.Collaborator{UserID: userID, Role: role, Share: c.Share})
+	}
+
+	res, err := r.collaboratorProvider.SetBeatCollaborators(req.Context(), claims.UserID, claims.IsAdmin(), beatID, collaborators)
+	if err != nil {
+		var modelErr *model.ModelError
+		switch {
+		case errors.Is(err, model.ErrBeatNotFound):
+			r.errorResponse(w, err, http.StatusNotFound)
+		case errors.Is(err, model.ErrNotBeatOwner):
+			r.errorResponse(w, err, http.StatusForbidden)
+		case errors.As(err, &modelErr):
+			r.errorResponse(w, err, http.StatusBadRequest)
+		default:
+			r.log.Error("internal error", sl.Err(err))
+			r.errorResponse(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	r.collaboratorsResponse(w, req, res)
+}
+
+func (r *Router) collaboratorsResponse(w http.ResponseWriter, req *http.Request, collaborators []model.Collaborator) {
+	ids := make([]uuid.UUID, 0, len(collaborators))
+	for _, c := range collaborators {
+		ids = append(ids, c.UserID)
+	}
+	users := r.userProvider.GetUsers(req.Context(), ids)
+
+	r.jsonResponse(w, collaboratorsResponse{Collaborators: toCollaboratorsResponse(collaborators, users)})
+}
+
+// earnings returns the earnings of the authenticated user from the licenses of the beats
+// they collaborated on, their own beats included. Amounts are in minor units of the
+// currency.
+func (r *Router) earnings(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	earnings, err := r.collaboratorProvider.GetEarnings(req.Context(), userID)
+	if err != nil {
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := earningsResponse{
+		Currency: earnings.Currency,
+		Total:    earnings.Total,
+		Earnings: make([]earningResponse, 0, len(earnings.Entries)),
+	}
+	for _, e := range earnings.Entries {
+		res.Earnings = append(res.Earnings, earningResponse{
+			BeatID: e.BeatID.String(),
+			Beat:   e.Beat,
+			Tier:   string(e.Tier),
+			Number: e.Number,
+			Role:   string(e.Role),
+			Share:  e.Share,
+			Price:  e.Price,
+			Amount: e.Amount,
+			SoldAt: e.SoldAt,
+		})
+	}
+
+	r.jsonResponse(w, res)
+}
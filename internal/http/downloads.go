@@ -0,0 +1,203 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type downloadResponse struct {
+	DownloadURL string `json:"downloadUrl"`
+}
+
+type licenseAuditResponse struct {
+	Action    string     `json:"action"`
+	ActorID   *string    `json:"actorId"`
+	IP        *string    `json:"ip"`
+	UserAgent *string    `json:"userAgent"`
+	URL       *string    `json:"url"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	Reason    *string    `json:"reason"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type licenseDownloadsResponse struct {
+	Number    string                 `json:"number"`
+	BeatID    string                 `json:"beatId"`
+	UserID    string                 `json:"userId"`
+	Tier      string                 `json:"tier"`
+	Downloads int32                  `json:"downloads"`
+	Limit     int32                  `json:"limit"`
+	RevokedAt *time.Time             `json:"revokedAt"`
+	Audit     []licenseAuditResponse `json:"audit"`
+}
+
+// ParseTrustedProxies parses the addresses and CIDRs of trusted proxies.
+func ParseTrustedProxies(proxies []string) ([]netip.Prefix, error) {
+	res := make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if !strings.Contains(p, "/") {
+			addr, err := netip.ParseAddr(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+			}
+			res = append(res, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+		}
+		res = append(res, prefix.Masked())
+	}
+	return res, nil
+}
+
+// downloadClient returns the client of the request for the audit log of downloads. The
+// last address of X-Forwarded-For is taken only if the request comes from a trusted
+// proxy, which appends it: any other client could write what it likes there.
+func (r *Router) downloadClient(req *http.Request) model.DownloadClient {
+	client := model.DownloadClient{UserAgent: req.UserAgent(), IP: req.RemoteAddr}
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		client.IP = host
+	}
+
+	if v := req.Header.Values("X-Forwarded-For"); len(v) > 0 && r.isTrustedProxy(client.IP) {
+		fwd := v[len(v)-1]
+		client.IP = strings.TrimSpace(fwd[strings.LastIndex(fwd, ",")+1:])
+	}
+	return client
+}
+
+func (r *Router) isTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return slices.ContainsFunc(r.trustedProxies, func(p netip.Prefix) bool { return p.Contains(addr) })
+}
+
+// downloadErrorResponse writes err of a download operation with its status code.
+func (r *Router) downloadErrorResponse(w http.ResponseWriter, err error) {
+	var modelErr *model.ModelError
+	switch {
+	case errors.Is(err, model.ErrLicenseNotFound), errors.Is(err, model.ErrBeatNotFound), errors.Is(err, model.ErrArchiveNotFound):
+		r.errorResponse(w, err, http.StatusNotFound)
+	case errors.Is(err, model.ErrDownloadLimit), errors.Is(err, model.ErrLicenseRevoked):
+		r.errorResponse(w, err, http.StatusForbidden)
+	case errors.As(err, &modelErr):
+		r.errorResponse(w, err, http.StatusBadRequest)
+	default:
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+	}
+}
+
+// downloadPurchase issues a download link of a license bought by the authenticated user,
+// or gives out the last one again while it is valid. New links count against the
+// download limit of the license.
+func (r *Router) downloadPurchase(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	beatID, err := uuid.Parse(params["beat_id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	tier, err := model.ParseLicenseTier(params["tier"])
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	url, err := r.downloadProvider.ReissueDownload(req.Context(), userID, beatID, tier, r.downloadClient(req))
+	if err != nil {
+		r.downloadErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, downloadResponse{DownloadURL: url})
+}
+
+// licenseDownloads returns the download counter of a license and its audit log, with
+// resets and revocations, to an admin.
+func (r *Router) licenseDownloads(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	if !r.requireAdmin(w, req) {
+		return
+	}
+
+	d, err := r.downloadProvider.GetDownloads(req.Context(), params["number"])
+	if err != nil {
+		r.downloadErrorResponse(w, err)
+		return
+	}
+
+	res := licenseDownloadsResponse{
+		Number:    d.License.Number,
+		BeatID:    d.License.BeatID.String(),
+		UserID:    d.License.UserID.String(),
+		Tier:      string(d.License.Tier),
+		Downloads: d.License.Downloads,
+		Limit:     d.Limit,
+		Audit:     make([]licenseAuditResponse, 0, len(d.Audit)),
+	}
+	if d.License.RevokedAt.Valid {
+		res.RevokedAt = &d.License.RevokedAt.Time
+	}
+	for _, a := range d.Audit {
+		entry := licenseAuditResponse{
+			Action:    string(a.Action),
+			IP:        a.Ip,
+			UserAgent: a.UserAgent,
+			URL:       a.Url,
+			Reason:    a.Reason,
+			CreatedAt: a.CreatedAt.Time,
+		}
+		if a.ActorID.Valid {
+			actorID := uuid.UUID(a.ActorID.Bytes).String()
+			entry.ActorID = &actorID
+		}
+		if a.ExpiresAt.Valid {
+			entry.ExpiresAt = &a.ExpiresAt.Time
+		}
+		res.Audit = append(res.Audit, entry)
+	}
+
+	r.jsonResponse(w, res)
+}
+
+// resetLicenseDownloads sets the download counter of a license to zero on behalf of the
+// admin.
+func (r *Router) resetLicenseDownloads(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	claims, ok := r.requireClaims(w, req)
+	if !ok {
+		return
+	}
+
+	if !claims.IsAdmin() {
+		r.errorResponse(w, model.NewErr(model.ErrUnauthorized, "must be admin"), http.StatusForbidden)
+		return
+	}
+
+	if err := r.downloadProvider.ResetDownloads(req.Context(), claims.UserID, params["number"]); err != nil {
+		r.downloadErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
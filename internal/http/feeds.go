@@ -0,0 +1,184 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/feed"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// feedMaxAge is how long clients and proxies may cache a feed.
+	feedMaxAge = 5 * time.Minute
+	// imageMaxAge is how long clients and proxies may cache a cover image.
+	imageMaxAge = 24 * time.Hour
+	// feedEnclosureType is the type of the preview stream. The real type is only known
+	// per file, the uploads are mp3 previews.
+	feedEnclosureType = "audio/mpeg"
+)
+
+// baseURL is the public URL of the service, the public_url from the config or, if it
+// is not set, the URL the request came to.
+func (r *Router) baseURL(req *http.Request) string {
+	if r.publicURL != "" {
+		return strings.TrimSuffix(r.publicURL, "/")
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + req.Host
+}
+
+// feed writes the newest published beats of the whole catalog, of the beatmaker with
+// the id parameter or of the genre parameter as an RSS 2.0 or Atom feed. Every item
+// has the preview stream as an enclosure and the cover image as a thumbnail.
+func (r *Router) feed(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	format := params["format"]
+	if format != "rss" && format != "atom" {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, "feed format must be one of rss or atom"), http.StatusBadRequest)
+		return
+	}
+
+	locales := r.locales(req)
+	feedParams := model.GetFeedParams{Locales: locales}
+	if v, ok := params["id"]; ok {
+		beatmakerID, err := uuid.Parse(v)
+		if err != nil {
+			r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beatmaker id must be uuid"), http.StatusBadRequest)
+			return
+		}
+		feedParams.BeatmakerID = &beatmakerID
+	}
+	if v, ok := params["genre"]; ok {
+		feedParams.Genre = &v
+	}
+
+	res, err := r.feedProvider.GetFeed(req.Context(), feedParams)
+	if err != nil {
+		if errors.Is(err, model.ErrTaxonomyNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	base := r.baseURL(req)
+	f := toFeed(res, base)
+	f.Self = base + req.URL.RequestURI()
+	f.Language = locales[0]
+
+	var (
+		buf         bytes.Buffer
+		contentType string
+	)
+	if format == "rss" {
+		err = f.WriteRSS(&buf)
+		contentType = "application/rss+xml; charset=utf-8"
+	} else {
+		err = f.WriteAtom(&buf)
+		contentType = "application/atom+xml; charset=utf-8"
+	}
+	if err != nil {
+		r.log.Error("marshal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha1.Sum(buf.Bytes())
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(feedMaxAge.Seconds())))
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:8])+`"`)
+	w.Header().Add("Vary", "Accept-Language")
+
+	http.ServeContent(w, req, "", f.Updated, bytes.NewReader(buf.Bytes()))
+}
+
+func toFeed(res *model.Feed, base string) *feed.Feed {
+	f := &feed.Feed{
+		Title:       "New beats",
+		Description: "The newest beats of the catalog",
+		Link:        base + "/",
+		Items:       make([]feed.Item, 0, len(res.Beats)),
+	}
+
+	switch {
+	case res.Beatmaker != nil:
+		f.Title = "New beats by " + res.Beatmaker.GetPseudonym()
+		f.Description = "The newest beats of " + res.Beatmaker.GetPseudonym()
+	case res.Genre != nil:
+		f.Title = "New " + res.Genre.Name + " beats"
+		f.Description = "The newest beats in " + res.Genre.Name
+	}
+
+	for _, b := range res.Beats {
+		if b.CreatedAt.After(f.Updated) {
+			f.Updated = b.CreatedAt
+		}
+
+		beatURL := fmt.Sprintf("%s/v1/beat/%s", base, b.ID)
+		item := feed.Item{
+			ID:          "urn:uuid:" + b.ID.String(),
+			Title:       b.Name,
+			Description: b.Description,
+			Categories:  b.Genres,
+			Published:   b.CreatedAt,
+			Enclosure:   &feed.Enclosure{URL: beatURL + "/stream?source=feed", Type: feedEnclosureType},
+			Image:       beatURL + "/image",
+		}
+		if user, ok := res.Users[b.BeatmakerID]; ok {
+			item.Author = user.GetPseudonym()
+		}
+		f.Items = append(f.Items, item)
+	}
+
+	return f
+}
+
+// beatImage writes the cover image of a beat. Its URL does not expire, unlike the
+// download URLs of the beat lists.
+func (r *Router) beatImage(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	image, size, contentType, err := r.beatProvider.GetBeatImage(req.Context(), beatID)
+	if err != nil {
+		if errors.Is(err, model.ErrBeatNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	defer image.Close()
+
+	w.Header().Set("Content-Type", *contentType)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", *size))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(imageMaxAge.Seconds())))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err = io.Copy(w, image); err != nil {
+		r.log.Error("write error", sl.Err(err))
+	}
+}
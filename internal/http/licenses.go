@@ -0,0 +1,258 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type licenseTierRequest struct {
+	Tier            string   `json:"tier"`
+	Price           int64    `json:"price"`
+	Deliverables    []string `json:"deliverables"`
+	StreamCap       *int32   `json:"streamCap"`
+	DistributionCap *int32   `json:"distributionCap"`
+	SalesCap        *int32   `json:"salesCap"`
+}
+
+type setLicenseTiersRequest struct {
+	Licenses []licenseTierRequest `json:"licenses"`
+}
+
+type licenseTierResponse struct {
+	Tier            string   `json:"tier"`
+	Price           int64    `json:"price"`
+	Currency        string   `json:"currency"`
+	Deliverables    []string `json:"deliverables"`
+	StreamCap       *int32   `json:"streamCap"`
+	DistributionCap *int32   `json:"distributionCap"`
+	SalesCap        *int32   `json:"salesCap"`
+}
+
+type licenseTiersResponse struct {
+	Licenses []licenseTierResponse `json:"licenses"`
+}
+
+type licenseVerificationResponse struct {
+	Number          string     `json:"number"`
+	BeatID          string     `json:"beatId"`
+	Beat            string     `json:"beat"`
+	Beatmaker       string     `json:"beatmaker"`
+	Tier            string     `json:"tier"`
+	Licensee        string     `json:"licensee"`
+	IssuedAt        time.Time  `json:"issuedAt"`
+	Valid           bool       `json:"valid"`
+	RevokedAt       *time.Time `json:"revokedAt"`
+	StreamCap       *int32     `json:"streamCap"`
+	DistributionCap *int32     `json:"distributionCap"`
+}
+
+type revokeLicenseRequest struct {
+	Reason      string `json:"reason"`
+	RestoreBeat bool   `json:"restoreBeat"`
+	Refund      bool   `json:"refund"`
+}
+
+type revokedLicenseResponse struct {
+	Number    string    `json:"number"`
+	BeatID    string    `json:"beatId"`
+	UserID    string    `json:"userId"`
+	Tier      string    `json:"tier"`
+	RevokedAt time.Time `json:"revokedAt"`
+}
+
+func toLicenseTiersResponse(tiers []model.LicenseTier) licenseTiersResponse {
+	res := licenseTiersResponse{Licenses: make([]licenseTierResponse, 0, len(tiers))}
+	for _, t := range tiers {
+		deliverables := make([]string, 0, len(t.Deliverables))
+		for _, d := range t.Deliverables {
+			deliverables = append(deliverables, string(d))
+		}
+
+		res.Licenses = append(res.Licenses, licenseTierResponse{
+			Tier:            string(t.Tier),
+			Price:           t.Price,
+			Currency:        t.Currency,
+			Deliverables:    deliverables,
+			StreamCap:       t.StreamCap,
+			DistributionCap: t.DistributionCap,
+			SalesCap:        t.SalesCap,
+		})
+	}
+	return res
+}
+
+// licenseTiers returns the license tiers a beat is sold under. Prices are in minor units
+// of the currency.
+func (r *Router) licenseTiers(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	tiers, err := r.licenseProvider.GetLicenseTiers(req.Context(), beatID)
+	if err != nil {
+		if errors.Is(err, model.ErrBeatNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	r.jsonResponse(w, toLicenseTiersResponse(tiers))
+}
+
+// setLicenseTiers replaces the license tiers of a beat. Only its beatmaker or an admin
+// can set them.
+func (r *Router) setLicenseTiers(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	claims, ok := r.requireClaims(w, req)
+	if !ok {
+		return
+	}
+
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in setLicenseTiersRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	tiers := make([]model.LicenseTier, 0, len(in.Licenses))
+	for _, l := range in.Licenses {
+		deliverables := make([]model.MediaType, 0, len(l.Deliverables))
+		for _, d := range l.Deliverables {
+			deliverables = append(deliverables, model.MediaType(d))
+		}
+
+		tiers = append(tiers, model.LicenseTier{
+			Tier:            generated.LicenseTier(l.Tier),
+			Price:           l.Price,
+			Deliverables:    deliverables,
+			StreamCap:       l.StreamCap,
+			DistributionCap: l.DistributionCap,
+			SalesCap:        l.SalesCap,
+		})
+	}
+
+	res, err := r.licenseProvider.SetLicenseTiers(req.Context(), claims.UserID, claims.IsAdmin(), beatID, tiers)
+	if err != nil {
+		var modelErr *model.ModelError
+		switch {
+		case errors.Is(err, model.ErrBeatNotFound):
+			r.errorResponse(w, err, http.StatusNotFound)
+		case errors.Is(err, model.ErrNotBeatOwner):
+			r.errorResponse(w, err, http.StatusForbidden)
+		case errors.As(err, &modelErr):
+			r.errorResponse(w, err, http.StatusBadRequest)
+		default:
+			r.log.Error("internal error", sl.Err(err))
+			r.errorResponse(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	r.jsonResponse(w, toLicenseTiersResponse(res))
+}
+
+// verifyLicense returns the public record of a license to anyone who has its number,
+// given as ?number= or as the ?payload= of the QR code on its agreement.
+func (r *Router) verifyLicense(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	payload := req.URL.Query().Get("number")
+	if payload == "" {
+		payload = req.URL.Query().Get("payload")
+	}
+
+	v, err := r.licenseProvider.VerifyLicense(req.Context(), payload)
+	if err != nil {
+		var modelErr *model.ModelError
+		switch {
+		case errors.Is(err, model.ErrLicenseNotFound):
+			r.errorResponse(w, err, http.StatusNotFound)
+		case errors.As(err, &modelErr):
+			r.errorResponse(w, err, http.StatusBadRequest)
+		default:
+			r.log.Error("internal error", sl.Err(err))
+			r.errorResponse(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	r.jsonResponse(w, licenseVerificationResponse{
+		Number:          v.Number,
+		BeatID:          v.BeatID.String(),
+		Beat:            v.Beat,
+		Beatmaker:       v.Beatmaker,
+		Tier:            string(v.Tier),
+		Licensee:        v.Licensee,
+		IssuedAt:        v.IssuedAt,
+		Valid:           v.Valid,
+		RevokedAt:       v.RevokedAt,
+		StreamCap:       v.StreamCap,
+		DistributionCap: v.DistributionCap,
+	})
+}
+
+// revokeLicense revokes a license on behalf of the admin after a refund or chargeback.
+// The reason is recorded in the audit log of the license, restoreBeat returns the beat of
+// an exclusive license to the catalog. refund returns the payment through the payment
+// provider, without it the money is taken to be back already, e.g. by a chargeback.
+func (r *Router) revokeLicense(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	claims, ok := r.requireClaims(w, req)
+	if !ok {
+		return
+	}
+
+	if !claims.IsAdmin() {
+		r.errorResponse(w, model.NewErr(model.ErrUnauthorized, "must be admin"), http.StatusForbidden)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in revokeLicenseRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	license, err := r.licenseProvider.RevokeLicense(req.Context(), claims.UserID, params["number"], in.Reason, in.RestoreBeat, in.Refund)
+	if err != nil {
+		var modelErr *model.ModelError
+		switch {
+		case errors.Is(err, model.ErrLicenseNotFound):
+			r.errorResponse(w, err, http.StatusNotFound)
+		case errors.Is(err, model.ErrLicenseRevoked):
+			r.errorResponse(w, err, http.StatusConflict)
+		case errors.As(err, &modelErr):
+			r.errorResponse(w, err, http.StatusBadRequest)
+		default:
+			r.log.Error("internal error", sl.Err(err))
+			r.errorResponse(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	r.jsonResponse(w, revokedLicenseResponse{
+		Number:    license.Number,
+		BeatID:    license.BeatID.String(),
+		UserID:    license.UserID.String(),
+		Tier:      string(license.Tier),
+		RevokedAt: license.RevokedAt.Time,
+	})
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const defaultLikedBeatsLimit = 20
+
+type likeResponse struct {
+	BeatID string `json:"beatId"`
+	Liked  bool   `json:"liked"`
+	Likes  int64  `json:"likes"`
+}
+
+func (r *Router) likeBeat(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	r.setLike(w, req, params, true, r.likeProvider.LikeBeat)
+}
+
+func (r *Router) unlikeBeat(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	r.setLike(w, req, params, false, r.likeProvider.UnlikeBeat)
+}
+
+func (r *Router) setLike(
+	w http.ResponseWriter,
+	req *http.Request,
+	params map[string]string,
+	liked bool,
+	set func(ctx context.Context, userID, beatID uuid.UUID) (int64, error),
+) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	likes, err := set(req.Context(), userID, beatID)
+	if err != nil {
+		if errors.Is(err, model.ErrBeatNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	r.jsonResponse(w, likeResponse{BeatID: beatID.String(), Liked: liked, Likes: likes})
+}
+
+// likedBeats lists the beats liked by the authenticated user. It takes the same filters as searchBeats.
+func (r *Router) likedBeats(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	setDefaultLimit(req, defaultLikedBeatsLimit)
+
+	p, err := r.parseGetBeatsParams(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	beats, total, err := r.likeProvider.GetLikedBeats(req.Context(), userID, *p)
+	if err != nil {
+		var modelErr *model.ModelError
+		if errors.As(err, &modelErr) {
+			r.errorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	r.beatsResponse(w, req, beats, *total, *p, nil)
+}
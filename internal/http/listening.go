@@ -0,0 +1,168 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	sessionHeader   = "X-Session-Id"
+	maxSourceLength = 64
+	maxClientLength = 256
+)
+
+// parseListeningSession reads the listening session of a stream request. Clients should
+// generate the session id once per playback and send it with every range request, either
+// as session_id or in the X-Session-Id header. Streams are public, so a request with an
+// invalid or expired token is listened to anonymously.
+func (r *Router) parseListeningSession(req *http.Request, beatID uuid.UUID) (*model.ListeningSession, error) {
+	query := req.URL.Query()
+
+	session := model.ListeningSession{
+		BeatID: beatID,
+		Source: query.Get("source"),
+		Client: query.Get("client"),
+	}
+
+	id := query.Get("session_id")
+	if id == "" {
+		id = req.Header.Get(sessionHeader)
+	}
+	if id != "" {
+		var err error
+		if session.ID, err = uuid.Parse(id); err != nil {
+			return nil, model.NewErr(model.ErrInvalidID, "session id must be uuid")
+		}
+	}
+
+	if session.Client == "" {
+		session.Client = req.UserAgent()
+	}
+	session.Source = truncate(session.Source, maxSourceLength)
+	session.Client = truncate(session.Client, maxClientLength)
+
+	claims, err := r.authenticate(req)
+	if err != nil {
+		r.log.Debug("invalid token, listening anonymously", sl.Err(err))
+	} else if claims != nil && claims.UserID != uuid.Nil {
+		session.UserID = &claims.UserID
+	}
+
+	return &session, nil
+}
+
+func truncate(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+type heartbeatRequest struct {
+	SecondsPlayed int32 `json:"secondsPlayed"`
+}
+
+type heartbeatResponse struct {
+	SessionID     string `json:"sessionId"`
+	SecondsPlayed int32  `json:"secondsPlayed"`
+	IsQualified   bool   `json:"isQualified"`
+}
+
+func (r *Router) heartbeat(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	ctx := req.Context()
+
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	sessionID, err := uuid.Parse(params["session_id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "session id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in heartbeatRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+	if in.SecondsPlayed < 0 {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, "seconds played must be non negative"), http.StatusBadRequest)
+		return
+	}
+
+	progress, err := r.listeningProvider.Heartbeat(ctx, model.Heartbeat{
+		SessionID:     sessionID,
+		BeatID:        beatID,
+		SecondsPlayed: in.SecondsPlayed,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrSessionNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	r.jsonResponse(w, heartbeatResponse{
+		SessionID:     sessionID.String(),
+		SecondsPlayed: progress.SecondsPlayed,
+		IsQualified:   progress.IsQualified,
+	})
+}
+
+type beatPlays struct {
+	BeatID        string `json:"beatId"`
+	Name          string `json:"name"`
+	Plays         int64  `json:"plays"`
+	Sessions      int64  `json:"sessions"`
+	SecondsPlayed int64  `json:"secondsPlayed"`
+}
+
+type beatPlaysResponse struct {
+	Beats []beatPlays `json:"beats"`
+}
+
+// beatPlays lists the play counts of the beats of the authenticated beatmaker.
+func (r *Router) beatPlays(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	ctx := req.Context()
+
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	plays, err := r.listeningProvider.GetBeatPlays(ctx, userID)
+	if err != nil {
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := beatPlaysResponse{Beats: make([]beatPlays, 0, len(plays))}
+	for _, p := range plays {
+		res.Beats = append(res.Beats, beatPlays{
+			BeatID:        p.BeatID.String(),
+			Name:          p.Name,
+			Plays:         p.Plays,
+			Sessions:      p.Sessions,
+			SecondsPlayed: p.SecondsPlayed,
+		})
+	}
+
+	r.jsonResponse(w, res)
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/payment"
+	"github.com/google/uuid"
+)
+
+// maxWebhookSize is the largest webhook payload read from a payment provider.
+const maxWebhookSize = 1 << 20
+
+type createOrderRequest struct {
+	BeatID string `json:"beatId"`
+	Tier   string `json:"tier"`
+}
+
+type orderResponse struct {
+	ID           string     `json:"id"`
+	BeatID       string     `json:"beatId"`
+	Tier         string     `json:"tier"`
+	Price        int64      `json:"price"`
+	Currency     string     `json:"currency"`
+	Status       string     `json:"status"`
+	CheckoutURL  string     `json:"checkoutUrl,omitempty"`
+	DownloadURL  string     `json:"downloadUrl,omitempty"`
+	AgreementURL string     `json:"agreementUrl,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	PaidAt       *time.Time `json:"paidAt"`
+}
+
+func toOrderResponse(o model.Order, archive *model.BeatArchive) orderResponse {
+	res := orderResponse{
+		ID:          o.ID.String(),
+		BeatID:      o.BeatID.String(),
+		Tier:        string(o.Tier),
+		Price:       o.Price,
+		Currency:    o.Currency,
+		Status:      string(o.Status),
+		CheckoutURL: o.CheckoutURL,
+		CreatedAt:   o.CreatedAt,
+		PaidAt:      o.PaidAt,
+	}
+	if archive != nil {
+		res.DownloadURL = archive.ArchiveURL
+		if archive.AgreementURL != nil {
+			res.AgreementURL = *archive.AgreementURL
+		}
+	}
+	return res
+}
+
+// orderErrorResponse writes err of an order operation with its status code.
+func (r *Router) orderErrorResponse(w http.ResponseWriter, err error) {
+	var modelErr *model.ModelError
+	switch {
+	case errors.Is(err, model.ErrOrderNotFound), errors.Is(err, model.ErrBeatNotFound), errors.Is(err, model.ErrLicenseTierNotFound),
+		errors.Is(err, model.ErrArchiveNotFound), errors.Is(err, model.ErrProviderNotFound):
+		r.errorResponse(w, err, http.StatusNotFound)
+	case errors.Is(err, model.ErrNotOrderOwner):
+		r.errorResponse(w, err, http.StatusForbidden)
+	case errors.Is(err, model.ErrLicenseOwned), errors.Is(err, model.ErrInvalidOwner), errors.Is(err, model.ErrLicenseSoldOut),
+		errors.Is(err, model.ErrLicenseRevoked), errors.Is(err, model.ErrOrderPending):
+		r.errorResponse(w, err, http.StatusConflict)
+	case errors.As(err, &modelErr):
+		r.errorResponse(w, err, http.StatusBadRequest)
+	default:
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+	}
+}
+
+// createOrder orders a license of a beat for the authenticated user and returns the
+// URL of its checkout. The license is sold once the payment provider confirms the payment.
+func (r *Router) createOrder(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in createOrderRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	beatID, err := uuid.Parse(in.BeatID)
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	tier, err := model.ParseLicenseTier(in.Tier)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	order, err := r.orderProvider.CreateOrder(req.Context(), model.CreateOrder{UserID: userID, BeatID: beatID, Tier: tier})
+	if err != nil {
+		r.orderErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, toOrderResponse(*order, nil))
+}
+
+// order returns an order to its buyer or an admin, with the download URLs of the license
+// and its agreement once it is paid.
+func (r *Router) order(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	claims, ok := r.requireClaims(w, req)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "order id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	order, archive, err := r.orderProvider.GetOrder(req.Context(), claims.UserID, claims.IsAdmin(), id, r.downloadClient(req))
+	if err != nil {
+		r.orderErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, toOrderResponse(*order, archive))
+}
+
+// paymentWebhook receives the signed outcome of a checkout from the payment provider.
+func (r *Router) paymentWebhook(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	defer req.Body.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(req.Body, maxWebhookSize))
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidWebhook, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	order, err := r.orderProvider.HandleWebhook(req.Context(), params["provider"], payload, req.Header)
+	if err != nil {
+		r.orderErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, toOrderResponse(*order, nil))
+}
+
+// fakeCheckout pays the checkout of the fake payment provider, or fails it with
+// ?status=failed, and delivers its signed webhook to the order service as the provider
+// would. It is only served when the fake provider is used.
+func (r *Router) fakeCheckout(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	status := req.URL.Query().Get("status")
+	if status != "" && status != "paid" && status != "failed" {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, "status must be one of paid or failed"), http.StatusBadRequest)
+		return
+	}
+
+	payload, header, err := r.fakePayments.Complete(params["id"], status != "failed")
+	if err != nil {
+		if errors.Is(err, payment.ErrCheckoutNotFound) {
+			r.errorResponse(w, err, http.StatusNotFound)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	order, err := r.orderProvider.HandleWebhook(req.Context(), payment.FakeName, payload, header)
+	if err != nil {
+		r.orderErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, toOrderResponse(*order, nil))
+}
@@ -0,0 +1,374 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPlaylistBeatsLimit = 100
+	maxPlaylistNameLength     = 128
+	sharedPlaylistPath        = "/v1/shared/playlists/"
+)
+
+type playlistResponse struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"userId"`
+	Name       string    `json:"name"`
+	Visibility string    `json:"visibility"`
+	ShareURL   string    `json:"shareUrl"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type playlistsResponse struct {
+	Playlists []playlistResponse `json:"playlists"`
+}
+
+func toPlaylistResponse(p model.Playlist) playlistResponse {
+	return playlistResponse{
+		ID:         p.ID.String(),
+		UserID:     p.UserID.String(),
+		Name:       p.Name,
+		Visibility: string(p.Visibility),
+		ShareURL:   sharedPlaylistPath + p.ShareToken,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+	}
+}
+
+// playlistErrorResponse writes err of a playlist operation with its status code.
+func (r *Router) playlistErrorResponse(w http.ResponseWriter, err error) {
+	var modelErr *model.ModelError
+	switch {
+	case errors.Is(err, model.ErrPlaylistNotFound), errors.Is(err, model.ErrBeatNotFound), errors.Is(err, model.ErrBeatNotInPlaylist):
+		r.errorResponse(w, err, http.StatusNotFound)
+	case errors.Is(err, model.ErrNotPlaylistOwner):
+		r.errorResponse(w, err, http.StatusForbidden)
+	case errors.Is(err, model.ErrBeatInPlaylist), errors.Is(err, model.ErrPlaylistFull):
+		r.errorResponse(w, err, http.StatusConflict)
+	case errors.As(err, &modelErr):
+		r.errorResponse(w, err, http.StatusBadRequest)
+	default:
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+	}
+}
+
+func validatePlaylistName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > maxPlaylistNameLength {
+		return model.NewErr(model.ErrValidationFailed, "name must be 1 to 128 characters")
+	}
+	return nil
+}
+
+func parsePlaylistID(params map[string]string) (uuid.UUID, error) {
+	id, err := uuid.Parse(params["id"])
+	if err != nil {
+		return uuid.Nil, model.NewErr(model.ErrInvalidID, "playlist id must be uuid")
+	}
+	return id, nil
+}
+
+type createPlaylistRequest struct {
+	Name       string `json:"name"`
+	Visibility string `json:"visibility"`
+}
+
+func (r *Router) createPlaylist(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in createPlaylistRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if err := validatePlaylistName(in.Name); err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	visibility, err := model.ParsePlaylistVisibility(in.Visibility)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	playlist, err := r.playlistProvider.CreatePlaylist(req.Context(), userID, in.Name, visibility)
+	if err != nil {
+		r.playlistErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, toPlaylistResponse(*playlist))
+}
+
+// userPlaylists lists the playlists of the authenticated user.
+func (r *Router) userPlaylists(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	playlists, err := r.playlistProvider.GetUserPlaylists(req.Context(), userID)
+	if err != nil {
+		r.playlistErrorResponse(w, err)
+		return
+	}
+
+	res := playlistsResponse{Playlists: make([]playlistResponse, 0, len(playlists))}
+	for _, p := range playlists {
+		res.Playlists = append(res.Playlists, toPlaylistResponse(p))
+	}
+
+	r.jsonResponse(w, res)
+}
+
+// playlist returns the playlist with its beats in the GetBeats shape. It takes the same
+// filters as searchBeats and keeps the playlist order unless order_by is set.
+func (r *Router) playlist(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	id, err := parsePlaylistID(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	r.playlistBeats(w, req, func(viewer *uuid.UUID, p model.GetBeatsParams) (*model.Playlist, []model.Beat, *uint64, error) {
+		return r.playlistProvider.GetPlaylistBeats(req.Context(), id, viewer, p)
+	})
+}
+
+// sharedPlaylist is playlist for share links, which also open unlisted playlists.
+func (r *Router) sharedPlaylist(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	token := params["token"]
+
+	r.playlistBeats(w, req, func(viewer *uuid.UUID, p model.GetBeatsParams) (*model.Playlist, []model.Beat, *uint64, error) {
+		return r.playlistProvider.GetSharedPlaylistBeats(req.Context(), token, viewer, p)
+	})
+}
+
+func (r *Router) playlistBeats(
+	w http.ResponseWriter,
+	req *http.Request,
+	get func(viewer *uuid.UUID, p model.GetBeatsParams) (*model.Playlist, []model.Beat, *uint64, error),
+) {
+	claims, err := r.authenticate(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusUnauthorized)
+		return
+	}
+
+	var viewer *uuid.UUID
+	if claims != nil && claims.UserID != uuid.Nil {
+		viewer = &claims.UserID
+	}
+
+	setDefaultLimit(req, defaultPlaylistBeatsLimit)
+
+	p, err := r.parseGetBeatsParams(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	playlist, beats, total, err := get(viewer, *p)
+	if err != nil {
+		r.playlistErrorResponse(w, err)
+		return
+	}
+
+	r.beatsResponse(w, req, beats, *total, *p, map[string]any{"playlist": toPlaylistResponse(*playlist)})
+}
+
+type updatePlaylistRequest struct {
+	Name       *string `json:"name"`
+	Visibility *string `json:"visibility"`
+}
+
+func (r *Router) updatePlaylist(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	id, err := parsePlaylistID(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in updatePlaylistRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	update := model.UpdatePlaylist{ID: id, Name: in.Name}
+	if in.Name != nil {
+		if err := validatePlaylistName(*in.Name); err != nil {
+			r.errorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+	}
+	if in.Visibility != nil {
+		visibility, err := model.ParsePlaylistVisibility(*in.Visibility)
+		if err != nil {
+			r.errorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+		update.Visibility = &visibility
+	}
+
+	playlist, err := r.playlistProvider.UpdatePlaylist(req.Context(), userID, update)
+	if err != nil {
+		r.playlistErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, toPlaylistResponse(*playlist))
+}
+
+func (r *Router) deletePlaylist(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	id, err := parsePlaylistID(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := r.playlistProvider.DeletePlaylist(req.Context(), userID, id); err != nil {
+		r.playlistErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type addPlaylistBeatRequest struct {
+	BeatID   string `json:"beatId"`
+	Position *int32 `json:"position"`
+}
+
+// addPlaylistBeat adds a beat at position, counting from 1, or to the end of the playlist.
+func (r *Router) addPlaylistBeat(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	id, err := parsePlaylistID(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in addPlaylistBeatRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	beatID, err := uuid.Parse(in.BeatID)
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.playlistProvider.AddPlaylistBeat(req.Context(), userID, id, beatID, in.Position); err != nil {
+		r.playlistErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) removePlaylistBeat(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	id, err := parsePlaylistID(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	beatID, err := uuid.Parse(params["beat_id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.playlistProvider.RemovePlaylistBeat(req.Context(), userID, id, beatID); err != nil {
+		r.playlistErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type reorderPlaylistBeatsRequest struct {
+	BeatIDs []string `json:"beatIds"`
+}
+
+// reorderPlaylistBeats puts the beats in the given order, which must list every beat of the
+// playlist exactly once.
+func (r *Router) reorderPlaylistBeats(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	id, err := parsePlaylistID(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in reorderPlaylistBeatsRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	beatIDs := make([]uuid.UUID, 0, len(in.BeatIDs))
+	for _, v := range in.BeatIDs {
+		beatID, err := uuid.Parse(v)
+		if err != nil {
+			r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+			return
+		}
+		beatIDs = append(beatIDs, beatID)
+	}
+
+	if err := r.playlistProvider.ReorderPlaylistBeats(req.Context(), userID, id, beatIDs); err != nil {
+		r.playlistErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,217 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+// salesDateLayout is the layout of the from and to dates of a sales report.
+const salesDateLayout = time.DateOnly
+
+type purchaseResponse struct {
+	BeatID        string    `json:"beatId"`
+	Beat          string    `json:"beat"`
+	Tier          string    `json:"tier"`
+	Number        string    `json:"number"`
+	Price         int64     `json:"price"`
+	Currency      string    `json:"currency"`
+	PurchasedAt   time.Time `json:"purchasedAt"`
+	Available     bool      `json:"available"`
+	Revoked       bool      `json:"revoked"`
+	Downloads     int32     `json:"downloads"`
+	DownloadLimit int32     `json:"downloadLimit"`
+	AgreementURL  *string   `json:"agreementUrl"`
+}
+
+type purchasesResponse struct {
+	Purchases []purchaseResponse `json:"purchases"`
+}
+
+type salesReportRowResponse struct {
+	Period time.Time `json:"period"`
+	BeatID string    `json:"beatId"`
+	Beat   string    `json:"beat"`
+	Units  int64     `json:"units"`
+	Gross  int64     `json:"gross"`
+}
+
+type salesReportResponse struct {
+	Currency string                   `json:"currency"`
+	Period   string                   `json:"period"`
+	Units    int64                    `json:"units"`
+	Gross    int64                    `json:"gross"`
+	Sales    []salesReportRowResponse `json:"sales"`
+}
+
+// purchases returns the licenses bought by the authenticated user with their downloads.
+// Download links are issued by downloadPurchase.
+func (r *Router) purchases(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	purchases, err := r.salesProvider.GetPurchases(req.Context(), userID)
+	if err != nil {
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := purchasesResponse{Purchases: make([]purchaseResponse, 0, len(purchases))}
+	for _, p := range purchases {
+		res.Purchases = append(res.Purchases, purchaseResponse{
+			BeatID:        p.BeatID.String(),
+			Beat:          p.Beat,
+			Tier:          string(p.Tier),
+			Number:        p.Number,
+			Price:         p.Price,
+			Currency:      p.Currency,
+			PurchasedAt:   p.PurchasedAt,
+			Available:     p.Available,
+			Revoked:       p.Revoked,
+			Downloads:     p.Downloads,
+			DownloadLimit: p.DownloadLimit,
+			AgreementURL:  p.AgreementURL,
+		})
+	}
+
+	r.jsonResponse(w, res)
+}
+
+// salesReport returns the sales of the beats of the authenticated beatmaker by period and
+// beat, the beats they collaborated on included, as JSON or as CSV with ?format=csv. The
+// dates from and to are inclusive.
+func (r *Router) salesReport(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	userID, ok := r.requireUser(w, req)
+	if !ok {
+		return
+	}
+
+	p, err := parseSalesReportParams(req)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+	p.BeatmakerID = userID
+
+	format := req.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, "format must be one of json or csv"), http.StatusBadRequest)
+		return
+	}
+
+	report, err := r.salesProvider.GetSalesReport(req.Context(), *p)
+	if err != nil {
+		var modelErr *model.ModelError
+		if errors.As(err, &modelErr) {
+			r.errorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if format == "csv" {
+		r.salesReportCSV(w, report)
+		return
+	}
+
+	res := salesReportResponse{
+		Currency: report.Currency,
+		Period:   string(p.Period),
+		Units:    report.Units,
+		Gross:    report.Gross,
+		Sales:    make([]salesReportRowResponse, 0, len(report.Rows)),
+	}
+	for _, row := range report.Rows {
+		res.Sales = append(res.Sales, salesReportRowResponse{
+			Period: row.Period,
+			BeatID: row.BeatID.String(),
+			Beat:   row.Beat,
+			Units:  row.Units,
+			Gross:  row.Gross,
+		})
+	}
+
+	r.jsonResponse(w, res)
+}
+
+// salesReportCSV writes the rows of the report as CSV, with the gross revenue in major
+// units of its currency.
+func (r *Router) salesReportCSV(w http.ResponseWriter, report *model.SalesReport) {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	_ = cw.Write([]string{"period", "beat_id", "beat", "units", "gross", "currency"})
+	for _, row := range report.Rows {
+		_ = cw.Write([]string{
+			row.Period.Format(salesDateLayout),
+			row.BeatID.String(),
+			row.Beat,
+			strconv.FormatInt(row.Units, 10),
+			fmt.Sprintf("%d.%02d", row.Gross/100, row.Gross%100),
+			report.Currency,
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		r.log.Error("marshal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="sales.csv"`)
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		r.log.Error("write error", sl.Err(err))
+	}
+}
+
+func parseSalesReportParams(req *http.Request) (*model.GetSalesReportParams, error) {
+	query := req.URL.Query()
+
+	period, err := model.ParseSalesPeriod(query.Get("period"))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &model.GetSalesReportParams{Period: period}
+
+	if v := query.Get("beatId"); v != "" {
+		beatID, err := uuid.Parse(v)
+		if err != nil {
+			return nil, model.NewErr(model.ErrInvalidID, "beat id must be uuid")
+		}
+		p.BeatID = &beatID
+	}
+
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(salesDateLayout, v)
+		if err != nil {
+			return nil, model.NewErr(model.ErrValidationFailed, "from must be a date like 2006-01-02")
+		}
+		p.From = &from
+	}
+
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(salesDateLayout, v)
+		if err != nil {
+			return nil, model.NewErr(model.ErrValidationFailed, "to must be a date like 2006-01-02")
+		}
+		to = to.AddDate(0, 0, 1)
+		p.To = &to
+	}
+
+	return p, nil
+}
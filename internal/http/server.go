@@ -8,9 +8,12 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"strings"
 
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
 	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
 	"github.com/google/uuid"
@@ -19,17 +22,135 @@ import (
 
 type BeatProvider interface {
 	GetBeatStream(ctx context.Context, beatID uuid.UUID, start, end *int) (file io.ReadCloser, size *int, contentType *string, err error)
+	GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
+	GetBeatFacets(ctx context.Context, params model.GetBeatsParams) (facets *model.Facets, err error)
+	GetBeatImage(ctx context.Context, beatID uuid.UUID) (file io.ReadCloser, size *int, contentType *string, err error)
+}
+
+type SimilarBeatsProvider interface {
+	GetSimilarBeats(ctx context.Context, beatID uuid.UUID, limit uint64, locales []string) ([]model.Beat, error)
+}
+
+type ListeningProvider interface {
+	StartSession(ctx context.Context, session model.ListeningSession) (uuid.UUID, error)
+	Heartbeat(ctx context.Context, hb model.Heartbeat) (*model.ListeningProgress, error)
+	GetBeatPlays(ctx context.Context, beatmakerID uuid.UUID) ([]model.BeatPlays, error)
+}
+
+type LikeProvider interface {
+	LikeBeat(ctx context.Context, userID, beatID uuid.UUID) (int64, error)
+	UnlikeBeat(ctx context.Context, userID, beatID uuid.UUID) (int64, error)
+	GetLikedBeats(ctx context.Context, userID uuid.UUID, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
+}
+
+type PlaylistProvider interface {
+	CreatePlaylist(ctx context.Context, userID uuid.UUID, name string, visibility model.PlaylistVisibility) (*model.Playlist, error)
+	GetUserPlaylists(ctx context.Context, userID uuid.UUID) ([]model.Playlist, error)
+	GetPlaylistBeats(ctx context.Context, id uuid.UUID, viewer *uuid.UUID, params model.GetBeatsParams) (*model.Playlist, []model.Beat, *uint64, error)
+	GetSharedPlaylistBeats(ctx context.Context, shareToken string, viewer *uuid.UUID, params model.GetBeatsParams) (*model.Playlist, []model.Beat, *uint64, error)
+	UpdatePlaylist(ctx context.Context, userID uuid.UUID, update model.UpdatePlaylist) (*model.Playlist, error)
+	DeletePlaylist(ctx context.Context, userID, id uuid.UUID) error
+	AddPlaylistBeat(ctx context.Context, userID, id, beatID uuid.UUID, position *int32) error
+	RemovePlaylistBeat(ctx context.Context, userID, id, beatID uuid.UUID) error
+	ReorderPlaylistBeats(ctx context.Context, userID, id uuid.UUID, beatIDs []uuid.UUID) error
+}
+
+type TaxonomyProvider interface {
+	GetTaxonomy(ctx context.Context, kind model.TaxonomyKind) ([]model.TaxonomyEntry, error)
+	CreateTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, entry model.SaveTaxonomyEntry) (*model.TaxonomyEntry, error)
+	UpdateTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, update model.UpdateTaxonomyEntry) (*model.TaxonomyEntry, error)
+	DeleteTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID) error
+	MergeTaxonomyEntries(ctx context.Context, kind model.TaxonomyKind, sourceID, targetID uuid.UUID) (int64, error)
+	SetTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, locale, name string) error
+	DeleteTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, locale string) error
+}
+
+type TagProvider interface {
+	SetBeatTags(ctx context.Context, userID uuid.UUID, isAdmin bool, beatID uuid.UUID, tags []string) ([]generated.Tag, error)
+	AutocompleteTags(ctx context.Context, prefix string, limit int32) ([]generated.GetTagsByPrefixRow, error)
+}
+
+type SuggestProvider interface {
+	Suggest(ctx context.Context, prefix string, limit uint64, locales []string) (*model.Suggestions, error)
+}
+
+type BeatmakerProvider interface {
+	GetBeatmaker(ctx context.Context, beatmakerID uuid.UUID, locales []string) (*model.Storefront, error)
+	SetPinnedBeats(ctx context.Context, beatmakerID uuid.UUID, beatIDs []uuid.UUID) error
+}
+
+type FeedProvider interface {
+	GetFeed(ctx context.Context, params model.GetFeedParams) (*model.Feed, error)
+}
+
+type LicenseProvider interface {
+	GetLicenseTiers(ctx context.Context, beatID uuid.UUID) ([]model.LicenseTier, error)
+	SetLicenseTiers(ctx context.Context, userID uuid.UUID, isAdmin bool, beatID uuid.UUID, tiers []model.LicenseTier) ([]model.LicenseTier, error)
+	VerifyLicense(ctx context.Context, payload string) (*model.LicenseVerification, error)
+	RevokeLicense(ctx context.Context, adminID uuid.UUID, number, reason string, restoreBeat, refund bool) (*generated.BeatLicense, error)
+}
+
+type OrderProvider interface {
+	CreateOrder(ctx context.Context, params model.CreateOrder) (*model.Order, error)
+	GetOrder(ctx context.Context, userID uuid.UUID, isAdmin bool, id uuid.UUID, client model.DownloadClient) (*model.Order, *model.BeatArchive, error)
+	HandleWebhook(ctx context.Context, provider string, payload []byte, header http.Header) (*model.Order, error)
+}
+
+type SalesProvider interface {
+	GetPurchases(ctx context.Context, userID uuid.UUID) ([]model.Purchase, error)
+	GetSalesReport(ctx context.Context, params model.GetSalesReportParams) (*model.SalesReport, error)
+}
+
+type DownloadProvider interface {
+	ReissueDownload(ctx context.Context, userID, beatID uuid.UUID, tier generated.LicenseTier, client model.DownloadClient) (string, error)
+	GetDownloads(ctx context.Context, number string) (*model.LicenseDownloads, error)
+	ResetDownloads(ctx context.Context, adminID uuid.UUID, number string) error
+}
+
+type CollaboratorProvider interface {
+	GetBeatCollaborators(ctx context.Context, beatID uuid.UUID) ([]model.Collaborator, error)
+	SetBeatCollaborators(ctx context.Context, userID uuid.UUID, isAdmin bool, beatID uuid.UUID, collaborators []model.Collaborator) ([]model.Collaborator, error)
+	GetEarnings(ctx context.Context, userID uuid.UUID) (*model.Earnings, error)
+}
+
+// FakePayments completes the checkouts of the fake payment provider.
+type FakePayments interface {
+	Complete(sessionID string, paid bool) ([]byte, http.Header, error)
 }
 
 type MediaUploader interface {
 	UploadMedia(ctx context.Context, file io.Reader, m model.MediaMeta) error
 }
 
+type UserProvider interface {
+	GetUsers(ctx context.Context, ids []uuid.UUID) map[uuid.UUID]*userv1.GetUserResponse
+}
+
 type Router struct {
-	app           *runtime.ServeMux
-	beatProvider  BeatProvider
-	mediaUploader MediaUploader
-	log           *slog.Logger
+	app                  *runtime.ServeMux
+	beatProvider         BeatProvider
+	mediaUploader        MediaUploader
+	similarBeatsProvider SimilarBeatsProvider
+	listeningProvider    ListeningProvider
+	likeProvider         LikeProvider
+	playlistProvider     PlaylistProvider
+	taxonomyProvider     TaxonomyProvider
+	tagProvider          TagProvider
+	suggestProvider      SuggestProvider
+	beatmakerProvider    BeatmakerProvider
+	feedProvider         FeedProvider
+	licenseProvider      LicenseProvider
+	orderProvider        OrderProvider
+	salesProvider        SalesProvider
+	downloadProvider     DownloadProvider
+	collaboratorProvider CollaboratorProvider
+	fakePayments         FakePayments
+	userProvider         UserProvider
+	jwtSecret            string
+	defaultLocale        string
+	publicURL            string
+	trustedProxies       []netip.Prefix
+	log                  *slog.Logger
 }
 
 func (r *Router) errorResponse(w http.ResponseWriter, err error, code int) {
@@ -40,16 +161,65 @@ func (r *Router) errorResponse(w http.ResponseWriter, err error, code int) {
 	}
 }
 
+func (r *Router) jsonResponse(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		r.log.Error("write error", sl.Err(err))
+	}
+}
+
 func NewRouter(
 	app *runtime.ServeMux,
 	beatProvider BeatProvider,
 	mediaUploader MediaUploader,
+	similarBeatsProvider SimilarBeatsProvider,
+	listeningProvider ListeningProvider,
+	likeProvider LikeProvider,
+	playlistProvider PlaylistProvider,
+	taxonomyProvider TaxonomyProvider,
+	tagProvider TagProvider,
+	suggestProvider SuggestProvider,
+	beatmakerProvider BeatmakerProvider,
+	feedProvider FeedProvider,
+	licenseProvider LicenseProvider,
+	orderProvider OrderProvider,
+	salesProvider SalesProvider,
+	downloadProvider DownloadProvider,
+	collaboratorProvider CollaboratorProvider,
+	fakePayments FakePayments,
+	userProvider UserProvider,
+	jwtSecret string,
+	defaultLocale string,
+	publicURL string,
+	trustedProxies []netip.Prefix,
 	log *slog.Logger,
 ) {
 	r := &Router{
-		app:           app,
-		beatProvider:  beatProvider,
-		mediaUploader: mediaUploader,
+		app:                  app,
+		beatProvider:         beatProvider,
+		mediaUploader:        mediaUploader,
+		similarBeatsProvider: similarBeatsProvider,
+		listeningProvider:    listeningProvider,
+		likeProvider:         likeProvider,
+		playlistProvider:     playlistProvider,
+		taxonomyProvider:     taxonomyProvider,
+		tagProvider:          tagProvider,
+		suggestProvider:      suggestProvider,
+		beatmakerProvider:    beatmakerProvider,
+		feedProvider:         feedProvider,
+		licenseProvider:      licenseProvider,
+		orderProvider:        orderProvider,
+		salesProvider:        salesProvider,
+		downloadProvider:     downloadProvider,
+		collaboratorProvider: collaboratorProvider,
+		fakePayments:         fakePayments,
+		userProvider:         userProvider,
+		jwtSecret:            jwtSecret,
+		defaultLocale:        defaultLocale,
+		publicURL:            publicURL,
+		trustedProxies:       trustedProxies,
+		log:                  log,
 	}
 
 	r.initRoutes()
@@ -57,7 +227,62 @@ func NewRouter(
 
 func (r *Router) initRoutes() {
 	_ = r.app.HandlePath(http.MethodGet, "/v1/beat/{id}/stream", r.stream)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beat/{id}/image", r.beatImage)
 	_ = r.app.HandlePath(http.MethodPut, "/v1/beat", r.upload)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beats/search", r.searchBeats)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beats/trending", r.trendingBeats)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/suggest", r.suggest)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beat/{id}/similar", r.similarBeats)
+	_ = r.app.HandlePath(http.MethodPost, "/v1/beat/{id}/sessions/{session_id}/heartbeat", r.heartbeat)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beatmaker/plays", r.beatPlays)
+	_ = r.app.HandlePath(http.MethodPut, "/v1/beatmaker/pinned", r.setPinnedBeats)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beatmakers/{id}", r.beatmaker)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/feeds/{format}", r.feed)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/feeds/{format}/beatmakers/{id}", r.feed)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/feeds/{format}/genres/{genre}", r.feed)
+	_ = r.app.HandlePath(http.MethodPut, "/v1/beat/{id}/like", r.likeBeat)
+	_ = r.app.HandlePath(http.MethodDelete, "/v1/beat/{id}/like", r.unlikeBeat)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/me/likes", r.likedBeats)
+	_ = r.app.HandlePath(http.MethodPost, "/v1/playlists", r.createPlaylist)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/me/playlists", r.userPlaylists)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/playlists/{id}", r.playlist)
+	_ = r.app.HandlePath(http.MethodPatch, "/v1/playlists/{id}", r.updatePlaylist)
+	_ = r.app.HandlePath(http.MethodDelete, "/v1/playlists/{id}", r.deletePlaylist)
+	_ = r.app.HandlePath(http.MethodPost, "/v1/playlists/{id}/beats", r.addPlaylistBeat)
+	_ = r.app.HandlePath(http.MethodPut, "/v1/playlists/{id}/beats", r.reorderPlaylistBeats)
+	_ = r.app.HandlePath(http.MethodDelete, "/v1/playlists/{id}/beats/{beat_id}", r.removePlaylistBeat)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/shared/playlists/{token}", r.sharedPlaylist)
+	_ = r.app.HandlePath(http.MethodPut, "/v1/beat/{id}/tags", r.setBeatTags)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/tags/autocomplete", r.autocompleteTags)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beat/{id}/licenses", r.licenseTiers)
+	_ = r.app.HandlePath(http.MethodPut, "/v1/beat/{id}/licenses", r.setLicenseTiers)
+	_ = r.app.HandlePath(http.MethodGet, model.LicenseVerificationPath, r.verifyLicense)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/me/purchases", r.purchases)
+	_ = r.app.HandlePath(http.MethodPost, "/v1/me/purchases/{beat_id}/{tier}/download", r.downloadPurchase)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beatmaker/sales", r.salesReport)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/beat/{id}/collaborators", r.beatCollaborators)
+	_ = r.app.HandlePath(http.MethodPut, "/v1/beat/{id}/collaborators", r.setBeatCollaborators)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/me/earnings", r.earnings)
+	// Only set with payments.provider.
+	if r.orderProvider != nil {
+		_ = r.app.HandlePath(http.MethodPost, "/v1/orders", r.createOrder)
+		_ = r.app.HandlePath(http.MethodGet, "/v1/orders/{id}", r.order)
+		_ = r.app.HandlePath(http.MethodPost, "/v1/payments/{provider}/webhook", r.paymentWebhook)
+	}
+	// Only set with payments.fake_checkout, never in prod.
+	if r.fakePayments != nil {
+		_ = r.app.HandlePath(http.MethodPost, "/v1/payments/fake/checkout/{id}", r.fakeCheckout)
+	}
+	_ = r.app.HandlePath(http.MethodGet, "/v1/admin/licenses/{number}/downloads", r.licenseDownloads)
+	_ = r.app.HandlePath(http.MethodPost, "/v1/admin/licenses/{number}/downloads/reset", r.resetLicenseDownloads)
+	_ = r.app.HandlePath(http.MethodPost, "/v1/admin/licenses/{number}/revoke", r.revokeLicense)
+	_ = r.app.HandlePath(http.MethodGet, "/v1/admin/taxonomy/{kind}", r.taxonomy)
+	_ = r.app.HandlePath(http.MethodPost, "/v1/admin/taxonomy/{kind}", r.createTaxonomyEntry)
+	_ = r.app.HandlePath(http.MethodPatch, "/v1/admin/taxonomy/{kind}/{id}", r.updateTaxonomyEntry)
+	_ = r.app.HandlePath(http.MethodDelete, "/v1/admin/taxonomy/{kind}/{id}", r.deleteTaxonomyEntry)
+	_ = r.app.HandlePath(http.MethodPost, "/v1/admin/taxonomy/{kind}/{id}/merge", r.mergeTaxonomyEntry)
+	_ = r.app.HandlePath(http.MethodPut, "/v1/admin/taxonomy/{kind}/{id}/translations/{locale}", r.setTaxonomyTranslation)
+	_ = r.app.HandlePath(http.MethodDelete, "/v1/admin/taxonomy/{kind}/{id}/translations/{locale}", r.deleteTaxonomyTranslation)
 }
 
 func parseRangeHeader(req *http.Request) (start, end *int, err error) {
@@ -114,6 +339,12 @@ func (r *Router) stream(w http.ResponseWriter, req *http.Request, params map[str
 		return
 	}
 
+	session, err := r.parseListeningSession(req, beatID)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
 	beat, size, contentType, err := r.beatProvider.GetBeatStream(ctx, beatID, s, e)
 	if err != nil {
 		if errors.Is(err, model.ErrBeatNotFound) || errors.Is(err, model.ErrInvalidRangeHeader) {
@@ -127,6 +358,11 @@ func (r *Router) stream(w http.ResponseWriter, req *http.Request, params map[str
 
 	defer beat.Close()
 
+	// a failed session must not break the playback
+	if sessionID, err := r.listeningProvider.StartSession(ctx, *session); err == nil {
+		w.Header().Set(sessionHeader, sessionID.String())
+	}
+
 	w.Header().Set("Content-Type", *contentType)
 	w.Header().Set("Connection", "keep-alive")
 
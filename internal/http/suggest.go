@@ -0,0 +1,84 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+)
+
+const (
+	defaultSuggestLimit = 5
+	maxSuggestLimit     = 10
+)
+
+type beatSuggestionResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type beatmakerSuggestionResponse struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	Pseudonym string `json:"pseudonym"`
+}
+
+type taxonomySuggestionResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type suggestResponse struct {
+	Beats      []beatSuggestionResponse      `json:"beats"`
+	Beatmakers []beatmakerSuggestionResponse `json:"beatmakers"`
+	Genres     []taxonomySuggestionResponse  `json:"genres"`
+	Tags       []taxonomySuggestionResponse  `json:"tags"`
+}
+
+func toTaxonomySuggestionsResponse(suggestions []model.TaxonomySuggestion) []taxonomySuggestionResponse {
+	res := make([]taxonomySuggestionResponse, 0, len(suggestions))
+	for _, s := range suggestions {
+		res = append(res, taxonomySuggestionResponse{ID: s.ID.String(), Name: s.Name, Slug: s.Slug})
+	}
+	return res
+}
+
+// suggest completes the q query parameter of the search box with beat names, beatmakers,
+// genres and tags, up to limit of each.
+func (r *Router) suggest(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	query := req.URL.Query()
+
+	limit := uint64(defaultSuggestLimit)
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.ParseUint(v, 10, 64); err != nil || limit == 0 || limit > maxSuggestLimit {
+			r.errorResponse(w, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("limit must be in [1, %d]", maxSuggestLimit)), http.StatusBadRequest)
+			return
+		}
+	}
+
+	suggestions, err := r.suggestProvider.Suggest(req.Context(), query.Get("q"), limit, r.locales(req))
+	if err != nil {
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := suggestResponse{
+		Beats:      make([]beatSuggestionResponse, 0, len(suggestions.Beats)),
+		Beatmakers: make([]beatmakerSuggestionResponse, 0, len(suggestions.Beatmakers)),
+		Genres:     toTaxonomySuggestionsResponse(suggestions.Genres),
+		Tags:       toTaxonomySuggestionsResponse(suggestions.Tags),
+	}
+	for _, b := range suggestions.Beats {
+		res.Beats = append(res.Beats, beatSuggestionResponse{ID: b.ID.String(), Name: b.Name})
+	}
+	for _, b := range suggestions.Beatmakers {
+		res.Beatmakers = append(res.Beatmakers, beatmakerSuggestionResponse{ID: b.ID.String(), Username: b.Username, Pseudonym: b.Pseudonym})
+	}
+
+	r.jsonResponse(w, res)
+}
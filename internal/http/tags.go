@@ -0,0 +1,107 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAutocompleteTagsLimit = 10
+	maxAutocompleteTagsLimit     = 50
+)
+
+type tagResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Beats *int64 `json:"beats,omitempty"`
+}
+
+type tagsResponse struct {
+	Tags []tagResponse `json:"tags"`
+}
+
+type setBeatTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// setBeatTags replaces the tags of a beat with free-form tags, creating the new ones.
+func (r *Router) setBeatTags(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	claims, ok := r.requireClaims(w, req)
+	if !ok {
+		return
+	}
+
+	beatID, err := uuid.Parse(params["id"])
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "beat id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in setBeatTagsRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	tags, err := r.tagProvider.SetBeatTags(req.Context(), claims.UserID, claims.IsAdmin(), beatID, in.Tags)
+	if err != nil {
+		var modelErr *model.ModelError
+		switch {
+		case errors.Is(err, model.ErrBeatNotFound):
+			r.errorResponse(w, err, http.StatusNotFound)
+		case errors.Is(err, model.ErrNotBeatOwner):
+			r.errorResponse(w, err, http.StatusForbidden)
+		case errors.As(err, &modelErr):
+			r.errorResponse(w, err, http.StatusBadRequest)
+		default:
+			r.log.Error("internal error", sl.Err(err))
+			r.errorResponse(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := tagsResponse{Tags: make([]tagResponse, 0, len(tags))}
+	for _, t := range tags {
+		res.Tags = append(res.Tags, tagResponse{ID: t.ID.String(), Name: t.Name, Slug: t.Slug})
+	}
+
+	r.jsonResponse(w, res)
+}
+
+// autocompleteTags suggests the tags that start with the q query parameter, most used first.
+func (r *Router) autocompleteTags(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	query := req.URL.Query()
+
+	limit := int64(defaultAutocompleteTagsLimit)
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.ParseInt(v, 10, 32); err != nil || limit <= 0 || limit > maxAutocompleteTagsLimit {
+			r.errorResponse(w, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("limit must be in [1, %d]", maxAutocompleteTagsLimit)), http.StatusBadRequest)
+			return
+		}
+	}
+
+	tags, err := r.tagProvider.AutocompleteTags(req.Context(), query.Get("q"), int32(limit))
+	if err != nil {
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := tagsResponse{Tags: make([]tagResponse, 0, len(tags))}
+	for _, t := range tags {
+		res.Tags = append(res.Tags, tagResponse{ID: t.ID.String(), Name: t.Name, Slug: t.Slug, Beats: &t.Beats})
+	}
+
+	r.jsonResponse(w, res)
+}
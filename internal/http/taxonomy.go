@@ -0,0 +1,297 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type taxonomyEntryResponse struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Slug         string            `json:"slug"`
+	Position     int32             `json:"position"`
+	IsArchived   bool              `json:"isArchived"`
+	Beats        int64             `json:"beats"`
+	Translations map[string]string `json:"translations"`
+}
+
+type taxonomyResponse struct {
+	Entries []taxonomyEntryResponse `json:"entries"`
+}
+
+type mergeTaxonomyResponse struct {
+	TargetID string `json:"targetId"`
+	Moved    int64  `json:"moved"`
+}
+
+func toTaxonomyEntryResponse(e model.TaxonomyEntry) taxonomyEntryResponse {
+	translations := e.Translations
+	if translations == nil {
+		translations = map[string]string{}
+	}
+
+	return taxonomyEntryResponse{
+		ID:           e.ID.String(),
+		Name:         e.Name,
+		Slug:         e.Slug,
+		Position:     e.Position,
+		IsArchived:   e.IsArchived,
+		Beats:        e.Beats,
+		Translations: translations,
+	}
+}
+
+// taxonomyErrorResponse writes err of a taxonomy operation with its status code.
+func (r *Router) taxonomyErrorResponse(w http.ResponseWriter, err error) {
+	var modelErr *model.ModelError
+	switch {
+	case errors.Is(err, model.ErrTaxonomyNotFound), errors.Is(err, model.ErrTranslationNotFound):
+		r.errorResponse(w, err, http.StatusNotFound)
+	case errors.Is(err, model.ErrTaxonomyExists), errors.Is(err, model.ErrTaxonomyInUse):
+		r.errorResponse(w, err, http.StatusConflict)
+	case errors.As(err, &modelErr):
+		r.errorResponse(w, err, http.StatusBadRequest)
+	default:
+		r.log.Error("internal error", sl.Err(err))
+		r.errorResponse(w, err, http.StatusInternalServerError)
+	}
+}
+
+// parseTaxonomyParams reads the taxonomy kind and, if the route has one, the entry id.
+func parseTaxonomyParams(params map[string]string) (model.TaxonomyKind, uuid.UUID, error) {
+	kind, err := model.ParseTaxonomyKind(params["kind"])
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	if _, ok := params["id"]; !ok {
+		return kind, uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(params["id"])
+	if err != nil {
+		return "", uuid.Nil, model.NewErr(model.ErrInvalidID, "taxonomy entry id must be uuid")
+	}
+
+	return kind, id, nil
+}
+
+func (r *Router) taxonomy(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	if !r.requireAdmin(w, req) {
+		return
+	}
+
+	kind, _, err := parseTaxonomyParams(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	entries, err := r.taxonomyProvider.GetTaxonomy(req.Context(), kind)
+	if err != nil {
+		r.taxonomyErrorResponse(w, err)
+		return
+	}
+
+	res := taxonomyResponse{Entries: make([]taxonomyEntryResponse, 0, len(entries))}
+	for _, e := range entries {
+		res.Entries = append(res.Entries, toTaxonomyEntryResponse(e))
+	}
+
+	r.jsonResponse(w, res)
+}
+
+type createTaxonomyEntryRequest struct {
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Position *int32 `json:"position"`
+}
+
+func (r *Router) createTaxonomyEntry(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	if !r.requireAdmin(w, req) {
+		return
+	}
+
+	kind, _, err := parseTaxonomyParams(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in createTaxonomyEntryRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := r.taxonomyProvider.CreateTaxonomyEntry(req.Context(), kind, model.SaveTaxonomyEntry{
+		Name:     in.Name,
+		Slug:     in.Slug,
+		Position: in.Position,
+	})
+	if err != nil {
+		r.taxonomyErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, toTaxonomyEntryResponse(*entry))
+}
+
+type updateTaxonomyEntryRequest struct {
+	Name       *string `json:"name"`
+	Slug       *string `json:"slug"`
+	Position   *int32  `json:"position"`
+	IsArchived *bool   `json:"isArchived"`
+}
+
+// updateTaxonomyEntry renames, moves, archives or restores an entry. Archived entries stay
+// on the beats that have them but are hidden from GetBeatParams and can not be given to beats.
+func (r *Router) updateTaxonomyEntry(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	if !r.requireAdmin(w, req) {
+		return
+	}
+
+	kind, id, err := parseTaxonomyParams(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in updateTaxonomyEntryRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := r.taxonomyProvider.UpdateTaxonomyEntry(req.Context(), kind, model.UpdateTaxonomyEntry{
+		ID:         id,
+		Name:       in.Name,
+		Slug:       in.Slug,
+		Position:   in.Position,
+		IsArchived: in.IsArchived,
+	})
+	if err != nil {
+		r.taxonomyErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, toTaxonomyEntryResponse(*entry))
+}
+
+func (r *Router) deleteTaxonomyEntry(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	if !r.requireAdmin(w, req) {
+		return
+	}
+
+	kind, id, err := parseTaxonomyParams(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := r.taxonomyProvider.DeleteTaxonomyEntry(req.Context(), kind, id); err != nil {
+		r.taxonomyErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type mergeTaxonomyRequest struct {
+	TargetID string `json:"targetId"`
+}
+
+// mergeTaxonomyEntry moves the beats of the entry to the target entry and deletes the entry.
+func (r *Router) mergeTaxonomyEntry(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	if !r.requireAdmin(w, req) {
+		return
+	}
+
+	kind, id, err := parseTaxonomyParams(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in mergeTaxonomyRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	targetID, err := uuid.Parse(in.TargetID)
+	if err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrInvalidID, "target id must be uuid"), http.StatusBadRequest)
+		return
+	}
+
+	moved, err := r.taxonomyProvider.MergeTaxonomyEntries(req.Context(), kind, id, targetID)
+	if err != nil {
+		r.taxonomyErrorResponse(w, err)
+		return
+	}
+
+	r.jsonResponse(w, mergeTaxonomyResponse{TargetID: targetID.String(), Moved: moved})
+}
+
+type setTaxonomyTranslationRequest struct {
+	Name string `json:"name"`
+}
+
+// setTaxonomyTranslation sets the name of the entry in the locale of the route.
+func (r *Router) setTaxonomyTranslation(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	if !r.requireAdmin(w, req) {
+		return
+	}
+
+	kind, id, err := parseTaxonomyParams(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	defer req.Body.Close()
+
+	var in setTaxonomyTranslationRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		r.errorResponse(w, model.NewErr(model.ErrValidationFailed, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.taxonomyProvider.SetTaxonomyTranslation(req.Context(), kind, id, params["locale"], in.Name); err != nil {
+		r.taxonomyErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) deleteTaxonomyTranslation(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	if !r.requireAdmin(w, req) {
+		return
+	}
+
+	kind, id, err := parseTaxonomyParams(params)
+	if err != nil {
+		r.errorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := r.taxonomyProvider.DeleteTaxonomyTranslation(req.Context(), kind, id, params["locale"]); err != nil {
+		r.taxonomyErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
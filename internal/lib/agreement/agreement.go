@@ -0,0 +1,115 @@
+// Package agreement renders the license agreements of sold licenses as PDF, from a
+// text template per license tier.
+package agreement
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/pdf"
+)
+
+// commonTemplate holds the blocks the tier templates share.
+const commonTemplate = "_common.tmpl"
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// Templates returns the default templates: _common.tmpl and a <tier>.tmpl per tier.
+func Templates() fs.FS {
+	sub, err := fs.Sub(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Data is what an agreement is rendered from. Price is in minor units of Currency and
+// zero caps are unlimited. VerifyURL is where anyone can check the license by its number.
+type Data struct {
+	Number          string
+	VerifyURL       string
+	Date            time.Time
+	Buyer           string
+	Beatmaker       string
+	Beat            string
+	Tier            string
+	Price           int64
+	Currency        string
+	Deliverables    []string
+	StreamCap       int32
+	DistributionCap int32
+}
+
+// PriceText is the price in major units, e.g. "2000.00 RUB".
+func (d Data) PriceText() string {
+	return fmt.Sprintf("%d.%02d %s", d.Price/100, d.Price%100, d.Currency)
+}
+
+// DeliverablesText names the media the license delivers.
+func (d Data) DeliverablesText() string {
+	names := make([]string, 0, len(d.Deliverables))
+	for _, v := range d.Deliverables {
+		switch v {
+		case "file":
+			names = append(names, "audio file")
+		case "archive":
+			names = append(names, "track stems archive")
+		default:
+			names = append(names, v)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Renderer renders agreements from the templates of the tiers it was made with.
+type Renderer struct {
+	templates map[string]*template.Template
+}
+
+// New parses the <tier>.tmpl templates of the tiers and the shared _common.tmpl in fsys.
+// Lines of a rendered template starting with "# " are headings.
+func New(fsys fs.FS, tiers []string) (*Renderer, error) {
+	r := &Renderer{templates: make(map[string]*template.Template, len(tiers))}
+	for _, tier := range tiers {
+		t, err := template.New(tier+".tmpl").Option("missingkey=error").ParseFS(fsys, commonTemplate, tier+".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parse template of %s: %w", tier, err)
+		}
+		r.templates[tier] = t
+	}
+	return r, nil
+}
+
+// Render writes the agreement of data as PDF to w.
+func (r *Renderer) Render(w io.Writer, data Data) error {
+	t, ok := r.templates[data.Tier]
+	if !ok {
+		return fmt.Errorf("no agreement template of %s", data.Tier)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	doc := pdf.Document{Title: "License agreement " + data.Number}
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		if heading, ok := strings.CutPrefix(sc.Text(), "# "); ok {
+			doc.Heading(heading)
+		} else {
+			doc.Text(sc.Text())
+		}
+	}
+
+	_, err := doc.WriteTo(w)
+	return err
+}
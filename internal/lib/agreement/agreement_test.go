@@ -0,0 +1,75 @@
+package agreement
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tiers = []string{"mp3_lease", "wav_lease", "trackout", "exclusive"}
+
+func testData(tier string) Data {
+	return Data{
+		Number:       "BF-0A1B2C3D4E5F",
+		VerifyURL:    "https://beatflow.example/v1/licenses/verify?number=BF-0A1B2C3D4E5F",
+		Date:         time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Buyer:        "lilbuyer",
+		Beatmaker:    "Lil Beat",
+		Beat:         "Night Drive",
+		Tier:         tier,
+		Price:        200050,
+		Currency:     "RUB",
+		Deliverables: []string{"file", "archive"},
+		StreamCap:    100000,
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(Templates(), tiers)
+	require.NoError(t, err)
+
+	for _, tier := range tiers {
+		t.Run(tier, func(t *testing.T) {
+			t.Parallel()
+
+			var text bytes.Buffer
+			require.NoError(t, r.templates[tier].Execute(&text, testData(tier)))
+			assert.Contains(t, text.String(), "BF-0A1B2C3D4E5F")
+			assert.Contains(t, text.String(), "1 May 2024")
+			assert.Contains(t, text.String(), "lilbuyer")
+			assert.Contains(t, text.String(), `"Night Drive"`)
+			assert.Contains(t, text.String(), "Lil Beat")
+			assert.Contains(t, text.String(), "2000.50 RUB")
+			assert.Contains(t, text.String(), "audio file, track stems archive")
+			assert.Contains(t, text.String(), "up to 100000 streams")
+			assert.Contains(t, text.String(), "Distribution: unlimited")
+			assert.Contains(t, text.String(), `The Licensee keeps the credit "Prod. by Lil Beat"`)
+			assert.Contains(t, text.String(), "https://beatflow.example/v1/licenses/verify?number=BF-0A1B2C3D4E5F")
+
+			var out bytes.Buffer
+			require.NoError(t, r.Render(&out, testData(tier)))
+			assert.True(t, strings.HasPrefix(out.String(), "%PDF-"))
+		})
+	}
+}
+
+func TestNew_FailMissingTemplate(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Templates(), []string{"stems"})
+	assert.Error(t, err)
+}
+
+func TestRender_FailUnknownTier(t *testing.T) {
+	t.Parallel()
+
+	r := &Renderer{templates: map[string]*template.Template{}}
+	assert.Error(t, r.Render(&bytes.Buffer{}, testData("mp3_lease")))
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+// Claims are the claims of the access tokens issued by the user service.
+type Claims struct {
+	UserID uuid.UUID
+	Admin  model.AdminScale
+}
+
+func (c *Claims) IsAdmin() bool {
+	return c.Admin == model.AdminScaleMinor || c.Admin == model.AdminScaleMajor
+}
+
+// FromHeader parses the token of an authorization header in the form "Bearer <token>".
+func FromHeader(header, secret string) (*Claims, error) {
+	data := strings.Fields(header)
+	if len(data) < 2 || strings.ToLower(data[0]) != "bearer" {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "invalid header format")
+	}
+
+	return ParseToken(data[1], secret)
+}
+
+func ParseToken(token, secret string) (*Claims, error) {
+	data, err := jwt.Parse(token, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "unexpected signing method")
+		}
+
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", model.ErrUnauthorized, err)
+	}
+
+	claims, ok := data.Claims.(jwt.MapClaims)
+	if !ok || !data.Valid {
+		return nil, model.ErrUnauthorized
+	}
+
+	var res Claims
+	admin, _ := claims["admin"].(string)
+	res.Admin = model.AdminScale(admin)
+	if id, ok := claims["id"].(string); ok {
+		if res.UserID, err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "user id must be uuid")
+		}
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,68 @@
+// Package camelot maps musical keys to the Camelot wheel, where harmonically
+// compatible keys are neighbours.
+package camelot
+
+var pitchClasses = map[string]int{
+	"C":  0,
+	"C#": 1,
+	"D":  2,
+	"D#": 3,
+	"E":  4,
+	"F":  5,
+	"F#": 6,
+	"G":  7,
+	"G#": 8,
+	"A":  9,
+	"A#": 10,
+	"B":  11,
+}
+
+var noteNames = [12]string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+
+const (
+	Major = "major"
+	Minor = "minor"
+)
+
+// Key is a position on the Camelot wheel: Number is 1-12, Minor keys are the inner (A) ring.
+type Key struct {
+	Number int
+	Minor  bool
+}
+
+// FromNote returns the key of note name (C, C#, ..., B) in scale (major or minor).
+func FromNote(name, scale string) (Key, bool) {
+	pc, ok := pitchClasses[name]
+	if !ok || scale != Major && scale != Minor {
+		return Key{}, false
+	}
+
+	minor := scale == Minor
+	if minor {
+		// the relative major shares the number
+		pc = (pc + 3) % 12
+	}
+
+	// C major is 8B, every fifth up is the next number
+	return Key{Number: (7*pc+7)%12 + 1, Minor: minor}, true
+}
+
+// Note returns the note name and scale of k.
+func (k Key) Note() (name, scale string) {
+	// 7 is its own inverse modulo 12
+	pc := (7 * (k.Number + 4)) % 12
+	if !k.Minor {
+		return noteNames[pc], Major
+	}
+	return noteNames[(pc+9)%12], Minor
+}
+
+// Neighbours returns the keys that mix harmonically with k: the relative key and
+// the adjacent numbers on the same ring.
+func (k Key) Neighbours() []Key {
+	return []Key{
+		{Number: k.Number, Minor: !k.Minor},
+		{Number: k.Number%12 + 1, Minor: k.Minor},
+		{Number: (k.Number+10)%12 + 1, Minor: k.Minor},
+	}
+}
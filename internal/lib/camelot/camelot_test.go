@@ -0,0 +1,58 @@
+package camelot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromNote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		scale string
+		key   Key
+	}{
+		{name: "C", scale: Major, key: Key{Number: 8}},
+		{name: "A", scale: Minor, key: Key{Number: 8, Minor: true}},
+		{name: "G", scale: Major, key: Key{Number: 9}},
+		{name: "B", scale: Major, key: Key{Number: 1}},
+		{name: "G#", scale: Minor, key: Key{Number: 1, Minor: true}},
+		{name: "F", scale: Major, key: Key{Number: 7}},
+		{name: "D", scale: Minor, key: Key{Number: 7, Minor: true}},
+		{name: "C#", scale: Minor, key: Key{Number: 12, Minor: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.scale, func(t *testing.T) {
+			t.Parallel()
+
+			key, ok := FromNote(tt.name, tt.scale)
+			require.True(t, ok)
+			assert.Equal(t, tt.key, key)
+
+			name, scale := key.Note()
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.scale, scale)
+		})
+	}
+}
+
+func TestFromNote_Fail(t *testing.T) {
+	t.Parallel()
+
+	_, ok := FromNote("H", Major)
+	assert.False(t, ok)
+
+	_, ok = FromNote("C", "dorian")
+	assert.False(t, ok)
+}
+
+func TestNeighbours(t *testing.T) {
+	t.Parallel()
+
+	assert.ElementsMatch(t, []Key{{Number: 12}, {Number: 1, Minor: true}, {Number: 2}}, Key{Number: 1}.Neighbours())
+	assert.ElementsMatch(t, []Key{{Number: 12}, {Number: 1, Minor: true}, {Number: 11, Minor: true}}, Key{Number: 12, Minor: true}.Neighbours())
+}
@@ -0,0 +1,314 @@
+package celsql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+)
+
+const (
+	_defaultExpressionSizeLimit = 1024
+	_defaultRecursionLimit      = 32
+)
+
+// Column describes how a declared filter variable maps onto SQL.
+type Column struct {
+	// Type is the CEL type the variable is declared with.
+	Type *cel.Type
+	// Expr is the SQL expression of a scalar variable.
+	Expr string
+	// Contains builds the condition for `value in variable` when the variable is a list.
+	Contains func(value any) sq.Sqlizer
+	// Convert optionally maps a literal compared with the variable onto its SQL argument.
+	Convert func(value any) (any, error)
+}
+
+// Schema is the set of variables a filter expression may reference.
+type Schema map[string]Column
+
+// Error points at the place of the filter expression that can not be translated.
+type Error struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// Translator type-checks CEL expressions against a schema and translates
+// the supported subset into parameterized SQL conditions.
+type Translator struct {
+	env    *cel.Env
+	schema Schema
+}
+
+func New(schema Schema) (*Translator, error) {
+	opts := []cel.EnvOption{
+		cel.ParserExpressionSizeLimit(_defaultExpressionSizeLimit),
+		cel.ParserRecursionLimit(_defaultRecursionLimit),
+	}
+	for name, col := range schema {
+		opts = append(opts, cel.Variable(name, col.Type))
+	}
+
+	env, err := cel.NewEnv(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Translator{env: env, schema: schema}, nil
+}
+
+func MustNew(schema Schema) *Translator {
+	t, err := New(schema)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func (t *Translator) Translate(filter string) (sq.Sqlizer, error) {
+	checked, iss := t.env.Compile(filter)
+	if iss.Err() != nil {
+		e := iss.Errors()[0]
+		return nil, &Error{Line: e.Location.Line(), Column: e.Location.Column() + 1, Msg: e.Message}
+	}
+
+	tr := &translation{schema: t.schema, ast: checked.NativeRep()}
+	if !checked.OutputType().IsExactType(cel.BoolType) {
+		return nil, tr.errorf(tr.ast.Expr(), "filter must evaluate to bool, got %s", checked.OutputType())
+	}
+
+	return tr.condition(tr.ast.Expr())
+}
+
+type translation struct {
+	schema Schema
+	ast    *ast.AST
+}
+
+func (t *translation) errorf(e ast.Expr, format string, args ...any) *Error {
+	loc := t.ast.SourceInfo().GetStartLocation(e.ID())
+	return &Error{Line: loc.Line(), Column: loc.Column() + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+var comparisons = map[string]string{
+	operators.Equals:        "=",
+	operators.NotEquals:     "<>",
+	operators.Less:          "<",
+	operators.LessEquals:    "<=",
+	operators.Greater:       ">",
+	operators.GreaterEquals: ">=",
+}
+
+// flipped holds the operator to use when the literal is on the left-hand side.
+var flipped = map[string]string{
+	operators.Equals:        operators.Equals,
+	operators.NotEquals:     operators.NotEquals,
+	operators.Less:          operators.Greater,
+	operators.LessEquals:    operators.GreaterEquals,
+	operators.Greater:       operators.Less,
+	operators.GreaterEquals: operators.LessEquals,
+}
+
+func (t *translation) condition(e ast.Expr) (sq.Sqlizer, error) {
+	switch e.Kind() {
+	case ast.IdentKind:
+		col, err := t.scalar(e)
+		if err != nil {
+			return nil, err
+		}
+		if !col.Type.IsExactType(cel.BoolType) {
+			return nil, t.errorf(e, "%s is not a condition", e.AsIdent())
+		}
+		return sq.Expr(col.Expr), nil
+	case ast.LiteralKind:
+		if v, ok := e.AsLiteral().Value().(bool); ok {
+			if v {
+				return sq.Expr("true"), nil
+			}
+			return sq.Expr("false"), nil
+		}
+	case ast.CallKind:
+		call := e.AsCall()
+		switch fn := call.FunctionName(); fn {
+		case operators.LogicalAnd, operators.LogicalOr:
+			l, err := t.condition(call.Args()[0])
+			if err != nil {
+				return nil, err
+			}
+			r, err := t.condition(call.Args()[1])
+			if err != nil {
+				return nil, err
+			}
+			if fn == operators.LogicalAnd {
+				return sq.And{l, r}, nil
+			}
+			return sq.Or{l, r}, nil
+		case operators.LogicalNot:
+			c, err := t.condition(call.Args()[0])
+			if err != nil {
+				return nil, err
+			}
+			return sq.Expr("not (?)", c), nil
+		case operators.Equals, operators.NotEquals, operators.Less, operators.LessEquals, operators.Greater, operators.GreaterEquals:
+			return t.comparison(fn, call.Args()[0], call.Args()[1])
+		case operators.In:
+			return t.membership(e, call.Args()[0], call.Args()[1])
+		case overloads.StartsWith, overloads.EndsWith, overloads.Contains:
+			if call.IsMemberFunction() {
+				return t.like(e, fn, call.Target(), call.Args()[0])
+			}
+		}
+		return nil, t.errorf(e, "unsupported function %s", displayName(call.FunctionName()))
+	}
+
+	return nil, t.errorf(e, "unsupported expression")
+}
+
+func (t *translation) comparison(fn string, l, r ast.Expr) (sq.Sqlizer, error) {
+	if l.Kind() != ast.IdentKind && r.Kind() == ast.IdentKind {
+		l, r, fn = r, l, flipped[fn]
+	}
+
+	col, err := t.scalar(l)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := t.value(r, col)
+	if err != nil {
+		return nil, err
+	}
+
+	return sq.Expr(fmt.Sprintf("%s %s ?", col.Expr, comparisons[fn]), v), nil
+}
+
+func (t *translation) membership(e ast.Expr, elem, coll ast.Expr) (sq.Sqlizer, error) {
+	if coll.Kind() == ast.IdentKind {
+		col, ok := t.schema[coll.AsIdent()]
+		if !ok || col.Contains == nil {
+			return nil, t.errorf(coll, "%s is not a list", coll.AsIdent())
+		}
+
+		v, err := t.value(elem, col)
+		if err != nil {
+			return nil, err
+		}
+
+		return col.Contains(v), nil
+	}
+
+	if elem.Kind() != ast.IdentKind || coll.Kind() != ast.ListKind {
+		return nil, t.errorf(e, "in must test a variable against a list of values or a value against a list variable")
+	}
+
+	col, err := t.scalar(elem)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []any
+	for _, v := range coll.AsList().Elements() {
+		val, err := t.value(v, col)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, val)
+	}
+
+	return sq.Eq{col.Expr: values}, nil
+}
+
+func (t *translation) like(e ast.Expr, fn string, target, arg ast.Expr) (sq.Sqlizer, error) {
+	if target.Kind() != ast.IdentKind {
+		return nil, t.errorf(e, "%s must be called on a variable", fn)
+	}
+
+	col, err := t.scalar(target)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := t.value(arg, Column{})
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, t.errorf(arg, "%s expects a string", fn)
+	}
+
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	switch fn {
+	case overloads.StartsWith:
+		s += "%"
+	case overloads.EndsWith:
+		s = "%" + s
+	default:
+		s = "%" + s + "%"
+	}
+
+	return sq.Expr(fmt.Sprintf("%s like ?", col.Expr), s), nil
+}
+
+func (t *translation) scalar(e ast.Expr) (Column, error) {
+	if e.Kind() != ast.IdentKind {
+		return Column{}, t.errorf(e, "expected a variable")
+	}
+
+	col, ok := t.schema[e.AsIdent()]
+	if !ok || col.Expr == "" {
+		return Column{}, t.errorf(e, "%s can not be compared directly", e.AsIdent())
+	}
+
+	return col, nil
+}
+
+func (t *translation) value(e ast.Expr, col Column) (v any, err error) {
+	switch e.Kind() {
+	case ast.LiteralKind:
+		v = e.AsLiteral().Value()
+	case ast.CallKind:
+		call := e.AsCall()
+		if call.FunctionName() != overloads.TypeConvertTimestamp || len(call.Args()) != 1 || call.Args()[0].Kind() != ast.LiteralKind {
+			return nil, t.errorf(e, "expected a constant value")
+		}
+		s, ok := call.Args()[0].AsLiteral().Value().(string)
+		if !ok {
+			return nil, t.errorf(e, "timestamp expects an RFC 3339 string")
+		}
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, t.errorf(e, "timestamp expects an RFC 3339 string")
+		}
+		v = ts.UTC()
+	default:
+		return nil, t.errorf(e, "expected a constant value")
+	}
+
+	if col.Convert != nil {
+		if v, err = col.Convert(v); err != nil {
+			return nil, t.errorf(e, "%s", err.Error())
+		}
+	}
+
+	return v, nil
+}
+
+func displayName(fn string) string {
+	if name, ok := operators.FindReverse(fn); ok {
+		return name
+	}
+	return fn
+}
@@ -0,0 +1,125 @@
+package celsql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTranslator(t *testing.T) *Translator {
+	t.Helper()
+
+	tr, err := New(Schema{
+		"name":    {Type: cel.StringType, Expr: "b.name"},
+		"bpm":     {Type: cel.IntType, Expr: "b.bpm"},
+		"deleted": {Type: cel.BoolType, Expr: "b.is_deleted"},
+		"created": {Type: cel.TimestampType, Expr: "b.created_at"},
+		"genres":  {Type: cel.ListType(cel.StringType), Contains: func(v any) sq.Sqlizer { return sq.Expr("exists(genre = ?)", v) }},
+		"beatmaker": {
+			Type: cel.StringType,
+			Expr: "b.beatmaker_id",
+			Convert: func(v any) (any, error) {
+				if v == "" {
+					return nil, errors.New("beatmaker must not be empty")
+				}
+				return v, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return tr
+}
+
+func TestTranslate_Success(t *testing.T) {
+	t.Parallel()
+
+	tr := createTranslator(t)
+
+	tests := []struct {
+		filter string
+		sql    string
+		args   []any
+	}{
+		{
+			filter: `bpm >= 120 && bpm < 140`,
+			sql:    "(b.bpm >= ? AND b.bpm < ?)",
+			args:   []any{int64(120), int64(140)},
+		},
+		{
+			filter: `100 < bpm`,
+			sql:    "b.bpm > ?",
+			args:   []any{int64(100)},
+		},
+		{
+			filter: `"trap" in genres && !("drill" in genres)`,
+			sql:    "(exists(genre = ?) AND not (exists(genre = ?)))",
+			args:   []any{"trap", "drill"},
+		},
+		{
+			filter: `name in ["a", "b"] || deleted`,
+			sql:    "(b.name IN (?,?) OR b.is_deleted)",
+			args:   []any{"a", "b"},
+		},
+		{
+			filter: `name.startsWith("lo_fi%")`,
+			sql:    "b.name like ?",
+			args:   []any{`lo\_fi\%%`},
+		},
+		{
+			filter: `created > timestamp("2024-01-02T03:04:05Z")`,
+			sql:    "b.created_at > ?",
+			args:   []any{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			cond, err := tr.Translate(tt.filter)
+			require.NoError(t, err)
+
+			sql, args, err := cond.ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, tt.sql, sql)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestTranslate_Fail(t *testing.T) {
+	t.Parallel()
+
+	tr := createTranslator(t)
+
+	tests := []struct {
+		filter string
+		line   int
+		column int
+	}{
+		{filter: `bpm > `, line: 1, column: 7},
+		{filter: `unknown == 1`, line: 1, column: 1},
+		{filter: `bpm == "fast"`, line: 1, column: 5},
+		{filter: `bpm + 1`, line: 1, column: 5},
+		{filter: `bpm == 1 ||
+genres.exists(g, g == "trap")`, line: 2, column: 14},
+		{filter: `name.size() > 3`, line: 1, column: 10},
+		{filter: `beatmaker == ""`, line: 1, column: 14},
+		{filter: `genres == ["trap"]`, line: 1, column: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			_, err := tr.Translate(tt.filter)
+
+			var filterErr *Error
+			require.ErrorAs(t, err, &filterErr)
+			assert.Equal(t, tt.line, filterErr.Line, filterErr.Error())
+			assert.Equal(t, tt.column, filterErr.Column, filterErr.Error())
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package feed writes syndication feeds in the RSS 2.0 and Atom formats.
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// mediaNS is the Media RSS namespace used for item images in both formats.
+const mediaNS = "http://search.yahoo.com/mrss/"
+
+type (
+	Feed struct {
+		Title       string
+		Description string
+		// Link is the page of the feed, Self is the URL of the feed itself.
+		Link     string
+		Self     string
+		Language string
+		Updated  time.Time
+		Items    []Item
+	}
+
+	Item struct {
+		// ID must stay the same for the item forever, e.g. urn:uuid:<beat id>.
+		ID          string
+		Title       string
+		Link        string
+		Description string
+		Author      string
+		Categories  []string
+		Published   time.Time
+		Enclosure   *Enclosure
+		Image       string
+	}
+
+	// Enclosure is a media file attached to an item. Length is in bytes and may be 0
+	// if it is not known.
+	Enclosure struct {
+		URL    string
+		Type   string
+		Length int64
+	}
+)
+
+type (
+	rss struct {
+		XMLName xml.Name   `xml:"rss"`
+		Version string     `xml:"version,attr"`
+		Atom    string     `xml:"xmlns:atom,attr"`
+		DC      string     `xml:"xmlns:dc,attr"`
+		Media   string     `xml:"xmlns:media,attr"`
+		Channel rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title         string    `xml:"title"`
+		Link          string    `xml:"link"`
+		Self          atomLink  `xml:"atom:link"`
+		Description   string    `xml:"description"`
+		Language      string    `xml:"language,omitempty"`
+		LastBuildDate string    `xml:"lastBuildDate"`
+		Items         []rssItem `xml:"item"`
+	}
+
+	rssItem struct {
+		Title       string          `xml:"title"`
+		Link        string          `xml:"link,omitempty"`
+		Description string          `xml:"description,omitempty"`
+		Author      string          `xml:"dc:creator,omitempty"`
+		Categories  []string        `xml:"category"`
+		GUID        rssGUID         `xml:"guid"`
+		PubDate     string          `xml:"pubDate"`
+		Enclosure   *rssEnclosure   `xml:"enclosure"`
+		Thumbnail   *mediaThumbnail `xml:"media:thumbnail"`
+	}
+
+	rssGUID struct {
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	}
+
+	rssEnclosure struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length int64  `xml:"length,attr"`
+	}
+
+	mediaThumbnail struct {
+		URL string `xml:"url,attr"`
+	}
+)
+
+// WriteRSS writes f as an RSS 2.0 document.
+func (f *Feed) WriteRSS(w io.Writer) error {
+	doc := rss{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Media:   mediaNS,
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Self:          atomLink{Href: f.Self, Rel: "self", Type: "application/rss+xml"},
+			Description:   f.Description,
+			Language:      f.Language,
+			LastBuildDate: f.Updated.UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(f.Items)),
+		},
+	}
+
+	for _, it := range f.Items {
+		item := rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			Description: it.Description,
+			Author:      it.Author,
+			Categories:  it.Categories,
+			GUID:        rssGUID{Value: it.ID},
+			PubDate:     it.Published.UTC().Format(time.RFC1123Z),
+		}
+		if it.Enclosure != nil {
+			item.Enclosure = &rssEnclosure{URL: it.Enclosure.URL, Type: it.Enclosure.Type, Length: it.Enclosure.Length}
+		}
+		if it.Image != "" {
+			item.Thumbnail = &mediaThumbnail{URL: it.Image}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+
+	return write(w, doc)
+}
+
+type (
+	atomFeed struct {
+		XMLName xml.Name    `xml:"feed"`
+		NS      string      `xml:"xmlns,attr"`
+		Media   string      `xml:"xmlns:media,attr"`
+		Lang    string      `xml:"xml:lang,attr,omitempty"`
+		ID      string      `xml:"id"`
+		Title   string      `xml:"title"`
+		Updated string      `xml:"updated"`
+		Links   []atomLink  `xml:"link"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	atomLink struct {
+		Href   string `xml:"href,attr"`
+		Rel    string `xml:"rel,attr,omitempty"`
+		Type   string `xml:"type,attr,omitempty"`
+		Length int64  `xml:"length,attr,omitempty"`
+	}
+
+	atomEntry struct {
+		ID         string          `xml:"id"`
+		Title      string          `xml:"title"`
+		Updated    string          `xml:"updated"`
+		Published  string          `xml:"published"`
+		Author     *atomAuthor     `xml:"author"`
+		Summary    string          `xml:"summary,omitempty"`
+		Categories []atomCategory  `xml:"category"`
+		Links      []atomLink      `xml:"link"`
+		Thumbnail  *mediaThumbnail `xml:"media:thumbnail"`
+	}
+
+	atomAuthor struct {
+		Name string `xml:"name"`
+	}
+
+	atomCategory struct {
+		Term string `xml:"term,attr"`
+	}
+)
+
+// WriteAtom writes f as an Atom document. The feed id is its Self URL.
+func (f *Feed) WriteAtom(w io.Writer) error {
+	doc := atomFeed{
+		NS:      "http://www.w3.org/2005/Atom",
+		Media:   mediaNS,
+		Lang:    f.Language,
+		ID:      f.Self,
+		Title:   f.Title,
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate"},
+			{Href: f.Self, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+
+	for _, it := range f.Items {
+		entry := atomEntry{
+			ID:        it.ID,
+			Title:     it.Title,
+			Updated:   it.Published.UTC().Format(time.RFC3339),
+			Published: it.Published.UTC().Format(time.RFC3339),
+			Summary:   it.Description,
+		}
+		if it.Author != "" {
+			entry.Author = &atomAuthor{Name: it.Author}
+		}
+		for _, c := range it.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: c})
+		}
+		if it.Link != "" {
+			entry.Links = append(entry.Links, atomLink{Href: it.Link, Rel: "alternate"})
+		}
+		if it.Enclosure != nil {
+			entry.Links = append(entry.Links, atomLink{Href: it.Enclosure.URL, Rel: "enclosure", Type: it.Enclosure.Type, Length: it.Enclosure.Length})
+		}
+		if it.Image != "" {
+			entry.Thumbnail = &mediaThumbnail{URL: it.Image}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return write(w, doc)
+}
+
+func write(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
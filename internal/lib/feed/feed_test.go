@@ -0,0 +1,98 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFeed() *Feed {
+	return &Feed{
+		Title:   "New beats: Phonk & Drift",
+		Link:    "https://beatflow.example/",
+		Self:    "https://beatflow.example/v1/feeds/rss",
+		Updated: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Items: []Item{{
+			ID:         "urn:uuid:0d6b5c3e-2f64-4b8e-9a57-6a1b1b1f3c2d",
+			Title:      "Night Drive",
+			Author:     "Lil Beat",
+			Categories: []string{"Phonk"},
+			Published:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Enclosure:  &Enclosure{URL: "https://beatflow.example/v1/beat/1/stream", Type: "audio/mpeg"},
+			Image:      "https://beatflow.example/v1/beat/1/image",
+		}},
+	}
+}
+
+func TestWriteRSS(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, testFeed().WriteRSS(&buf))
+
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title     string `xml:"title"`
+				GUID      string `xml:"guid"`
+				PubDate   string `xml:"pubDate"`
+				Enclosure struct {
+					URL    string `xml:"url,attr"`
+					Type   string `xml:"type,attr"`
+					Length string `xml:"length,attr"`
+				} `xml:"enclosure"`
+				Thumbnail struct {
+					URL string `xml:"url,attr"`
+				} `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "New beats: Phonk & Drift", doc.Channel.Title)
+	require.Len(t, doc.Channel.Items, 1)
+
+	item := doc.Channel.Items[0]
+	assert.Equal(t, "urn:uuid:0d6b5c3e-2f64-4b8e-9a57-6a1b1b1f3c2d", item.GUID)
+	assert.Equal(t, "Wed, 01 May 2024 12:00:00 +0000", item.PubDate)
+	assert.Equal(t, "https://beatflow.example/v1/beat/1/stream", item.Enclosure.URL)
+	assert.Equal(t, "audio/mpeg", item.Enclosure.Type)
+	assert.Equal(t, "0", item.Enclosure.Length)
+	assert.Equal(t, "https://beatflow.example/v1/beat/1/image", item.Thumbnail.URL)
+}
+
+func TestWriteAtom(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, testFeed().WriteAtom(&buf))
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID     string `xml:"id"`
+			Author string `xml:"author>name"`
+			Links  []struct {
+				Href string `xml:"href,attr"`
+				Rel  string `xml:"rel,attr"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "https://beatflow.example/v1/feeds/rss", doc.ID)
+	assert.Equal(t, "2024-05-01T12:00:00Z", doc.Updated)
+	require.Len(t, doc.Entries, 1)
+	assert.Equal(t, "Lil Beat", doc.Entries[0].Author)
+	require.Len(t, doc.Entries[0].Links, 1)
+	assert.Equal(t, "enclosure", doc.Entries[0].Links[0].Rel)
+}
@@ -0,0 +1,71 @@
+// Package locale picks the locales in which a request wants to see display names.
+package locale
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// maxLocales caps the number of locales taken from a single Accept-Language value.
+const maxLocales = 8
+
+// wildcard is what the wildcard of Accept-Language parses to.
+var wildcard = language.Make("mul")
+
+// Canonical returns the canonical form of the BCP 47 tag s, e.g. pt-BR for pt_br.
+func Canonical(s string) (string, bool) {
+	tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"))
+	if err != nil || tag == language.Und {
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// Parse parses a locale or an Accept-Language value into canonical locales in order of
+// preference. Every regional locale is followed by its language, so that pt-BR falls
+// back to pt. Invalid values give no locales.
+func Parse(s string) []string {
+	tags, _, err := language.ParseAcceptLanguage(strings.ReplaceAll(s, "_", "-"))
+	if err != nil {
+		return nil
+	}
+
+	var res []string
+	for _, tag := range tags {
+		if tag == language.Und || tag == wildcard || len(res) >= maxLocales {
+			continue
+		}
+		res = appendUnique(res, tag.String())
+		if base, conf := tag.Base(); conf != language.No {
+			res = appendUnique(res, base.String())
+		}
+	}
+	return res
+}
+
+// Resolve returns the locales of the first candidate that has any, followed by fallback.
+// Candidates go from the most to the least explicit, e.g. a query parameter before
+// the Accept-Language header.
+func Resolve(fallback string, candidates ...string) []string {
+	var res []string
+	for _, c := range candidates {
+		if res = Parse(c); len(res) > 0 {
+			break
+		}
+	}
+
+	for _, l := range Parse(fallback) {
+		res = appendUnique(res, l)
+	}
+	return res
+}
+
+func appendUnique(s []string, v string) []string {
+	for _, e := range s {
+		if e == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
@@ -0,0 +1,52 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{in: "ru", want: []string{"ru"}},
+		{in: "pt_br", want: []string{"pt-BR", "pt"}},
+		{in: "en-US,en;q=0.9,ru;q=0.8", want: []string{"en-US", "en", "ru"}},
+		{in: "ru;q=0.5, de", want: []string{"de", "ru"}},
+		{in: "*", want: nil},
+		{in: "", want: nil},
+		{in: "not a locale!", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Parse(tt.in))
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"ru", "en"}, Resolve("en", "ru", "de"))
+	assert.Equal(t, []string{"de-AT", "de", "en"}, Resolve("en", "", "de-AT"))
+	assert.Equal(t, []string{"en"}, Resolve("en", "en"))
+	assert.Equal(t, []string{"en"}, Resolve("en", "!!!"))
+}
+
+func TestCanonical(t *testing.T) {
+	t.Parallel()
+
+	l, ok := Canonical("pt_br")
+	assert.True(t, ok)
+	assert.Equal(t, "pt-BR", l)
+
+	_, ok = Canonical("not a locale")
+	assert.False(t, ok)
+}
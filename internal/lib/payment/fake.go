@@ -0,0 +1,191 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FakeName is the name of the fake provider.
+	FakeName = "fake"
+	// FakeSignatureHeader is the header of the fake webhook signatures, in the form
+	// t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<payload>">.
+	FakeSignatureHeader = "Fake-Signature"
+	// FakeCheckoutPath is the path of the fake checkout pages, followed by the session id.
+	FakeCheckoutPath = "/v1/payments/fake/checkout/"
+
+	fakeTolerance = 5 * time.Minute
+)
+
+// ErrCheckoutNotFound is returned by Fake.Complete for sessions it did not start.
+var ErrCheckoutNotFound = errors.New("checkout not found")
+
+// Fake is an in-process payment provider for local runs and tests. Its checkouts are paid
+// or failed by Complete, which returns the webhook a real provider would send, signed the
+// way ParseWebhook checks it. Refunds of paid checkouts are only recorded.
+type Fake struct {
+	secret  []byte
+	baseURL string
+	now     func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]Checkout
+	paid     map[string]Checkout
+	refunded map[string]Checkout
+}
+
+// NewFake returns a fake provider that signs webhooks with secret and serves checkout
+// pages under baseURL.
+func NewFake(secret, baseURL string) *Fake {
+	return &Fake{
+		secret:   []byte(secret),
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		now:      time.Now,
+		sessions: make(map[string]Checkout),
+		paid:     make(map[string]Checkout),
+		refunded: make(map[string]Checkout),
+	}
+}
+
+func (f *Fake) Name() string {
+	return FakeName
+}
+
+func (f *Fake) CreateCheckout(ctx context.Context, checkout Checkout) (*Session, error) {
+	id, err := randomID("cs_")
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.sessions[id] = checkout
+	f.mu.Unlock()
+
+	return &Session{ID: id, URL: f.baseURL + FakeCheckoutPath + id}, nil
+}
+
+// Complete pays or fails the checkout of the session and returns the webhook of the outcome.
+func (f *Fake) Complete(sessionID string, paid bool) ([]byte, http.Header, error) {
+	f.mu.Lock()
+	checkout, ok := f.sessions[sessionID]
+	delete(f.sessions, sessionID)
+	if ok && paid {
+		f.paid[sessionID] = checkout
+	}
+	f.mu.Unlock()
+
+	if !ok {
+		return nil, nil, ErrCheckoutNotFound
+	}
+
+	id, err := randomID("evt_")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	event := Event{ID: id, Kind: EventFailed, CheckoutID: sessionID}
+	if paid {
+		event.Kind = EventPaid
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := make(http.Header)
+	header.Set(FakeSignatureHeader, f.sign(payload, f.now()))
+	return payload, header, nil
+}
+
+func (f *Fake) ParseWebhook(payload []byte, header http.Header) (*Event, error) {
+	var (
+		ts  int64
+		sig []byte
+		err error
+	)
+	for _, part := range strings.Split(header.Get(FakeSignatureHeader), ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			ts, err = strconv.ParseInt(v, 10, 64)
+		case "v1":
+			sig, err = hex.DecodeString(v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed signature", ErrInvalidWebhook)
+		}
+	}
+
+	if ts == 0 || sig == nil {
+		return nil, fmt.Errorf("%w: signature not provided", ErrInvalidWebhook)
+	}
+
+	if d := f.now().Sub(time.Unix(ts, 0)); d > fakeTolerance || d < -fakeTolerance {
+		return nil, fmt.Errorf("%w: signature expired", ErrInvalidWebhook)
+	}
+
+	if !hmac.Equal(sig, f.mac(payload, ts)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidWebhook)
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWebhook, err.Error())
+	}
+
+	if event.Kind != EventPaid && event.Kind != EventFailed {
+		return nil, fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, event.Kind)
+	}
+
+	return &event, nil
+}
+
+// Refund records the refund of the paid checkout of the session.
+func (f *Fake) Refund(ctx context.Context, checkoutID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.refunded[checkoutID]; ok {
+		return nil
+	}
+
+	checkout, ok := f.paid[checkoutID]
+	if !ok {
+		return ErrCheckoutNotFound
+	}
+
+	delete(f.paid, checkoutID)
+	f.refunded[checkoutID] = checkout
+	return nil
+}
+
+func (f *Fake) sign(payload []byte, t time.Time) string {
+	return fmt.Sprintf("t=%d,v1=%s", t.Unix(), hex.EncodeToString(f.mac(payload, t.Unix())))
+}
+
+func (f *Fake) mac(payload []byte, ts int64) []byte {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write([]byte(strconv.FormatInt(ts, 10) + "."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func randomID(prefix string) (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
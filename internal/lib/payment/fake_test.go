@@ -0,0 +1,109 @@
+package payment
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake_Complete(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake("secret", "https://beatflow.example/")
+
+	session, err := f.CreateCheckout(context.Background(), Checkout{OrderID: uuid.New(), Amount: 2000, Currency: "RUB"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://beatflow.example"+FakeCheckoutPath+session.ID, session.URL)
+
+	payload, header, err := f.Complete(session.ID, true)
+	require.NoError(t, err)
+
+	event, err := f.ParseWebhook(payload, header)
+	require.NoError(t, err)
+	assert.Equal(t, EventPaid, event.Kind)
+	assert.Equal(t, session.ID, event.CheckoutID)
+
+	// A session is completed once.
+	_, _, err = f.Complete(session.ID, true)
+	assert.ErrorIs(t, err, ErrCheckoutNotFound)
+}
+
+func TestFake_Refund(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake("secret", "")
+
+	paid, err := f.CreateCheckout(context.Background(), Checkout{OrderID: uuid.New(), Amount: 2000, Currency: "RUB"})
+	require.NoError(t, err)
+	_, _, err = f.Complete(paid.ID, true)
+	require.NoError(t, err)
+
+	failed, err := f.CreateCheckout(context.Background(), Checkout{OrderID: uuid.New(), Amount: 2000, Currency: "RUB"})
+	require.NoError(t, err)
+	_, _, err = f.Complete(failed.ID, false)
+	require.NoError(t, err)
+
+	require.NoError(t, f.Refund(context.Background(), paid.ID))
+	// Refunds are idempotent, checkouts that were not paid cannot be refunded.
+	require.NoError(t, f.Refund(context.Background(), paid.ID))
+	assert.ErrorIs(t, f.Refund(context.Background(), failed.ID), ErrCheckoutNotFound)
+}
+
+func TestFake_ParseWebhookFail(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake("secret", "")
+	payload := []byte(`{"id":"evt_1","type":"checkout.paid","checkout_id":"cs_1"}`)
+
+	header := func(v string) http.Header {
+		h := make(http.Header)
+		h.Set(FakeSignatureHeader, v)
+		return h
+	}
+
+	tests := []struct {
+		name    string
+		payload []byte
+		header  http.Header
+	}{
+		{
+			name:    "no signature",
+			payload: payload,
+			header:  http.Header{},
+		},
+		{
+			name:    "other secret",
+			payload: payload,
+			header:  header(NewFake("other", "").sign(payload, time.Now())),
+		},
+		{
+			name:    "tampered payload",
+			payload: []byte(`{"id":"evt_1","type":"checkout.paid","checkout_id":"cs_2"}`),
+			header:  header(f.sign(payload, time.Now())),
+		},
+		{
+			name:    "expired",
+			payload: payload,
+			header:  header(f.sign(payload, time.Now().Add(-time.Hour))),
+		},
+		{
+			name:    "unknown event",
+			payload: []byte(`{"id":"evt_1","type":"checkout.refunded","checkout_id":"cs_1"}`),
+			header:  header(f.sign([]byte(`{"id":"evt_1","type":"checkout.refunded","checkout_id":"cs_1"}`), time.Now())),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := f.ParseWebhook(tt.payload, tt.header)
+			assert.ErrorIs(t, err, ErrInvalidWebhook)
+		})
+	}
+}
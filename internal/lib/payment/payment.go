@@ -0,0 +1,56 @@
+// Package payment is the interface of the payment providers orders are paid through:
+// a checkout is started for an order and the provider reports its outcome through
+// signed webhooks. Paid checkouts can be refunded.
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidWebhook is returned for webhooks that are malformed, have a wrong signature
+// or were signed too long ago.
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// Checkout is the payment of an order. Amount is in minor units of Currency.
+type Checkout struct {
+	OrderID     uuid.UUID
+	Amount      int64
+	Currency    string
+	Description string
+}
+
+// Session is a started checkout, the buyer pays it at URL.
+type Session struct {
+	ID  string
+	URL string
+}
+
+type EventKind string
+
+const (
+	EventPaid   EventKind = "checkout.paid"
+	EventFailed EventKind = "checkout.failed"
+)
+
+// Event is the outcome of the checkout of a session, sent by the provider in a webhook.
+type Event struct {
+	ID         string    `json:"id"`
+	Kind       EventKind `json:"type"`
+	CheckoutID string    `json:"checkout_id"`
+}
+
+// Provider starts checkouts, verifies the webhooks of their outcomes and refunds them.
+type Provider interface {
+	// Name is the name of the provider in the webhook URL and in the orders.
+	Name() string
+	CreateCheckout(ctx context.Context, checkout Checkout) (*Session, error)
+	// ParseWebhook verifies the signature of the webhook and returns its event.
+	ParseWebhook(payload []byte, header http.Header) (*Event, error)
+	// Refund returns the whole amount of the paid checkout to the buyer. Refunding a
+	// checkout again does nothing.
+	Refund(ctx context.Context, checkoutID string) error
+}
@@ -0,0 +1,167 @@
+// Package pdf writes plain text documents as PDF: A4 pages of headings and wrapped
+// paragraphs in the standard Helvetica fonts, which need no embedding.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/slug"
+)
+
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 56
+	textSize     = 11
+	headingSize  = 14
+	lineHeight   = 16
+	linesPerPage = (pageHeight - 2*margin) / lineHeight
+	// lineWidth is the number of characters in a line, Helvetica averages about half of
+	// the font size per character.
+	lineWidth = (pageWidth - 2*margin) * 2 / textSize
+)
+
+type line struct {
+	text    string
+	heading bool
+}
+
+// Document is a text document. Its zero value is an empty document.
+type Document struct {
+	Title string
+	lines []line
+}
+
+// Heading adds a bold line.
+func (d *Document) Heading(text string) {
+	d.lines = append(d.lines, line{text: text, heading: true})
+}
+
+// Text adds a paragraph wrapped at the page width. An empty text adds a blank line.
+func (d *Document) Text(text string) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		d.lines = append(d.lines, line{})
+		return
+	}
+
+	var b strings.Builder
+	for _, w := range words {
+		if b.Len() > 0 && len([]rune(b.String()))+1+len([]rune(w)) > lineWidth {
+			d.lines = append(d.lines, line{text: b.String()})
+			b.Reset()
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(w)
+	}
+	d.lines = append(d.lines, line{text: b.String()})
+}
+
+// WriteTo writes the document as PDF to w.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var pages [][]line
+	for i := 0; i < len(d.lines); i += linesPerPage {
+		pages = append(pages, d.lines[i:min(i+linesPerPage, len(d.lines))])
+	}
+	if len(pages) == 0 {
+		pages = append(pages, nil)
+	}
+
+	// Objects: 1 catalog, 2 pages, 3 and 4 fonts, 5 info, then a page and its content
+	// for every page.
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Title (%s) /Producer (drop-audio-streaming) >>", encode(d.Title)),
+	}
+
+	kids := make([]string, 0, len(pages))
+	for _, p := range pages {
+		pageID := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageID))
+
+		var content bytes.Buffer
+		content.WriteString("BT\n")
+		fmt.Fprintf(&content, "%d %d Td\n", margin, pageHeight-margin)
+		for i, l := range p {
+			font, size := "F1", textSize
+			if l.heading {
+				font, size = "F2", headingSize
+			}
+			if i > 0 {
+				fmt.Fprintf(&content, "0 -%d Td\n", lineHeight)
+			}
+			fmt.Fprintf(&content, "/%s %d Tf (%s) Tj\n", font, size, encode(l.text))
+		}
+		content.WriteString("ET")
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, pageID+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, o := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// encode escapes s for a PDF string in WinAnsiEncoding. The standard fonts have no
+// cyrillic letters, so they are transliterated, other runes outside Latin-1 become '?'.
+func encode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case unicode.Is(unicode.Cyrillic, r) && isASCII(slug.Transliterate(string(r))):
+			t := slug.Transliterate(string(r))
+			if unicode.IsUpper(r) && t != "" {
+				t = strings.ToUpper(t[:1]) + t[1:]
+			}
+			b.WriteString(t)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
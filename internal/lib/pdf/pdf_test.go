@@ -0,0 +1,85 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTo(t *testing.T) {
+	t.Parallel()
+
+	d := Document{Title: "License (BF-1)"}
+	d.Heading("Exclusive license agreement")
+	d.Text("")
+	d.Text(strings.Repeat("word ", 100))
+	for i := 0; i < linesPerPage; i++ {
+		d.Text(fmt.Sprintf("line %d", i))
+	}
+
+	var buf bytes.Buffer
+	_, err := d.WriteTo(&buf)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(out, "%%EOF\n"))
+	assert.Contains(t, out, "/Count 2")
+	assert.Contains(t, out, `(License \(BF-1\))`)
+
+	// The xref offsets point at the objects.
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(out)
+	require.Len(t, m, 2)
+	xref, err := strconv.Atoi(m[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out[xref:], "xref\n"))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(out[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, e := range entries {
+		off, err := strconv.Atoi(e[1])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(out[off:], fmt.Sprintf("%d 0 obj", i+1)))
+	}
+}
+
+func TestText_Wraps(t *testing.T) {
+	t.Parallel()
+
+	var d Document
+	d.Text(strings.Repeat("word ", 100))
+
+	require.Greater(t, len(d.lines), 1)
+	for _, l := range d.lines {
+		assert.LessOrEqual(t, len(l.text), lineWidth)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{in: "Night Drive", out: "Night Drive"},
+		{in: `a(b)c\`, out: `a\(b\)c\\`},
+		{in: "Ночной Дрифт", out: "Nochnoi Drift"},
+		{in: "Café ©", out: `Caf\351 \251`},
+		{in: "beat 🔥", out: "beat ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.out, encode(tt.in))
+		})
+	}
+}
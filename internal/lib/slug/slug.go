@@ -0,0 +1,67 @@
+// Package slug makes URL-safe identifiers from display names.
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu",
+	'я': "ia",
+}
+
+var symbols = map[rune]string{
+	'#': " sharp ",
+	'&': " and ",
+	'+': " plus ",
+}
+
+// Transliterate lowercases s and replaces cyrillic letters with latin ones.
+func Transliterate(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if t, ok := cyrillic[r]; ok {
+			b.WriteString(t)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Make returns the slug of s: transliterated lowercase latin letters and digits
+// separated by single dashes, e.g. "UK Garage" becomes "uk-garage" and "C#" "c-sharp".
+func Make(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range Transliterate(s) {
+		if t, ok := symbols[r]; ok {
+			for _, r := range t {
+				dash = write(&b, r, dash)
+			}
+			continue
+		}
+		dash = write(&b, r, dash)
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+func write(b *strings.Builder, r rune, dash bool) bool {
+	if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+		b.WriteRune(r)
+		return false
+	}
+	if !dash && b.Len() > 0 {
+		b.WriteByte('-')
+	}
+	return true
+}
+
+// Valid reports whether s is a slug as made by Make.
+func Valid(s string) bool {
+	return s != "" && Make(s) == s
+}
@@ -0,0 +1,44 @@
+package slug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "UK Garage", want: "uk-garage"},
+		{in: "Hip-hop", want: "hip-hop"},
+		{in: "Drum and Bass", want: "drum-and-bass"},
+		{in: "C#", want: "c-sharp"},
+		{in: "  Lo-Fi  ", want: "lo-fi"},
+		{in: "R&B", want: "r-and-b"},
+		{in: "качовый", want: "kachovyi"},
+		{in: "меланхоличный", want: "melankholichnyi"},
+		{in: "энергичный", want: "energichnyi"},
+		{in: "---", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Make(tt.in))
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Valid("uk-garage"))
+	assert.False(t, Valid("UK Garage"))
+	assert.False(t, Valid("uk--garage"))
+	assert.False(t, Valid(""))
+}
@@ -0,0 +1,162 @@
+package beat
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/agreement"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+// agreementsPath is the directory of the license agreements in the bucket of the beat media.
+const agreementsPath = "licenses/"
+
+type AgreementServiceConfig struct {
+	currency  string
+	publicURL string
+}
+
+func NewAgreementServiceConfig(currency, publicURL string) *AgreementServiceConfig {
+	return &AgreementServiceConfig{
+		currency:  currency,
+		publicURL: publicURL,
+	}
+}
+
+//go:generate mockery --name AgreementModifier
+type AgreementModifier interface {
+	SetLicenseAgreement(ctx context.Context, arg generated.SetLicenseAgreementParams) error
+}
+
+//go:generate mockery --name AgreementProvider
+type AgreementProvider interface {
+	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
+}
+
+//go:generate mockery --name AgreementRenderer
+type AgreementRenderer interface {
+	Render(w io.Writer, data agreement.Data) error
+}
+
+type AgreementService struct {
+	agreementModifier AgreementModifier
+	agreementProvider AgreementProvider
+	mediaUploader     MediaUploader
+	userProvider      UserProvider
+	renderer          AgreementRenderer
+	config            *AgreementServiceConfig
+	log               *slog.Logger
+}
+
+func NewAgreementService(
+	agreementModifier AgreementModifier,
+	agreementProvider AgreementProvider,
+	mediaUploader MediaUploader,
+	userProvider UserProvider,
+	renderer AgreementRenderer,
+	config *AgreementServiceConfig,
+	log *slog.Logger,
+) *AgreementService {
+	return &AgreementService{
+		agreementModifier: agreementModifier,
+		agreementProvider: agreementProvider,
+		mediaUploader:     mediaUploader,
+		userProvider:      userProvider,
+		renderer:          renderer,
+		config:            config,
+		log:               log,
+	}
+}
+
+// IssueAgreement renders the agreement of the license from the template of its tier,
+// uploads the PDF next to the beat media and returns its path. The agreement is rendered
+// once, later calls return the path of the first one. It is not rendered while the user
+// service cannot name the buyer or the beatmaker, the next call tries again.
+func (s *AgreementService) IssueAgreement(ctx context.Context, license generated.BeatLicense) (string, error) {
+	if license.AgreementPath != nil {
+		return *license.AgreementPath, nil
+	}
+
+	beat, err := s.agreementProvider.GetBeatByID(ctx, license.BeatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return "", err
+	}
+
+	users := s.userProvider.GetUsers(ctx, []uuid.UUID{license.UserID, beat.BeatmakerID})
+	for _, id := range []uuid.UUID{license.UserID, beat.BeatmakerID} {
+		if users[id] == nil {
+			err := fmt.Errorf("user %s not resolved", id)
+			s.log.Warn("agreement not rendered", slog.String("number", license.Number), sl.Err(err))
+			return "", err
+		}
+	}
+
+	data := agreement.Data{
+		Number:       license.Number,
+		VerifyURL:    model.LicenseVerificationURL(s.config.publicURL, license.Number),
+		Date:         license.CreatedAt.Time,
+		Buyer:        buyerName(users[license.UserID]),
+		Beatmaker:    displayName(users[beat.BeatmakerID]),
+		Beat:         beat.Name,
+		Tier:         string(license.Tier),
+		Price:        license.Price,
+		Currency:     s.config.currency,
+		Deliverables: license.Deliverables,
+	}
+	if license.StreamCap != nil {
+		data.StreamCap = *license.StreamCap
+	}
+	if license.DistributionCap != nil {
+		data.DistributionCap = *license.DistributionCap
+	}
+
+	var buf bytes.Buffer
+	if err := s.renderer.Render(&buf, data); err != nil {
+		s.log.Error("failed to render agreement", sl.Err(err))
+		return "", err
+	}
+
+	path := agreementsPath + license.Number + ".pdf"
+	if err := s.mediaUploader.UploadMedia(ctx, path, "application/pdf", &buf); err != nil {
+		s.log.Error("failed to upload agreement", sl.Err(err))
+		return "", err
+	}
+
+	if err := s.agreementModifier.SetLicenseAgreement(ctx, generated.SetLicenseAgreementParams{
+		BeatID:        license.BeatID,
+		UserID:        license.UserID,
+		Tier:          license.Tier,
+		AgreementPath: &path,
+	}); err != nil {
+		s.log.Error("failed to set license agreement", sl.Err(err))
+		return "", err
+	}
+
+	return path, nil
+}
+
+// buyerName is the full name of the buyer, or the username if they have not given it.
+func buyerName(user *userv1.GetUserResponse) string {
+	if name := strings.TrimSpace(user.GetFirstName() + " " + user.GetLastName()); name != "" {
+		return name
+	}
+	return user.GetUsername()
+}
+
+// displayName is the public name of the user: the pseudonym, or the username if they have
+// none. Unknown users have no public name.
+func displayName(user *userv1.GetUserResponse) string {
+	if user.GetPseudonym() != "" {
+		return user.GetPseudonym()
+	}
+	return user.GetUsername()
+}
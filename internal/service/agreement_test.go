@@ -0,0 +1,140 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/agreement"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type agreementDependencies struct {
+	agreementService  *AgreementService
+	agreementModifier *mocks.AgreementModifier
+	agreementProvider *mocks.AgreementProvider
+	mediaUploader     *mocks.MediaUploader
+	userProvider      *mocks.UserProvider
+	renderer          *mocks.AgreementRenderer
+}
+
+func createAgreementService(t *testing.T) agreementDependencies {
+	t.Helper()
+
+	agreementModifier := mocks.NewAgreementModifier(t)
+	agreementProvider := mocks.NewAgreementProvider(t)
+	mediaUploader := mocks.NewMediaUploader(t)
+	userProvider := mocks.NewUserProvider(t)
+	renderer := mocks.NewAgreementRenderer(t)
+
+	return agreementDependencies{
+		agreementService: NewAgreementService(agreementModifier, agreementProvider, mediaUploader, userProvider, renderer,
+			NewAgreementServiceConfig("RUB", "https://beatflow.example"), slogdiscard.NewDiscardLogger()),
+		agreementModifier: agreementModifier,
+		agreementProvider: agreementProvider,
+		mediaUploader:     mediaUploader,
+		userProvider:      userProvider,
+		renderer:          renderer,
+	}
+}
+
+func TestIssueAgreement_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createAgreementService(t)
+
+	streamCap := int32(100000)
+	license := generated.BeatLicense{
+		BeatID:       uuid.New(),
+		UserID:       uuid.New(),
+		Tier:         generated.LicenseTierMp3Lease,
+		Number:       "BF-0123456789AB",
+		Price:        2000,
+		Deliverables: []string{"file"},
+		StreamCap:    &streamCap,
+	}
+	beat := generated.Beat{ID: license.BeatID, BeatmakerID: uuid.New(), Name: "Night Drive"}
+	users := map[uuid.UUID]*userv1.GetUserResponse{
+		license.UserID:   {Username: "buyer", FirstName: "Ivan", LastName: "Petrov"},
+		beat.BeatmakerID: {Username: "maker", Pseudonym: "DJ Maker"},
+	}
+	path := "licenses/BF-0123456789AB.pdf"
+
+	s.agreementProvider.On("GetBeatByID", mock.Anything, license.BeatID).Return(&beat, nil).Once()
+	s.userProvider.On("GetUsers", mock.Anything, []uuid.UUID{license.UserID, beat.BeatmakerID}).Return(users).Once()
+	s.renderer.On("Render", mock.Anything, agreement.Data{
+		Number:       license.Number,
+		VerifyURL:    "https://beatflow.example/v1/licenses/verify?number=BF-0123456789AB",
+		Buyer:        "Ivan Petrov",
+		Beatmaker:    "DJ Maker",
+		Beat:         beat.Name,
+		Tier:         string(license.Tier),
+		Price:        2000,
+		Currency:     "RUB",
+		Deliverables: []string{"file"},
+		StreamCap:    streamCap,
+	}).Return(nil).Once()
+	s.mediaUploader.On("UploadMedia", mock.Anything, path, "application/pdf", mock.Anything).Return(nil).Once()
+	s.agreementModifier.On("SetLicenseAgreement", mock.Anything, generated.SetLicenseAgreementParams{
+		BeatID:        license.BeatID,
+		UserID:        license.UserID,
+		Tier:          license.Tier,
+		AgreementPath: &path,
+	}).Return(nil).Once()
+
+	res, err := s.agreementService.IssueAgreement(context.Background(), license)
+	require.NoError(t, err)
+	assert.Equal(t, path, res)
+}
+
+func TestIssueAgreement_SuccessIssued(t *testing.T) {
+	t.Parallel()
+
+	s := createAgreementService(t)
+
+	path := "licenses/BF-0123456789AB.pdf"
+	res, err := s.agreementService.IssueAgreement(context.Background(), generated.BeatLicense{AgreementPath: &path})
+	require.NoError(t, err)
+	assert.Equal(t, path, res)
+}
+
+func TestIssueAgreement_FailUpload(t *testing.T) {
+	t.Parallel()
+
+	s := createAgreementService(t)
+
+	expErr := errors.New("error")
+	s.agreementProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{}, nil).Once()
+	s.userProvider.On("GetUsers", mock.Anything, mock.Anything).Return(map[uuid.UUID]*userv1.GetUserResponse{uuid.Nil: {Username: "user"}}).Once()
+	s.renderer.On("Render", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mediaUploader.On("UploadMedia", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expErr).Once()
+
+	_, err := s.agreementService.IssueAgreement(context.Background(), generated.BeatLicense{Number: "BF-1"})
+	assert.ErrorIs(t, err, expErr)
+}
+
+func TestIssueAgreement_FailUserNotResolved(t *testing.T) {
+	t.Parallel()
+
+	s := createAgreementService(t)
+
+	license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Number: "BF-0123456789AB"}
+	beat := generated.Beat{ID: license.BeatID, BeatmakerID: uuid.New()}
+
+	// Without the name of the buyer nothing is rendered or stored, the next download
+	// renders the agreement again.
+	s.agreementProvider.On("GetBeatByID", mock.Anything, license.BeatID).Return(&beat, nil).Once()
+	s.userProvider.On("GetUsers", mock.Anything, mock.Anything).Return(map[uuid.UUID]*userv1.GetUserResponse{
+		beat.BeatmakerID: {Username: "maker"},
+	}).Once()
+
+	_, err := s.agreementService.IssueAgreement(context.Background(), license)
+	assert.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
@@ -40,15 +41,19 @@ type BeatModifier interface {
 	SaveBeat(ctx context.Context, beat model.SaveBeat) error
 	UpdateBeat(ctx context.Context, beat model.UpdateBeat) (*generated.Beat, error)
 	DeleteBeat(ctx context.Context, id uuid.UUID) error
-	SaveOwner(ctx context.Context, owner generated.SaveOwnerParams) error
+	SaveLicense(ctx context.Context, sale model.SaveLicense) (*generated.BeatLicense, bool, error)
+	SaveSignal(ctx context.Context, signal generated.SaveSignalParams) error
 }
 
 //go:generate mockery --name BeatProvider
 type BeatProvider interface {
 	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
 	GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
-	GetBeatParams(ctx context.Context) (attrs *model.BeatAttributes, err error)
-	GetOwnerByBeatID(ctx context.Context, beatID uuid.UUID) (*generated.BeatsOwner, error)
+	GetBeatFacets(ctx context.Context, params model.GetBeatsParams) (facets *model.Facets, err error)
+	GetBeatParams(ctx context.Context, locales []string) (attrs *model.BeatAttributes, err error)
+	GetBeatLicenses(ctx context.Context, beatID uuid.UUID) ([]generated.BeatLicense, error)
+	GetAcquisition(ctx context.Context, arg generated.GetAcquisitionParams) (*generated.BeatAcquisition, error)
+	GetLicenseTiers(ctx context.Context, beatID uuid.UUID) ([]generated.BeatsLicenseTier, error)
 }
 
 //go:generate mockery --name URLProvider
@@ -56,6 +61,16 @@ type URLProvider interface {
 	GetDownloadMediaURL(ctx context.Context, path string, expires time.Duration) (*string, error)
 }
 
+//go:generate mockery --name DownloadIssuer
+type DownloadIssuer interface {
+	IssueDownload(ctx context.Context, license generated.BeatLicense, beat *generated.Beat, client model.DownloadClient) (string, error)
+}
+
+//go:generate mockery --name AgreementIssuer
+type AgreementIssuer interface {
+	IssueAgreement(ctx context.Context, license generated.BeatLicense) (string, error)
+}
+
 //go:generate mockery --name BeatBytesProvider
 type BeatBytesProvider interface {
 	GetBeatBytes(ctx context.Context, path string, s, e *int) (file io.ReadCloser, size *int, contentType *string, err error)
@@ -72,6 +87,8 @@ type BeatService struct {
 	urlProvider       URLProvider
 	mediaUploader     MediaUploader
 	beatBytesProvider BeatBytesProvider
+	downloadIssuer    DownloadIssuer
+	agreementIssuer   AgreementIssuer
 	config            *BeatServiceConfig
 	log               *slog.Logger
 }
@@ -82,6 +99,8 @@ func NewBeatService(
 	urlProvider URLProvider,
 	mediaUploader MediaUploader,
 	beatBytesProvider BeatBytesProvider,
+	downloadIssuer DownloadIssuer,
+	agreementIssuer AgreementIssuer,
 	config *BeatServiceConfig,
 	log *slog.Logger,
 ) *BeatService {
@@ -91,6 +110,8 @@ func NewBeatService(
 		urlProvider:       urlProvider,
 		mediaUploader:     mediaUploader,
 		beatBytesProvider: beatBytesProvider,
+		downloadIssuer:    downloadIssuer,
+		agreementIssuer:   agreementIssuer,
 		config:            config,
 		log:               log,
 	}
@@ -117,6 +138,29 @@ func (s *BeatService) GetBeatStream(ctx context.Context, beatID uuid.UUID, start
 	return file, size, contentType, nil
 }
 
+// GetBeatImage returns the cover image of the beat. Unlike the download URLs of GetBeats
+// its address does not expire, so it can be linked from feeds.
+func (s *BeatService) GetBeatImage(ctx context.Context, beatID uuid.UUID) (file io.ReadCloser, size *int, contentType *string, err error) {
+	beat, err := s.beatProvider.GetBeatByID(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return nil, nil, nil, err
+	}
+
+	if beat.IsDeleted || !beat.IsImageDownloaded {
+		s.log.Debug("image is not downloaded")
+		return nil, nil, nil, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	file, size, contentType, err = s.beatBytesProvider.GetBeatBytes(ctx, beat.ImagePath, nil, nil)
+	if err != nil {
+		s.log.Error("failed to get beat image bytes", sl.Err(err))
+		return nil, nil, nil, err
+	}
+
+	return file, size, contentType, nil
+}
+
 func (s *BeatService) getSaveMediaURL(name string, mt model.MediaType, exp time.Time) string {
 	url := "/v1/beat?"
 
@@ -177,8 +221,18 @@ func (s *BeatService) GetBeats(ctx context.Context, params model.GetBeatsParams)
 	return beats, total, nil
 }
 
-func (s *BeatService) GetBeatParams(ctx context.Context) (attrs *model.BeatAttributes, err error) {
-	return s.beatProvider.GetBeatParams(ctx)
+func (s *BeatService) GetBeatFacets(ctx context.Context, params model.GetBeatsParams) (facets *model.Facets, err error) {
+	facets, err = s.beatProvider.GetBeatFacets(ctx, params)
+	if err != nil {
+		s.log.Error("failed to get beat facets", sl.Err(err))
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+func (s *BeatService) GetBeatParams(ctx context.Context, locales []string) (attrs *model.BeatAttributes, err error) {
+	return s.beatProvider.GetBeatParams(ctx, locales)
 }
 
 func (s *BeatService) UpdateBeat(ctx context.Context, updateBeat model.UpdateBeat) (*string, *string, *string, error) {
@@ -250,39 +304,189 @@ func (s *BeatService) UploadMedia(ctx context.Context, file io.Reader, m model.M
 	return nil
 }
 
-func (s *BeatService) GetBeatArchive(ctx context.Context, params generated.SaveOwnerParams) (*string, error) {
+// GetBeatArchive returns a download URL of the deliverable of the license of the user for
+// the tier of params, exclusive if none is given: the archive if the license includes it,
+// the file otherwise. A user without the license buys it first under the current terms
+// of the tier. Beats without tiers are only sold exclusively with the archive. Leases are
+// sold to many users, up to the sales cap of the tier, until someone buys the beat
+// exclusively. Download URLs are issued to the client of params within the download
+// limit of the license. The download URL of the license agreement comes with it, the
+// agreement is rendered on the first download.
+func (s *BeatService) GetBeatArchive(ctx context.Context, params model.AcquireBeat) (*model.BeatArchive, error) {
+	if params.Tier == "" {
+		params.Tier = generated.LicenseTierExclusive
+	}
+
+	if err := s.checkIdempotencyKey(ctx, params); err != nil {
+		return nil, err
+	}
+
 	beat, err := s.beatProvider.GetBeatByID(ctx, params.BeatID)
 	if err != nil {
 		s.log.Error("failed to get beat", sl.Err(err))
 		return nil, err
 	}
 
-	if !beat.IsArchiveDownloaded {
+	licenses, err := s.beatProvider.GetBeatLicenses(ctx, params.BeatID)
+	if err != nil {
+		s.log.Error("failed to get licenses", sl.Err(err))
+		return nil, err
+	}
+
+	var license *generated.BeatLicense
+	if i := slices.IndexFunc(licenses, func(l generated.BeatLicense) bool {
+		return l.UserID == params.UserID && l.Tier == params.Tier
+	}); i >= 0 {
+		license = &licenses[i]
+	} else if license, err = s.sellLicense(ctx, beat, licenses, params); err != nil {
+		return nil, err
+	}
+
+	url, err := s.downloadIssuer.IssueDownload(ctx, *license, beat, params.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.BeatArchive{ArchiveURL: url}
+
+	// A missing agreement must not block the download, it is rendered again next time.
+	ttl := time.Minute * time.Duration(s.config.urlTTL)
+	if agreementPath, err := s.agreementIssuer.IssueAgreement(ctx, *license); err == nil {
+		if res.AgreementURL, err = s.urlProvider.GetDownloadMediaURL(ctx, agreementPath, ttl); err != nil {
+			s.log.Error("failed to get agreement url", sl.Err(err))
+		}
+	}
+
+	return res, nil
+}
+
+// checkIdempotencyKey makes sure the idempotency key of params, if any, was not used by
+// the user for a purchase of another beat or tier. A retry of the same purchase passes.
+func (s *BeatService) checkIdempotencyKey(ctx context.Context, params model.AcquireBeat) error {
+	if params.IdempotencyKey == "" {
+		return nil
+	}
+
+	acquisition, err := s.beatProvider.GetAcquisition(ctx, generated.GetAcquisitionParams{
+		UserID:         params.UserID,
+		IdempotencyKey: params.IdempotencyKey,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrAcquisitionNotFound) {
+			return nil
+		}
+		s.log.Error("failed to get acquisition", sl.Err(err))
+		return err
+	}
+
+	if acquisition.BeatID != params.BeatID || acquisition.Tier != params.Tier {
+		return &model.ModelError{Err: model.ErrIdempotencyKeyUsed}
+	}
+
+	return nil
+}
+
+// sellLicense sells the tier of params to the user under the current terms of the tier
+// and returns the license. licenses are the licenses of the beat, they only fail the
+// sale early: the store checks them again with the beat locked. Revoked licenses are
+// left out.
+func (s *BeatService) sellLicense(ctx context.Context, beat *generated.Beat, licenses []generated.BeatLicense, params model.AcquireBeat) (*generated.BeatLicense, error) {
+	licenses = slices.DeleteFunc(slices.Clone(licenses), func(l generated.BeatLicense) bool { return l.RevokedAt.Valid })
+	for _, l := range licenses {
+		if !model.IsLease(l.Tier) {
+			s.log.Debug("beat acquired by another owner", slog.String("beat_id", params.BeatID.String()), slog.String("user_id", params.UserID.String()), slog.String("owner_id", l.UserID.String()))
+			return nil, model.NewErr(model.ErrInvalidOwner, "beat acquired by another owner")
+		}
+	}
+
+	// Deleted beats are off the catalog, only their licensees can download them.
+	if beat.IsDeleted {
+		return nil, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	tier, err := s.getLicenseTier(ctx, params.BeatID, params.Tier)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := deliverable(beat, tier.Deliverables); !ok {
 		s.log.Debug("archive not found")
 		return nil, &model.ModelError{Err: model.ErrArchiveNotFound}
 	}
 
-	owner, err := s.beatProvider.GetOwnerByBeatID(ctx, params.BeatID)
-	if err != nil && !errors.Is(err, model.ErrOwnerNotFound) {
-		s.log.Error("failed to get owner", sl.Err(err))
-		return nil, err
+	if tier.SalesCap != nil {
+		sold := 0
+		for _, l := range licenses {
+			if l.Tier == tier.Tier {
+				sold++
+			}
+		}
+		if sold >= int(*tier.SalesCap) {
+			return nil, model.NewErr(model.ErrLicenseSoldOut, string(tier.Tier))
+		}
 	}
 
-	if errors.Is(err, model.ErrOwnerNotFound) {
-		if err := s.beatModifier.SaveOwner(ctx, params); err != nil {
-			s.log.Error("failed to save owner", sl.Err(err))
-			return nil, err
+	license := generated.SaveLicenseParams{
+		BeatID:          params.BeatID,
+		UserID:          params.UserID,
+		Tier:            tier.Tier,
+		Price:           tier.Price,
+		Deliverables:    tier.Deliverables,
+		StreamCap:       tier.StreamCap,
+		DistributionCap: tier.DistributionCap,
+	}
+	res, created, err := s.beatModifier.SaveLicense(ctx, model.SaveLicense{
+		License:        license,
+		SalesCap:       tier.SalesCap,
+		IdempotencyKey: params.IdempotencyKey,
+	})
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to save license", sl.Err(err))
 		}
-	} else if params.UserID != owner.UserID {
-		s.log.Debug("beat acquired by another owner", slog.String("beat_id", params.BeatID.String()), slog.String("user_id", params.UserID.String()), slog.String("owner_id", owner.UserID.String()))
-		return nil, model.NewErr(model.ErrInvalidOwner, "beat acquired by another owner")
+		return nil, err
+	}
+	if created {
+		s.saveSignal(ctx, params.BeatID, generated.BeatSignalAcquisition)
 	}
 
-	url, err := s.urlProvider.GetDownloadMediaURL(ctx, beat.ArchivePath, time.Minute*time.Duration(s.config.urlTTL))
+	return res, nil
+}
+
+// getLicenseTier returns the tier of the beat.
+func (s *BeatService) getLicenseTier(ctx context.Context, beatID uuid.UUID, tier generated.LicenseTier) (*generated.BeatsLicenseTier, error) {
+	tiers, err := s.beatProvider.GetLicenseTiers(ctx, beatID)
 	if err != nil {
-		s.log.Error("failed to get download media url", sl.Err(err))
+		s.log.Error("failed to get license tiers", sl.Err(err))
 		return nil, err
 	}
 
-	return url, nil
+	if len(tiers) == 0 && tier == generated.LicenseTierExclusive {
+		return &generated.BeatsLicenseTier{BeatID: beatID, Tier: tier, Deliverables: []string{string(model.MediaTypeArchive)}}, nil
+	}
+
+	for _, t := range tiers {
+		if t.Tier == tier {
+			return &t, nil
+		}
+	}
+
+	return nil, model.NewErr(model.ErrLicenseTierNotFound, fmt.Sprintf("beat is not sold under %s license", tier))
+}
+
+// deliverable returns the path of the media the deliverables give, the archive if they
+// include it and the file otherwise, and whether it is uploaded.
+func deliverable(beat *generated.Beat, deliverables []string) (string, bool) {
+	if slices.Contains(deliverables, string(model.MediaTypeArchive)) {
+		return beat.ArchivePath, beat.IsArchiveDownloaded
+	}
+	return beat.FilePath, beat.IsFileDownloaded
+}
+
+// saveSignal records a trending signal of the beat. Failing to record it does not fail the request.
+func (s *BeatService) saveSignal(ctx context.Context, beatID uuid.UUID, kind generated.BeatSignal) {
+	if err := s.beatModifier.SaveSignal(ctx, generated.SaveSignalParams{BeatID: beatID, Kind: kind}); err != nil {
+		s.log.Error("failed to save signal", sl.Err(err))
+	}
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -27,6 +28,8 @@ type dependencies struct {
 	urlProvider       *mocks.URLProvider
 	mediaUploader     *mocks.MediaUploader
 	beatBytesProvider *mocks.BeatBytesProvider
+	downloadIssuer    *mocks.DownloadIssuer
+	agreementIssuer   *mocks.AgreementIssuer
 	config            *BeatServiceConfig
 }
 
@@ -38,6 +41,8 @@ func createService(t *testing.T) dependencies {
 	urlProvider := mocks.NewURLProvider(t)
 	mediaUploader := mocks.NewMediaUploader(t)
 	beatBytesProvider := mocks.NewBeatBytesProvider(t)
+	downloadIssuer := mocks.NewDownloadIssuer(t)
+	agreementIssuer := mocks.NewAgreementIssuer(t)
 	config := NewBeatServiceConfig(100, 200, 300, "secret", 100)
 
 	beatService := NewBeatService(
@@ -46,6 +51,8 @@ func createService(t *testing.T) dependencies {
 		urlProvider,
 		mediaUploader,
 		beatBytesProvider,
+		downloadIssuer,
+		agreementIssuer,
 		config,
 		slogdiscard.NewDiscardLogger(),
 	)
@@ -57,6 +64,8 @@ func createService(t *testing.T) dependencies {
 		urlProvider:       urlProvider,
 		mediaUploader:     mediaUploader,
 		beatBytesProvider: beatBytesProvider,
+		downloadIssuer:    downloadIssuer,
+		agreementIssuer:   agreementIssuer,
 		config:            config,
 	}
 }
@@ -307,6 +316,36 @@ func TestGetBeats_Fail(t *testing.T) {
 	assert.ErrorIs(t, expErr, err)
 }
 
+func TestGetBeatFacets_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	params := model.GetBeatsParams{Genre: []string{"Trap"}}
+	facets := &model.Facets{
+		Genres: []model.FacetCount{{Value: "Trap", Count: 2}, {Value: "Drill", Count: 1}},
+	}
+
+	s.beatProvider.On("GetBeatFacets", mock.Anything, params).Return(facets, nil).Once()
+
+	res, err := s.beatService.GetBeatFacets(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, facets, res)
+}
+
+func TestGetBeatFacets_Fail(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	expErr := model.NewErr(model.ErrInvalidFilter, "1:1: unsupported expression")
+
+	s.beatProvider.On("GetBeatFacets", mock.Anything, mock.Anything).Return(nil, expErr).Once()
+
+	_, err := s.beatService.GetBeatFacets(context.Background(), model.GetBeatsParams{})
+	assert.ErrorIs(t, err, model.ErrInvalidFilter)
+}
+
 func TestGetBeatStream_Success(t *testing.T) {
 	t.Parallel()
 
@@ -353,6 +392,45 @@ func TestGetBeatStream_FailFileNotDownloaded(t *testing.T) {
 	assert.ErrorIs(t, err, model.ErrBeatNotFound)
 }
 
+func TestGetBeatImage_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	beatID := uuid.New()
+	beat := generated.Beat{
+		ID:                beatID,
+		ImagePath:         uuid.NewString(),
+		IsImageDownloaded: true,
+	}
+	size := 100
+	imageType := "image/png"
+	file := io.NopCloser(strings.NewReader("content"))
+
+	s.beatProvider.On("GetBeatByID", mock.Anything, beatID).Return(&beat, nil).Once()
+	s.beatBytesProvider.On("GetBeatBytes", mock.Anything, beat.ImagePath, (*int)(nil), (*int)(nil)).Return(file, &size, &imageType, nil).Once()
+
+	resFile, _, resContentType, err := s.beatService.GetBeatImage(context.Background(), beatID)
+	require.NoError(t, err)
+	assert.Equal(t, file, resFile)
+	if assert.NotNil(t, resContentType) {
+		assert.Equal(t, imageType, *resContentType)
+	}
+}
+
+func TestGetBeatImage_FailDeleted(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	beat := generated.Beat{IsImageDownloaded: true, IsDeleted: true}
+
+	s.beatProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&beat, nil).Once()
+
+	_, _, _, err := s.beatService.GetBeatImage(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, model.ErrBeatNotFound)
+}
+
 func TestGetBeatStream_Fail(t *testing.T) {
 	t.Parallel()
 
@@ -395,24 +473,55 @@ func TestGetBeatArchive_Success(t *testing.T) {
 
 	ctx := context.Background()
 	url := "url"
-	params := generated.SaveOwnerParams{
+	params := model.AcquireBeat{
 		BeatID: uuid.New(),
 		UserID: uuid.New(),
+		Tier:   generated.LicenseTierExclusive,
 	}
-	owner := generated.BeatsOwner(params)
+	license := generated.BeatLicense{BeatID: params.BeatID, UserID: params.UserID, Tier: params.Tier, Deliverables: []string{"archive"}}
 	beat := generated.Beat{
 		ID:                  params.BeatID,
 		ArchivePath:         uuid.NewString(),
 		IsArchiveDownloaded: true,
+		IsDeleted:           true,
 	}
 
 	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&beat, nil).Once()
-	s.beatProvider.On("GetOwnerByBeatID", mock.Anything, params.BeatID).Return(&owner, nil).Once()
-	s.urlProvider.On("GetDownloadMediaURL", mock.Anything, beat.ArchivePath, time.Minute*time.Duration(s.config.urlTTL)).Return(&url, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return([]generated.BeatLicense{license}, nil).Once()
+	s.downloadIssuer.On("IssueDownload", mock.Anything, license, &beat, params.Client).Return(url, nil).Once()
+	agreementPath, agreementURL := "licenses/BF-1.pdf", "agreement url"
+	s.agreementIssuer.On("IssueAgreement", mock.Anything, license).Return(agreementPath, nil).Once()
+	s.urlProvider.On("GetDownloadMediaURL", mock.Anything, agreementPath, time.Minute*time.Duration(s.config.urlTTL)).Return(&agreementURL, nil).Once()
 
 	res, err := s.beatService.GetBeatArchive(ctx, params)
 	require.NoError(t, err)
-	assert.Equal(t, url, *res)
+	assert.Equal(t, url, res.ArchiveURL)
+	assert.Equal(t, agreementURL, *res.AgreementURL)
+}
+
+func TestGetBeatArchive_SuccessLicenseTerms(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	// The license was sold with the file only, the tier gives the archive now.
+	params := model.AcquireBeat{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierWavLease}
+	license := generated.BeatLicense{BeatID: params.BeatID, UserID: params.UserID, Tier: params.Tier, Deliverables: []string{"file"}}
+	beat := generated.Beat{
+		ID:                  params.BeatID,
+		FilePath:            uuid.NewString(),
+		IsFileDownloaded:    true,
+		IsArchiveDownloaded: true,
+	}
+
+	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&beat, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return([]generated.BeatLicense{license}, nil).Once()
+	s.downloadIssuer.On("IssueDownload", mock.Anything, license, &beat, params.Client).Return(beat.FilePath, nil).Once()
+	s.agreementIssuer.On("IssueAgreement", mock.Anything, mock.Anything).Return("", errors.New("error")).Once()
+
+	res, err := s.beatService.GetBeatArchive(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, beat.FilePath, res.ArchiveURL)
 }
 
 func TestGetBeatArchive_FailArchiveNotFound(t *testing.T) {
@@ -420,8 +529,10 @@ func TestGetBeatArchive_FailArchiveNotFound(t *testing.T) {
 
 	s := createService(t)
 	s.beatProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{}, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	s.beatProvider.On("GetLicenseTiers", mock.Anything, mock.Anything).Return(nil, nil).Once()
 
-	_, err := s.beatService.GetBeatArchive(context.Background(), generated.SaveOwnerParams{})
+	_, err := s.beatService.GetBeatArchive(context.Background(), model.AcquireBeat{})
 	assert.ErrorIs(t, err, model.ErrArchiveNotFound)
 }
 
@@ -431,42 +542,213 @@ func TestGetBeatArchive_FailInvalidOwner(t *testing.T) {
 	s := createService(t)
 
 	ctx := context.Background()
-	params := generated.SaveOwnerParams{
+	params := model.AcquireBeat{
 		BeatID: uuid.New(),
 		UserID: uuid.New(),
+		Tier:   generated.LicenseTierMp3Lease,
 	}
-	owner := generated.BeatsOwner{
+	license := generated.BeatLicense{
 		BeatID: params.BeatID,
 		UserID: uuid.New(),
+		Tier:   generated.LicenseTierExclusive,
 	}
 
 	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&generated.Beat{IsArchiveDownloaded: true}, nil).Once()
-	s.beatProvider.On("GetOwnerByBeatID", mock.Anything, params.BeatID).Return(&owner, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return([]generated.BeatLicense{license}, nil).Once()
 
 	_, err := s.beatService.GetBeatArchive(ctx, params)
 	assert.ErrorIs(t, err, model.ErrInvalidOwner)
 }
 
+func TestGetBeatArchive_SuccessRevokedOwner(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	params := model.AcquireBeat{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierMp3Lease}
+	beat := generated.Beat{ID: params.BeatID, FilePath: uuid.NewString(), IsFileDownloaded: true}
+	tiers := []generated.BeatsLicenseTier{{BeatID: params.BeatID, Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}}
+	// The exclusive sale was refunded: the beat is for sale again.
+	licenses := []generated.BeatLicense{{BeatID: params.BeatID, UserID: uuid.New(), Tier: generated.LicenseTierExclusive,
+		RevokedAt: pgtype.Timestamp{Time: time.Now(), Valid: true}}}
+
+	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&beat, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(licenses, nil).Once()
+	s.beatProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(tiers, nil).Once()
+	s.beatModifier.On("SaveLicense", mock.Anything, mock.Anything).Return(&generated.BeatLicense{Deliverables: []string{"file"}}, true, nil).Once()
+	s.beatModifier.On("SaveSignal", mock.Anything, mock.Anything).Return(nil).Once()
+	s.downloadIssuer.On("IssueDownload", mock.Anything, mock.Anything, &beat, params.Client).Return(beat.FilePath, nil).Once()
+	s.agreementIssuer.On("IssueAgreement", mock.Anything, mock.Anything).Return("", errors.New("error")).Once()
+
+	res, err := s.beatService.GetBeatArchive(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, beat.FilePath, res.ArchiveURL)
+}
+
 func TestGetBeatArchive_SuccessOwnerNotFound(t *testing.T) {
 	t.Parallel()
 
 	s := createService(t)
 
 	ctx := context.Background()
-	params := generated.SaveOwnerParams{
+	params := model.AcquireBeat{
 		BeatID: uuid.New(),
 		UserID: uuid.New(),
 	}
 
 	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&generated.Beat{IsArchiveDownloaded: true}, nil).Once()
-	s.beatProvider.On("GetOwnerByBeatID", mock.Anything, params.BeatID).Return(nil, model.ErrOwnerNotFound).Once()
-	s.beatModifier.On("SaveOwner", mock.Anything, params).Return(nil).Once()
-	s.urlProvider.On("GetDownloadMediaURL", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(nil, nil).Once()
+	s.beatProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(nil, nil).Once()
+	license := generated.SaveLicenseParams{
+		BeatID:       params.BeatID,
+		UserID:       params.UserID,
+		Tier:         generated.LicenseTierExclusive,
+		Deliverables: []string{"archive"},
+	}
+	s.beatModifier.On("SaveLicense", mock.Anything, model.SaveLicense{License: license}).
+		Return(&generated.BeatLicense{Deliverables: license.Deliverables}, true, nil).Once()
+	s.beatModifier.On("SaveSignal", mock.Anything, generated.SaveSignalParams{BeatID: params.BeatID, Kind: generated.BeatSignalAcquisition}).Return(nil).Once()
+	s.downloadIssuer.On("IssueDownload", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("url", nil).Once()
+	s.agreementIssuer.On("IssueAgreement", mock.Anything, mock.Anything).Return("", errors.New("error")).Once()
 
 	_, err := s.beatService.GetBeatArchive(ctx, params)
 	assert.NoError(t, err)
 }
 
+func TestGetBeatArchive_SuccessLease(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	ctx := context.Background()
+	params := model.AcquireBeat{
+		BeatID: uuid.New(),
+		UserID: uuid.New(),
+		Tier:   generated.LicenseTierMp3Lease,
+	}
+	beat := generated.Beat{
+		ID:                  params.BeatID,
+		FilePath:            uuid.NewString(),
+		IsFileDownloaded:    true,
+		IsArchiveDownloaded: true,
+	}
+	salesCap := int32(2)
+	tiers := []generated.BeatsLicenseTier{
+		{BeatID: params.BeatID, Tier: generated.LicenseTierMp3Lease, Price: 2000, Deliverables: []string{"file"}, SalesCap: &salesCap},
+		{BeatID: params.BeatID, Tier: generated.LicenseTierExclusive, Deliverables: []string{"file", "archive"}},
+	}
+	// Another user leasing the beat does not end lease sales.
+	licenses := []generated.BeatLicense{{BeatID: params.BeatID, UserID: uuid.New(), Tier: generated.LicenseTierMp3Lease}}
+
+	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&beat, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(licenses, nil).Once()
+	s.beatProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(tiers, nil).Once()
+	license := generated.SaveLicenseParams{
+		BeatID:       params.BeatID,
+		UserID:       params.UserID,
+		Tier:         generated.LicenseTierMp3Lease,
+		Price:        2000,
+		Deliverables: []string{"file"},
+	}
+	s.beatModifier.On("SaveLicense", mock.Anything, model.SaveLicense{License: license, SalesCap: &salesCap}).
+		Return(&generated.BeatLicense{Deliverables: license.Deliverables}, true, nil).Once()
+	s.beatModifier.On("SaveSignal", mock.Anything, mock.Anything).Return(nil).Once()
+	s.downloadIssuer.On("IssueDownload", mock.Anything, mock.Anything, &beat, params.Client).Return(beat.FilePath, nil).Once()
+	s.agreementIssuer.On("IssueAgreement", mock.Anything, mock.Anything).Return("", errors.New("error")).Once()
+
+	res, err := s.beatService.GetBeatArchive(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, beat.FilePath, res.ArchiveURL)
+	assert.Nil(t, res.AgreementURL)
+}
+
+func TestGetBeatArchive_FailSoldOut(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	params := model.AcquireBeat{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierWavLease}
+	salesCap := int32(1)
+	tiers := []generated.BeatsLicenseTier{{BeatID: params.BeatID, Tier: generated.LicenseTierWavLease, Deliverables: []string{"file"}, SalesCap: &salesCap}}
+	licenses := []generated.BeatLicense{{BeatID: params.BeatID, UserID: uuid.New(), Tier: generated.LicenseTierWavLease}}
+
+	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&generated.Beat{IsFileDownloaded: true}, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(licenses, nil).Once()
+	s.beatProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(tiers, nil).Once()
+
+	_, err := s.beatService.GetBeatArchive(context.Background(), params)
+	assert.ErrorIs(t, err, model.ErrLicenseSoldOut)
+}
+
+func TestGetBeatArchive_FailLicenseTierNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	params := model.AcquireBeat{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierTrackout}
+	tiers := []generated.BeatsLicenseTier{{BeatID: params.BeatID, Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}}
+
+	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&generated.Beat{IsArchiveDownloaded: true}, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(nil, nil).Once()
+	s.beatProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(tiers, nil).Once()
+
+	_, err := s.beatService.GetBeatArchive(context.Background(), params)
+	assert.ErrorIs(t, err, model.ErrLicenseTierNotFound)
+}
+
+func TestGetBeatArchive_FailDeleted(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	params := model.AcquireBeat{BeatID: uuid.New(), UserID: uuid.New()}
+
+	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&generated.Beat{IsArchiveDownloaded: true, IsDeleted: true}, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(nil, nil).Once()
+
+	_, err := s.beatService.GetBeatArchive(context.Background(), params)
+	assert.ErrorIs(t, err, model.ErrBeatNotFound)
+}
+
+func TestGetBeatArchive_SuccessRetry(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	params := model.AcquireBeat{BeatID: uuid.New(), UserID: uuid.New(), IdempotencyKey: uuid.NewString()}
+	beat := generated.Beat{ID: params.BeatID, ArchivePath: uuid.NewString(), IsArchiveDownloaded: true}
+
+	// A concurrent retry sold the license first: the store returns it and no signal is saved.
+	s.beatProvider.On("GetAcquisition", mock.Anything, generated.GetAcquisitionParams{UserID: params.UserID, IdempotencyKey: params.IdempotencyKey}).
+		Return(nil, &model.ModelError{Err: model.ErrAcquisitionNotFound}).Once()
+	s.beatProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&beat, nil).Once()
+	s.beatProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(nil, nil).Once()
+	s.beatProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(nil, nil).Once()
+	s.beatModifier.On("SaveLicense", mock.Anything, mock.MatchedBy(func(sale model.SaveLicense) bool {
+		return sale.IdempotencyKey == params.IdempotencyKey
+	})).Return(&generated.BeatLicense{Deliverables: []string{"archive"}}, false, nil).Once()
+	s.downloadIssuer.On("IssueDownload", mock.Anything, mock.Anything, &beat, params.Client).Return(beat.ArchivePath, nil).Once()
+	s.agreementIssuer.On("IssueAgreement", mock.Anything, mock.Anything).Return("", errors.New("error")).Once()
+
+	res, err := s.beatService.GetBeatArchive(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, beat.ArchivePath, res.ArchiveURL)
+}
+
+func TestGetBeatArchive_FailIdempotencyKeyUsed(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	params := model.AcquireBeat{BeatID: uuid.New(), UserID: uuid.New(), IdempotencyKey: uuid.NewString()}
+
+	s.beatProvider.On("GetAcquisition", mock.Anything, mock.Anything).
+		Return(&generated.BeatAcquisition{UserID: params.UserID, BeatID: uuid.New(), Tier: generated.LicenseTierExclusive}, nil).Once()
+
+	_, err := s.beatService.GetBeatArchive(context.Background(), params)
+	assert.ErrorIs(t, err, model.ErrIdempotencyKeyUsed)
+}
+
 func TestGetBeatArchive_Fail(t *testing.T) {
 	t.Parallel()
 
@@ -485,26 +767,35 @@ func TestGetBeatArchive_Fail(t *testing.T) {
 			},
 		},
 		{
-			name: "get owner by beat id error",
+			name: "get licenses error",
+			beh: func() {
+				s.beatProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{IsArchiveDownloaded: true}, nil).Once()
+				s.beatProvider.On("GetBeatLicenses", mock.Anything, mock.Anything).Return(nil, expErr).Once()
+			},
+		},
+		{
+			name: "get license tiers error",
 			beh: func() {
 				s.beatProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{IsArchiveDownloaded: true}, nil).Once()
-				s.beatProvider.On("GetOwnerByBeatID", mock.Anything, mock.Anything).Return(nil, expErr).Once()
+				s.beatProvider.On("GetBeatLicenses", mock.Anything, mock.Anything).Return(nil, nil).Once()
+				s.beatProvider.On("GetLicenseTiers", mock.Anything, mock.Anything).Return(nil, expErr).Once()
 			},
 		},
 		{
-			name: "save owner error",
+			name: "save license error",
 			beh: func() {
 				s.beatProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{IsArchiveDownloaded: true}, nil).Once()
-				s.beatProvider.On("GetOwnerByBeatID", mock.Anything, mock.Anything).Return(nil, model.ErrOwnerNotFound).Once()
-				s.beatModifier.On("SaveOwner", mock.Anything, mock.Anything).Return(expErr).Once()
+				s.beatProvider.On("GetBeatLicenses", mock.Anything, mock.Anything).Return(nil, nil).Once()
+				s.beatProvider.On("GetLicenseTiers", mock.Anything, mock.Anything).Return(nil, nil).Once()
+				s.beatModifier.On("SaveLicense", mock.Anything, mock.Anything).Return(nil, false, expErr).Once()
 			},
 		},
 		{
-			name: "get download media url error",
+			name: "issue download error",
 			beh: func() {
 				s.beatProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{IsArchiveDownloaded: true}, nil).Once()
-				s.beatProvider.On("GetOwnerByBeatID", mock.Anything, mock.Anything).Return(&generated.BeatsOwner{}, nil).Once()
-				s.urlProvider.On("GetDownloadMediaURL", mock.Anything, mock.Anything, mock.Anything).Return(nil, expErr).Once()
+				s.beatProvider.On("GetBeatLicenses", mock.Anything, mock.Anything).Return([]generated.BeatLicense{{Tier: generated.LicenseTierExclusive, Deliverables: []string{"archive"}}}, nil).Once()
+				s.downloadIssuer.On("IssueDownload", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", expErr).Once()
 			},
 		},
 	}
@@ -513,7 +804,7 @@ func TestGetBeatArchive_Fail(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.beh()
 
-			_, err := s.beatService.GetBeatArchive(context.Background(), generated.SaveOwnerParams{})
+			_, err := s.beatService.GetBeatArchive(context.Background(), model.AcquireBeat{})
 			assert.ErrorIs(t, err, expErr)
 		})
 	}
@@ -0,0 +1,143 @@
+package beat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type BeatmakerServiceConfig struct {
+	latestBeats uint64
+	maxPinned   int
+}
+
+func NewBeatmakerServiceConfig(latestBeats uint64, maxPinned int) *BeatmakerServiceConfig {
+	return &BeatmakerServiceConfig{
+		latestBeats: latestBeats,
+		maxPinned:   maxPinned,
+	}
+}
+
+//go:generate mockery --name BeatmakerModifier
+type BeatmakerModifier interface {
+	SetPinnedBeats(ctx context.Context, beatmakerID uuid.UUID, beatIDs []uuid.UUID) error
+}
+
+//go:generate mockery --name BeatmakerProvider
+type BeatmakerProvider interface {
+	GetBeatmakerStats(ctx context.Context, beatmakerID uuid.UUID) (generated.GetBeatmakerStatsRow, error)
+	GetBeatmakerGenres(ctx context.Context, beatmakerID uuid.UUID, locales []string) ([]model.FacetCount, error)
+	GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
+}
+
+//go:generate mockery --name ProfileProvider
+type ProfileProvider interface {
+	GetUser(ctx context.Context, id uuid.UUID) (*userv1.GetUserResponse, error)
+}
+
+type BeatmakerService struct {
+	beatmakerModifier BeatmakerModifier
+	beatmakerProvider BeatmakerProvider
+	profileProvider   ProfileProvider
+	config            *BeatmakerServiceConfig
+	log               *slog.Logger
+}
+
+func NewBeatmakerService(
+	beatmakerModifier BeatmakerModifier,
+	beatmakerProvider BeatmakerProvider,
+	profileProvider ProfileProvider,
+	config *BeatmakerServiceConfig,
+	log *slog.Logger,
+) *BeatmakerService {
+	return &BeatmakerService{
+		beatmakerModifier: beatmakerModifier,
+		beatmakerProvider: beatmakerProvider,
+		profileProvider:   profileProvider,
+		config:            config,
+		log:               log,
+	}
+}
+
+// GetBeatmaker returns the storefront of the beatmaker: the profile from the user service,
+// the statistics and genres of the beatmaker, the latest published beats and the beats
+// the beatmaker pinned. A beatmaker unknown to the user service is not found, a user
+// service that can not be reached leaves the profile empty.
+func (s *BeatmakerService) GetBeatmaker(ctx context.Context, beatmakerID uuid.UUID, locales []string) (*model.Storefront, error) {
+	var res model.Storefront
+
+	profile, err := s.profileProvider.GetUser(ctx, beatmakerID)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, &model.ModelError{Err: model.ErrBeatmakerNotFound}
+		}
+		s.log.Warn("failed to get beatmaker profile", sl.Err(err))
+	}
+	res.Profile = profile
+
+	if res.Stats, err = s.beatmakerProvider.GetBeatmakerStats(ctx, beatmakerID); err != nil {
+		s.log.Error("failed to get beatmaker stats", sl.Err(err))
+		return nil, err
+	}
+
+	if res.Genres, err = s.beatmakerProvider.GetBeatmakerGenres(ctx, beatmakerID, locales); err != nil {
+		s.log.Error("failed to get beatmaker genres", sl.Err(err))
+		return nil, err
+	}
+
+	published := true
+	if res.Latest, _, err = s.beatmakerProvider.GetBeats(ctx, model.GetBeatsParams{
+		BeatmakerID:  &beatmakerID,
+		IsDownloaded: &published,
+		OrderBy:      &model.OrderBy{Field: "created_at", Order: "desc"},
+		Limit:        s.config.latestBeats,
+		Locales:      locales,
+	}); err != nil {
+		s.log.Error("failed to get latest beats", sl.Err(err))
+		return nil, err
+	}
+
+	if res.Pinned, _, err = s.beatmakerProvider.GetBeats(ctx, model.GetBeatsParams{
+		PinnedBy:     &beatmakerID,
+		BeatmakerID:  &beatmakerID,
+		IsDownloaded: &published,
+		Limit:        uint64(s.config.maxPinned),
+		Locales:      locales,
+	}); err != nil {
+		s.log.Error("failed to get pinned beats", sl.Err(err))
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SetPinnedBeats replaces the beats featured on the storefront of the beatmaker with
+// beatIDs in their order. An empty list unpins all beats.
+func (s *BeatmakerService) SetPinnedBeats(ctx context.Context, beatmakerID uuid.UUID, beatIDs []uuid.UUID) error {
+	if len(beatIDs) > s.config.maxPinned {
+		return model.NewErr(model.ErrValidationFailed, fmt.Sprintf("at most %d beats can be pinned", s.config.maxPinned))
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(beatIDs))
+	for _, id := range beatIDs {
+		if _, ok := seen[id]; ok {
+			return model.NewErr(model.ErrValidationFailed, "beat ids must be unique")
+		}
+		seen[id] = struct{}{}
+	}
+
+	if err := s.beatmakerModifier.SetPinnedBeats(ctx, beatmakerID, beatIDs); err != nil {
+		s.log.Error("failed to set pinned beats", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package beat
+
+import (
+	"context"
+	"testing"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type beatmakerDependencies struct {
+	beatmakerService  *BeatmakerService
+	beatmakerModifier *mocks.BeatmakerModifier
+	beatmakerProvider *mocks.BeatmakerProvider
+	profileProvider   *mocks.ProfileProvider
+}
+
+func createBeatmakerService(t *testing.T) beatmakerDependencies {
+	t.Helper()
+
+	beatmakerModifier := mocks.NewBeatmakerModifier(t)
+	beatmakerProvider := mocks.NewBeatmakerProvider(t)
+	profileProvider := mocks.NewProfileProvider(t)
+
+	return beatmakerDependencies{
+		beatmakerService:  NewBeatmakerService(beatmakerModifier, beatmakerProvider, profileProvider, NewBeatmakerServiceConfig(10, 3), slogdiscard.NewDiscardLogger()),
+		beatmakerModifier: beatmakerModifier,
+		beatmakerProvider: beatmakerProvider,
+		profileProvider:   profileProvider,
+	}
+}
+
+func TestGetBeatmaker_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createBeatmakerService(t)
+
+	id := uuid.New()
+	profile := &userv1.GetUserResponse{UserId: id.String(), Username: "lilbeat", Pseudonym: "Lil Beat"}
+	stats := generated.GetBeatmakerStatsRow{Beats: 12, Plays: 340, Sales: 5}
+	genres := []model.FacetCount{{Value: "Phonk", Label: "Фонк", Count: 7}}
+	latest := []model.Beat{{ID: uuid.New(), BeatmakerID: id}}
+	pinned := []model.Beat{{ID: uuid.New(), BeatmakerID: id}}
+	locales := []string{"ru", "en"}
+
+	s.profileProvider.On("GetUser", mock.Anything, id).Return(profile, nil).Once()
+	s.beatmakerProvider.On("GetBeatmakerStats", mock.Anything, id).Return(stats, nil).Once()
+	s.beatmakerProvider.On("GetBeatmakerGenres", mock.Anything, id, locales).Return(genres, nil).Once()
+	s.beatmakerProvider.On("GetBeats", mock.Anything, mock.MatchedBy(func(p model.GetBeatsParams) bool {
+		return p.PinnedBy == nil && *p.BeatmakerID == id && *p.IsDownloaded && p.OrderBy.Field == "created_at" && p.Limit == 10
+	})).Return(latest, nil, nil).Once()
+	s.beatmakerProvider.On("GetBeats", mock.Anything, mock.MatchedBy(func(p model.GetBeatsParams) bool {
+		return p.PinnedBy != nil && *p.PinnedBy == id && *p.IsDownloaded && p.Limit == 3
+	})).Return(pinned, nil, nil).Once()
+
+	res, err := s.beatmakerService.GetBeatmaker(context.Background(), id, locales)
+	require.NoError(t, err)
+	assert.Equal(t, &model.Storefront{Profile: profile, Stats: stats, Genres: genres, Latest: latest, Pinned: pinned}, res)
+}
+
+func TestGetBeatmaker_FailNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := createBeatmakerService(t)
+
+	id := uuid.New()
+
+	s.profileProvider.On("GetUser", mock.Anything, id).Return(nil, status.Error(codes.NotFound, "user not found")).Once()
+
+	_, err := s.beatmakerService.GetBeatmaker(context.Background(), id, nil)
+	assert.ErrorIs(t, err, model.ErrBeatmakerNotFound)
+}
+
+func TestGetBeatmaker_SuccessWithoutProfile(t *testing.T) {
+	t.Parallel()
+
+	s := createBeatmakerService(t)
+
+	id := uuid.New()
+
+	s.profileProvider.On("GetUser", mock.Anything, id).Return(nil, status.Error(codes.Unavailable, "connection refused")).Once()
+	s.beatmakerProvider.On("GetBeatmakerStats", mock.Anything, id).Return(generated.GetBeatmakerStatsRow{}, nil).Once()
+	s.beatmakerProvider.On("GetBeatmakerGenres", mock.Anything, id, []string(nil)).Return(nil, nil).Once()
+	s.beatmakerProvider.On("GetBeats", mock.Anything, mock.Anything).Return(nil, nil, nil).Twice()
+
+	res, err := s.beatmakerService.GetBeatmaker(context.Background(), id, nil)
+	require.NoError(t, err)
+	assert.Nil(t, res.Profile)
+}
+
+func TestSetPinnedBeats_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createBeatmakerService(t)
+
+	id := uuid.New()
+	beatIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	s.beatmakerModifier.On("SetPinnedBeats", mock.Anything, id, beatIDs).Return(nil).Once()
+
+	err := s.beatmakerService.SetPinnedBeats(context.Background(), id, beatIDs)
+	require.NoError(t, err)
+}
+
+func TestSetPinnedBeats_FailValidation(t *testing.T) {
+	dup := uuid.New()
+
+	tests := []struct {
+		name    string
+		beatIDs []uuid.UUID
+	}{
+		{name: "too many", beatIDs: []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}},
+		{name: "duplicate", beatIDs: []uuid.UUID{dup, dup}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createBeatmakerService(t)
+
+			err := s.beatmakerService.SetPinnedBeats(context.Background(), uuid.New(), tt.beatIDs)
+			assert.ErrorIs(t, err, model.ErrValidationFailed)
+		})
+	}
+}
+
+func TestSetPinnedBeats_FailNotOwner(t *testing.T) {
+	t.Parallel()
+
+	s := createBeatmakerService(t)
+
+	id := uuid.New()
+	beatIDs := []uuid.UUID{uuid.New()}
+
+	s.beatmakerModifier.On("SetPinnedBeats", mock.Anything, id, beatIDs).
+		Return(model.NewErr(model.ErrNotBeatOwner, "only your own beats can be pinned")).Once()
+
+	err := s.beatmakerService.SetPinnedBeats(context.Background(), id, beatIDs)
+	assert.ErrorIs(t, err, model.ErrNotBeatOwner)
+}
@@ -0,0 +1,184 @@
+package beat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type CollaboratorServiceConfig struct {
+	currency string
+}
+
+func NewCollaboratorServiceConfig(currency string) *CollaboratorServiceConfig {
+	return &CollaboratorServiceConfig{
+		currency: currency,
+	}
+}
+
+//go:generate mockery --name CollaboratorModifier
+type CollaboratorModifier interface {
+	SetBeatCollaborators(ctx context.Context, beatID uuid.UUID, collaborators []generated.SaveBeatCollaboratorParams) ([]generated.BeatsCollaborator, error)
+}
+
+//go:generate mockery --name CollaboratorProvider
+type CollaboratorProvider interface {
+	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
+	GetBeatCollaborators(ctx context.Context, beatID uuid.UUID) ([]generated.BeatsCollaborator, error)
+	GetCollaboratorEarnings(ctx context.Context, collaboratorID uuid.UUID) ([]generated.GetCollaboratorEarningsRow, error)
+}
+
+type CollaboratorService struct {
+	collaboratorModifier CollaboratorModifier
+	collaboratorProvider CollaboratorProvider
+	config               *CollaboratorServiceConfig
+	log                  *slog.Logger
+}
+
+func NewCollaboratorService(
+	collaboratorModifier CollaboratorModifier,
+	collaboratorProvider CollaboratorProvider,
+	config *CollaboratorServiceConfig,
+	log *slog.Logger,
+) *CollaboratorService {
+	return &CollaboratorService{
+		collaboratorModifier: collaboratorModifier,
+		collaboratorProvider: collaboratorProvider,
+		config:               config,
+		log:                  log,
+	}
+}
+
+// GetBeatCollaborators returns the collaborators of the beat, the biggest share first.
+// A beat without collaborators has none, its beatmaker earns everything.
+func (s *CollaboratorService) GetBeatCollaborators(ctx context.Context, beatID uuid.UUID) ([]model.Collaborator, error) {
+	if _, err := s.getBeat(ctx, beatID); err != nil {
+		return nil, err
+	}
+
+	collaborators, err := s.collaboratorProvider.GetBeatCollaborators(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get beat collaborators", sl.Err(err))
+		return nil, err
+	}
+
+	return toCollaborators(collaborators), nil
+}
+
+// SetBeatCollaborators replaces the collaborators of the beat. Their shares must add up
+// to 100, an empty list leaves the whole revenue to the beatmaker. Only the beatmaker of
+// the beat or an admin can set them. Licenses already sold keep the split they were sold
+// under.
+func (s *CollaboratorService) SetBeatCollaborators(ctx context.Context, userID uuid.UUID, isAdmin bool, beatID uuid.UUID, collaborators []model.Collaborator) ([]model.Collaborator, error) {
+	beat, err := s.getBeat(ctx, beatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin && beat.BeatmakerID != userID {
+		return nil, &model.ModelError{Err: model.ErrNotBeatOwner}
+	}
+
+	params := make([]generated.SaveBeatCollaboratorParams, 0, len(collaborators))
+	seen := make(map[uuid.UUID]struct{}, len(collaborators))
+	var total int32
+	for _, c := range collaborators {
+		if c.UserID == uuid.Nil {
+			return nil, model.NewErr(model.ErrValidationFailed, "collaborator user id must not be empty")
+		}
+		if _, ok := seen[c.UserID]; ok {
+			return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("collaborator %s is given twice", c.UserID))
+		}
+		seen[c.UserID] = struct{}{}
+
+		if !slices.Contains(model.CollaboratorRoles, c.Role) {
+			return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("collaborator role %q is unknown", c.Role))
+		}
+		if c.Share <= 0 || c.Share > 100 {
+			return nil, model.NewErr(model.ErrValidationFailed, "collaborator share must be in [1, 100]")
+		}
+		total += c.Share
+
+		params = append(params, generated.SaveBeatCollaboratorParams{
+			BeatID: beatID,
+			UserID: c.UserID,
+			Role:   c.Role,
+			Share:  c.Share,
+		})
+	}
+
+	if len(params) > 0 && total != 100 {
+		return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("collaborator shares must add up to 100, got %d", total))
+	}
+
+	res, err := s.collaboratorModifier.SetBeatCollaborators(ctx, beatID, params)
+	if err != nil {
+		s.log.Error("failed to set beat collaborators", sl.Err(err))
+		return nil, err
+	}
+
+	return toCollaborators(res), nil
+}
+
+// GetEarnings returns the earnings of the collaborator from the licenses of the beats
+// they collaborated on, their own beats included, the latest first.
+func (s *CollaboratorService) GetEarnings(ctx context.Context, userID uuid.UUID) (*model.Earnings, error) {
+	rows, err := s.collaboratorProvider.GetCollaboratorEarnings(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get collaborator earnings", sl.Err(err))
+		return nil, err
+	}
+
+	res := &model.Earnings{
+		Currency: s.config.currency,
+		Entries:  make([]model.Earning, 0, len(rows)),
+	}
+	for _, row := range rows {
+		res.Entries = append(res.Entries, model.Earning{
+			BeatID: row.BeatID,
+			Beat:   row.Name,
+			Tier:   row.Tier,
+			Number: row.Number,
+			Role:   row.Role,
+			Share:  row.Share,
+			Price:  row.Price,
+			Amount: row.Amount,
+			SoldAt: row.CreatedAt.Time,
+		})
+		res.Total += row.Amount
+	}
+
+	return res, nil
+}
+
+func (s *CollaboratorService) getBeat(ctx context.Context, beatID uuid.UUID) (*generated.Beat, error) {
+	beat, err := s.collaboratorProvider.GetBeatByID(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return nil, err
+	}
+
+	if beat.IsDeleted {
+		return nil, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	return beat, nil
+}
+
+func toCollaborators(collaborators []generated.BeatsCollaborator) []model.Collaborator {
+	res := make([]model.Collaborator, 0, len(collaborators))
+	for _, c := range collaborators {
+		res = append(res, model.Collaborator{
+			UserID: c.UserID,
+			Role:   c.Role,
+			Share:  c.Share,
+		})
+	}
+	return res
+}
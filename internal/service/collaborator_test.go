@@ -0,0 +1,207 @@
+package beat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type collaboratorDependencies struct {
+	collaboratorService  *CollaboratorService
+	collaboratorModifier *mocks.CollaboratorModifier
+	collaboratorProvider *mocks.CollaboratorProvider
+}
+
+func createCollaboratorService(t *testing.T) collaboratorDependencies {
+	t.Helper()
+
+	collaboratorModifier := mocks.NewCollaboratorModifier(t)
+	collaboratorProvider := mocks.NewCollaboratorProvider(t)
+
+	return collaboratorDependencies{
+		collaboratorService:  NewCollaboratorService(collaboratorModifier, collaboratorProvider, NewCollaboratorServiceConfig("RUB"), slogdiscard.NewDiscardLogger()),
+		collaboratorModifier: collaboratorModifier,
+		collaboratorProvider: collaboratorProvider,
+	}
+}
+
+func TestSetBeatCollaborators_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createCollaboratorService(t)
+
+	userID := uuid.New()
+	coProducerID := uuid.New()
+	beatID := uuid.New()
+	collaborators := []model.Collaborator{
+		{UserID: userID, Role: generated.CollaboratorRoleProducer, Share: 60},
+		{UserID: coProducerID, Role: generated.CollaboratorRoleCoProducer, Share: 40},
+	}
+	saved := []generated.BeatsCollaborator{
+		{BeatID: beatID, UserID: userID, Role: generated.CollaboratorRoleProducer, Share: 60},
+		{BeatID: beatID, UserID: coProducerID, Role: generated.CollaboratorRoleCoProducer, Share: 40},
+	}
+
+	s.collaboratorProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID, BeatmakerID: userID}, nil).Once()
+	s.collaboratorModifier.On("SetBeatCollaborators", mock.Anything, beatID, []generated.SaveBeatCollaboratorParams{
+		{BeatID: beatID, UserID: userID, Role: generated.CollaboratorRoleProducer, Share: 60},
+		{BeatID: beatID, UserID: coProducerID, Role: generated.CollaboratorRoleCoProducer, Share: 40},
+	}).Return(saved, nil).Once()
+
+	res, err := s.collaboratorService.SetBeatCollaborators(context.Background(), userID, false, beatID, collaborators)
+	require.NoError(t, err)
+	assert.Equal(t, collaborators, res)
+}
+
+func TestSetBeatCollaborators_SuccessEmpty(t *testing.T) {
+	t.Parallel()
+
+	s := createCollaboratorService(t)
+
+	beatID := uuid.New()
+
+	// Clearing the collaborators leaves the whole revenue to the beatmaker.
+	s.collaboratorProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID}, nil).Once()
+	s.collaboratorModifier.On("SetBeatCollaborators", mock.Anything, beatID, []generated.SaveBeatCollaboratorParams{}).Return(nil, nil).Once()
+
+	res, err := s.collaboratorService.SetBeatCollaborators(context.Background(), uuid.New(), true, beatID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestSetBeatCollaborators_FailNotBeatOwner(t *testing.T) {
+	t.Parallel()
+
+	s := createCollaboratorService(t)
+
+	beatID := uuid.New()
+	s.collaboratorProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID, BeatmakerID: uuid.New()}, nil).Once()
+
+	_, err := s.collaboratorService.SetBeatCollaborators(context.Background(), uuid.New(), false, beatID, nil)
+	assert.ErrorIs(t, err, model.ErrNotBeatOwner)
+}
+
+func TestSetBeatCollaborators_FailValidation(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tests := []struct {
+		name          string
+		collaborators []model.Collaborator
+	}{
+		{
+			name: "shares under 100",
+			collaborators: []model.Collaborator{
+				{UserID: uuid.New(), Role: generated.CollaboratorRoleProducer, Share: 50},
+				{UserID: uuid.New(), Role: generated.CollaboratorRoleEngineer, Share: 30},
+			},
+		},
+		{
+			name: "shares over 100",
+			collaborators: []model.Collaborator{
+				{UserID: uuid.New(), Role: generated.CollaboratorRoleProducer, Share: 70},
+				{UserID: uuid.New(), Role: generated.CollaboratorRoleComposer, Share: 40},
+			},
+		},
+		{
+			name:          "zero share",
+			collaborators: []model.Collaborator{{UserID: uuid.New(), Role: generated.CollaboratorRoleProducer}},
+		},
+		{
+			name:          "unknown role",
+			collaborators: []model.Collaborator{{UserID: uuid.New(), Role: "manager", Share: 100}},
+		},
+		{
+			name:          "empty user",
+			collaborators: []model.Collaborator{{Role: generated.CollaboratorRoleProducer, Share: 100}},
+		},
+		{
+			name: "duplicate user",
+			collaborators: []model.Collaborator{
+				{UserID: userID, Role: generated.CollaboratorRoleProducer, Share: 50},
+				{UserID: userID, Role: generated.CollaboratorRoleSongwriter, Share: 50},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createCollaboratorService(t)
+			s.collaboratorProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{}, nil).Once()
+
+			_, err := s.collaboratorService.SetBeatCollaborators(context.Background(), uuid.New(), true, uuid.New(), tt.collaborators)
+			assert.ErrorIs(t, err, model.ErrValidationFailed)
+		})
+	}
+}
+
+func TestGetBeatCollaborators_FailBeatDeleted(t *testing.T) {
+	t.Parallel()
+
+	s := createCollaboratorService(t)
+
+	beatID := uuid.New()
+	s.collaboratorProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID, IsDeleted: true}, nil).Once()
+
+	_, err := s.collaboratorService.GetBeatCollaborators(context.Background(), beatID)
+	assert.ErrorIs(t, err, model.ErrBeatNotFound)
+}
+
+func TestGetEarnings_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createCollaboratorService(t)
+
+	userID := uuid.New()
+	soldAt := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+	rows := []generated.GetCollaboratorEarningsRow{
+		{BeatID: uuid.New(), Name: "Night", Tier: generated.LicenseTierWavLease, Role: generated.CollaboratorRoleCoProducer, Share: 40, Price: 5001, Amount: 2000, CreatedAt: pgtype.Timestamp{Time: soldAt, Valid: true}},
+		{BeatID: uuid.New(), Name: "Day", Tier: generated.LicenseTierMp3Lease, Role: generated.CollaboratorRoleProducer, Share: 100, Price: 2000, Amount: 2000},
+	}
+
+	s.collaboratorProvider.On("GetCollaboratorEarnings", mock.Anything, userID).Return(rows, nil).Once()
+
+	res, err := s.collaboratorService.GetEarnings(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, "RUB", res.Currency)
+	assert.Equal(t, int64(4000), res.Total)
+	require.Len(t, res.Entries, 2)
+	assert.Equal(t, soldAt, res.Entries[0].SoldAt)
+	assert.Equal(t, generated.CollaboratorRoleCoProducer, res.Entries[0].Role)
+}
+
+func TestSplitEarnings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		price  int64
+		shares []int32
+		want   []int64
+	}{
+		{name: "even", price: 10000, shares: []int32{50, 50}, want: []int64{5000, 5000}},
+		{name: "remainder to the first shares", price: 100, shares: []int32{34, 33, 33}, want: []int64{34, 33, 33}},
+		{name: "rounding", price: 1001, shares: []int32{33, 33, 34}, want: []int64{331, 330, 340}},
+		{name: "free", price: 0, shares: []int32{60, 40}, want: []int64{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, model.SplitEarnings(tt.price, tt.shares))
+		})
+	}
+}
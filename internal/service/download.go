@@ -0,0 +1,213 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// minLinkLifetime is how long an issued download link must still be valid to be given out
+// again instead of issuing a new one.
+const minLinkLifetime = time.Minute
+
+type DownloadServiceConfig struct {
+	limit  int32
+	urlTTL int
+}
+
+func NewDownloadServiceConfig(limit int32, urlTTL int) *DownloadServiceConfig {
+	return &DownloadServiceConfig{
+		limit:  limit,
+		urlTTL: urlTTL,
+	}
+}
+
+//go:generate mockery --name DownloadModifier
+type DownloadModifier interface {
+	IssueDownload(ctx context.Context, issue model.IssueDownload) (string, error)
+	ResetDownloads(ctx context.Context, audit generated.SaveLicenseAuditParams) error
+}
+
+//go:generate mockery --name DownloadProvider
+type DownloadProvider interface {
+	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
+	GetBeatLicenses(ctx context.Context, beatID uuid.UUID) ([]generated.BeatLicense, error)
+	GetLicenseByNumber(ctx context.Context, number string) (*generated.BeatLicense, error)
+	GetLicenseAudit(ctx context.Context, arg generated.GetLicenseAuditParams) ([]generated.LicenseAudit, error)
+}
+
+type DownloadService struct {
+	downloadModifier DownloadModifier
+	downloadProvider DownloadProvider
+	urlProvider      URLProvider
+	config           *DownloadServiceConfig
+	log              *slog.Logger
+}
+
+func NewDownloadService(
+	downloadModifier DownloadModifier,
+	downloadProvider DownloadProvider,
+	urlProvider URLProvider,
+	config *DownloadServiceConfig,
+	log *slog.Logger,
+) *DownloadService {
+	return &DownloadService{
+		downloadModifier: downloadModifier,
+		downloadProvider: downloadProvider,
+		urlProvider:      urlProvider,
+		config:           config,
+		log:              log,
+	}
+}
+
+// IssueDownload returns a download link of the deliverable of the license of the beat.
+// A link issued before that is still valid is given out again without counting against
+// the download limit, otherwise a new link is issued and counted. Either way the link is
+// recorded in the audit log with the client. Revoked licenses get no links. The new link
+// is signed up front, the store decides which one is given out with the license locked.
+func (s *DownloadService) IssueDownload(ctx context.Context, license generated.BeatLicense, beat *generated.Beat, client model.DownloadClient) (string, error) {
+	if license.RevokedAt.Valid {
+		return "", &model.ModelError{Err: model.ErrLicenseRevoked}
+	}
+
+	path, ok := deliverable(beat, license.Deliverables)
+	if !ok {
+		s.log.Debug("archive not found")
+		return "", &model.ModelError{Err: model.ErrArchiveNotFound}
+	}
+
+	now := time.Now().UTC()
+	ttl := time.Minute * time.Duration(s.config.urlTTL)
+	url, err := s.urlProvider.GetDownloadMediaURL(ctx, path, ttl)
+	if err != nil {
+		s.log.Error("failed to get download media url", sl.Err(err))
+		return "", err
+	}
+
+	res, err := s.downloadModifier.IssueDownload(ctx, model.IssueDownload{
+		Audit: generated.SaveLicenseAuditParams{
+			BeatID:    license.BeatID,
+			UserID:    license.UserID,
+			Tier:      license.Tier,
+			Action:    generated.LicenseAuditActionDownload,
+			Ip:        nonEmpty(client.IP),
+			UserAgent: nonEmpty(client.UserAgent),
+			Url:       url,
+			ExpiresAt: pgtype.Timestamp{Time: now.Add(ttl), Valid: true},
+		},
+		Limit:     s.config.limit,
+		LiveUntil: now.Add(minLinkLifetime),
+	})
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to issue download", sl.Err(err))
+		}
+		return "", err
+	}
+
+	return res, nil
+}
+
+// ReissueDownload returns a download link of the license of the tier of the beat the user
+// bought, see IssueDownload.
+func (s *DownloadService) ReissueDownload(ctx context.Context, userID, beatID uuid.UUID, tier generated.LicenseTier, client model.DownloadClient) (string, error) {
+	beat, err := s.downloadProvider.GetBeatByID(ctx, beatID)
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to get beat", sl.Err(err))
+		}
+		return "", err
+	}
+
+	licenses, err := s.downloadProvider.GetBeatLicenses(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get licenses", sl.Err(err))
+		return "", err
+	}
+
+	i := slices.IndexFunc(licenses, func(l generated.BeatLicense) bool {
+		return l.UserID == userID && l.Tier == tier
+	})
+	if i < 0 {
+		return "", &model.ModelError{Err: model.ErrLicenseNotFound}
+	}
+
+	return s.IssueDownload(ctx, licenses[i], beat, client)
+}
+
+// GetDownloads returns the download counter and the audit log of the license of the number.
+func (s *DownloadService) GetDownloads(ctx context.Context, number string) (*model.LicenseDownloads, error) {
+	license, err := s.getLicense(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := s.downloadProvider.GetLicenseAudit(ctx, generated.GetLicenseAuditParams{
+		BeatID: license.BeatID,
+		UserID: license.UserID,
+		Tier:   license.Tier,
+	})
+	if err != nil {
+		s.log.Error("failed to get license audit", sl.Err(err))
+		return nil, err
+	}
+
+	return &model.LicenseDownloads{License: *license, Limit: s.config.limit, Audit: audit}, nil
+}
+
+// ResetDownloads sets the download counter of the license of the number to zero on behalf
+// of the admin. The reset is recorded in the audit log.
+func (s *DownloadService) ResetDownloads(ctx context.Context, adminID uuid.UUID, number string) error {
+	license, err := s.getLicense(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	if err := s.downloadModifier.ResetDownloads(ctx, generated.SaveLicenseAuditParams{
+		BeatID:  license.BeatID,
+		UserID:  license.UserID,
+		Tier:    license.Tier,
+		Action:  generated.LicenseAuditActionReset,
+		ActorID: pgtype.UUID{Bytes: adminID, Valid: true},
+	}); err != nil {
+		s.log.Error("failed to reset downloads", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *DownloadService) getLicense(ctx context.Context, number string) (*generated.BeatLicense, error) {
+	number, err := model.ParseLicenseNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	license, err := s.downloadProvider.GetLicenseByNumber(ctx, number)
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to get license", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	return license, nil
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
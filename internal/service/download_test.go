@@ -0,0 +1,161 @@
+package beat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type downloadDependencies struct {
+	downloadService  *DownloadService
+	downloadModifier *mocks.DownloadModifier
+	downloadProvider *mocks.DownloadProvider
+	urlProvider      *mocks.URLProvider
+}
+
+func createDownloadService(t *testing.T) downloadDependencies {
+	t.Helper()
+
+	downloadModifier := mocks.NewDownloadModifier(t)
+	downloadProvider := mocks.NewDownloadProvider(t)
+	urlProvider := mocks.NewURLProvider(t)
+
+	return downloadDependencies{
+		downloadService:  NewDownloadService(downloadModifier, downloadProvider, urlProvider, NewDownloadServiceConfig(3, 5), slogdiscard.NewDiscardLogger()),
+		downloadModifier: downloadModifier,
+		downloadProvider: downloadProvider,
+		urlProvider:      urlProvider,
+	}
+}
+
+func TestIssueDownload_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createDownloadService(t)
+
+	license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierTrackout, Deliverables: []string{"archive"}}
+	beat := generated.Beat{ID: license.BeatID, ArchivePath: uuid.NewString(), IsArchiveDownloaded: true}
+	client := model.DownloadClient{IP: "203.0.113.7", UserAgent: "curl/8.0"}
+	url := "url"
+
+	s.urlProvider.On("GetDownloadMediaURL", mock.Anything, beat.ArchivePath, 5*time.Minute).Return(&url, nil).Once()
+	s.downloadModifier.On("IssueDownload", mock.Anything, mock.MatchedBy(func(issue model.IssueDownload) bool {
+		return issue.Limit == 3 && issue.Audit.Action == generated.LicenseAuditActionDownload &&
+			*issue.Audit.Ip == client.IP && *issue.Audit.UserAgent == client.UserAgent && *issue.Audit.Url == url &&
+			time.Until(issue.LiveUntil) > 0 && time.Until(issue.LiveUntil) <= minLinkLifetime
+	})).Return(url, nil).Once()
+
+	res, err := s.downloadService.IssueDownload(context.Background(), license, &beat, client)
+	require.NoError(t, err)
+	assert.Equal(t, url, res)
+}
+
+func TestIssueDownload_SuccessLive(t *testing.T) {
+	t.Parallel()
+
+	s := createDownloadService(t)
+
+	license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}
+	beat := generated.Beat{ID: license.BeatID, FilePath: uuid.NewString(), IsFileDownloaded: true}
+	url := "url"
+	live := "live"
+
+	// The store gives out the link issued before while it is still valid, the new one is
+	// dropped.
+	s.urlProvider.On("GetDownloadMediaURL", mock.Anything, beat.FilePath, mock.Anything).Return(&url, nil).Once()
+	s.downloadModifier.On("IssueDownload", mock.Anything, mock.Anything).Return(live, nil).Once()
+
+	res, err := s.downloadService.IssueDownload(context.Background(), license, &beat, model.DownloadClient{})
+	require.NoError(t, err)
+	assert.Equal(t, live, res)
+}
+
+func TestIssueDownload_FailLimit(t *testing.T) {
+	t.Parallel()
+
+	s := createDownloadService(t)
+
+	license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}
+	beat := generated.Beat{ID: license.BeatID, FilePath: uuid.NewString(), IsFileDownloaded: true}
+	url := "url"
+
+	s.urlProvider.On("GetDownloadMediaURL", mock.Anything, mock.Anything, mock.Anything).Return(&url, nil).Once()
+	s.downloadModifier.On("IssueDownload", mock.Anything, mock.Anything).Return("", &model.ModelError{Err: model.ErrDownloadLimit}).Once()
+
+	_, err := s.downloadService.IssueDownload(context.Background(), license, &beat, model.DownloadClient{})
+	assert.ErrorIs(t, err, model.ErrDownloadLimit)
+}
+
+func TestIssueDownload_FailArchiveNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := createDownloadService(t)
+
+	license := generated.BeatLicense{Deliverables: []string{"archive"}}
+
+	_, err := s.downloadService.IssueDownload(context.Background(), license, &generated.Beat{}, model.DownloadClient{})
+	assert.ErrorIs(t, err, model.ErrArchiveNotFound)
+}
+
+func TestIssueDownload_FailRevoked(t *testing.T) {
+	t.Parallel()
+
+	s := createDownloadService(t)
+
+	license := generated.BeatLicense{Deliverables: []string{"file"}, RevokedAt: pgtype.Timestamp{Time: time.Now(), Valid: true}}
+	beat := generated.Beat{FilePath: uuid.NewString(), IsFileDownloaded: true}
+
+	_, err := s.downloadService.IssueDownload(context.Background(), license, &beat, model.DownloadClient{})
+	assert.ErrorIs(t, err, model.ErrLicenseRevoked)
+}
+
+func TestReissueDownload_FailLicenseNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := createDownloadService(t)
+
+	beatID := uuid.New()
+	licenses := []generated.BeatLicense{{BeatID: beatID, UserID: uuid.New(), Tier: generated.LicenseTierExclusive}}
+
+	s.downloadProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{}, nil).Once()
+	s.downloadProvider.On("GetBeatLicenses", mock.Anything, beatID).Return(licenses, nil).Once()
+
+	_, err := s.downloadService.ReissueDownload(context.Background(), uuid.New(), beatID, generated.LicenseTierExclusive, model.DownloadClient{})
+	assert.ErrorIs(t, err, model.ErrLicenseNotFound)
+}
+
+func TestResetDownloads_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createDownloadService(t)
+
+	adminID := uuid.New()
+	license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierWavLease, Number: "BF-0123456789AB"}
+
+	s.downloadProvider.On("GetLicenseByNumber", mock.Anything, license.Number).Return(&license, nil).Once()
+	s.downloadModifier.On("ResetDownloads", mock.Anything, mock.MatchedBy(func(audit generated.SaveLicenseAuditParams) bool {
+		return audit.Action == generated.LicenseAuditActionReset && audit.ActorID.Bytes == adminID && audit.Tier == license.Tier
+	})).Return(nil).Once()
+
+	err := s.downloadService.ResetDownloads(context.Background(), adminID, license.Number)
+	assert.NoError(t, err)
+}
+
+func TestResetDownloads_FailInvalidNumber(t *testing.T) {
+	t.Parallel()
+
+	s := createDownloadService(t)
+
+	err := s.downloadService.ResetDownloads(context.Background(), uuid.New(), "not a number")
+	assert.ErrorIs(t, err, model.ErrValidationFailed)
+}
@@ -0,0 +1,119 @@
+package beat
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type FeedServiceConfig struct {
+	limit uint64
+}
+
+func NewFeedServiceConfig(limit uint64) *FeedServiceConfig {
+	return &FeedServiceConfig{
+		limit: limit,
+	}
+}
+
+//go:generate mockery --name FeedProvider
+type FeedProvider interface {
+	GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
+	GetTaxonomy(ctx context.Context, kind model.TaxonomyKind) ([]model.TaxonomyEntry, error)
+}
+
+type FeedService struct {
+	feedProvider FeedProvider
+	userProvider UserProvider
+	config       *FeedServiceConfig
+	log          *slog.Logger
+}
+
+func NewFeedService(
+	feedProvider FeedProvider,
+	userProvider UserProvider,
+	config *FeedServiceConfig,
+	log *slog.Logger,
+) *FeedService {
+	return &FeedService{
+		feedProvider: feedProvider,
+		userProvider: userProvider,
+		config:       config,
+		log:          log,
+	}
+}
+
+// GetFeed returns the newest published beats of the feed selected by params. Deleted
+// beats and beats whose file, image or archive is not uploaded yet are left out.
+func (s *FeedService) GetFeed(ctx context.Context, params model.GetFeedParams) (*model.Feed, error) {
+	var res model.Feed
+
+	published := true
+	beatsParams := model.GetBeatsParams{
+		BeatmakerID:  params.BeatmakerID,
+		IsDownloaded: &published,
+		OrderBy:      &model.OrderBy{Field: "created_at", Order: "desc"},
+		Limit:        s.config.limit,
+		Locales:      params.Locales,
+	}
+
+	if params.Genre != nil {
+		genre, err := s.getGenre(ctx, *params.Genre, params.Locales)
+		if err != nil {
+			return nil, err
+		}
+		res.Genre = genre
+		beatsParams.Genre = []string{genre.ID.String()}
+	}
+
+	beats, _, err := s.feedProvider.GetBeats(ctx, beatsParams)
+	if err != nil {
+		s.log.Error("failed to get feed beats", sl.Err(err))
+		return nil, err
+	}
+	res.Beats = beats
+
+	ids := model.BeatmakerIDs(beats)
+	if params.BeatmakerID != nil {
+		ids = append(ids, *params.BeatmakerID)
+	}
+	res.Users = s.userProvider.GetUsers(ctx, ids)
+
+	if params.BeatmakerID != nil {
+		res.Beatmaker = res.Users[*params.BeatmakerID]
+	}
+
+	return &res, nil
+}
+
+// getGenre finds the genre by id, slug or name and names it in the first of locales
+// that has a translation.
+func (s *FeedService) getGenre(ctx context.Context, genre string, locales []string) (*model.TaxonomyEntry, error) {
+	entries, err := s.feedProvider.GetTaxonomy(ctx, model.TaxonomyGenres)
+	if err != nil {
+		s.log.Error("failed to get genres", sl.Err(err))
+		return nil, err
+	}
+
+	id, _ := uuid.Parse(genre)
+	for i := range entries {
+		e := entries[i]
+		if e.ID != id && e.Slug != genre && !strings.EqualFold(e.Name, genre) {
+			continue
+		}
+
+		for _, l := range locales {
+			if name, ok := e.Translations[l]; ok {
+				e.Name = name
+				break
+			}
+		}
+		return &e, nil
+	}
+
+	return nil, &model.ModelError{Err: model.ErrTaxonomyNotFound}
+}
@@ -0,0 +1,94 @@
+package beat
+
+import (
+	"context"
+	"testing"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type feedDependencies struct {
+	feedService  *FeedService
+	feedProvider *mocks.FeedProvider
+	userProvider *mocks.UserProvider
+}
+
+func createFeedService(t *testing.T) feedDependencies {
+	t.Helper()
+
+	feedProvider := mocks.NewFeedProvider(t)
+	userProvider := mocks.NewUserProvider(t)
+
+	return feedDependencies{
+		feedService:  NewFeedService(feedProvider, userProvider, NewFeedServiceConfig(20), slogdiscard.NewDiscardLogger()),
+		feedProvider: feedProvider,
+		userProvider: userProvider,
+	}
+}
+
+func TestGetFeed_SuccessBeatmaker(t *testing.T) {
+	t.Parallel()
+
+	s := createFeedService(t)
+
+	beatmakerID := uuid.New()
+	beats := []model.Beat{{ID: uuid.New(), BeatmakerID: beatmakerID}}
+	users := map[uuid.UUID]*userv1.GetUserResponse{beatmakerID: {Pseudonym: "Lil Beat"}}
+
+	s.feedProvider.On("GetBeats", mock.Anything, mock.MatchedBy(func(p model.GetBeatsParams) bool {
+		return *p.BeatmakerID == beatmakerID && *p.IsDownloaded && p.OrderBy.Field == "created_at" && p.OrderBy.Order == "desc" && p.Limit == 20
+	})).Return(beats, nil, nil).Once()
+	s.userProvider.On("GetUsers", mock.Anything, []uuid.UUID{beatmakerID, beatmakerID}).Return(users).Once()
+
+	res, err := s.feedService.GetFeed(context.Background(), model.GetFeedParams{BeatmakerID: &beatmakerID})
+	require.NoError(t, err)
+	assert.Equal(t, beats, res.Beats)
+	assert.Equal(t, users[beatmakerID], res.Beatmaker)
+	assert.Nil(t, res.Genre)
+}
+
+func TestGetFeed_SuccessGenre(t *testing.T) {
+	t.Parallel()
+
+	s := createFeedService(t)
+
+	genreID := uuid.New()
+	entries := []model.TaxonomyEntry{
+		{ID: uuid.New(), Name: "Trap", Slug: "trap"},
+		{ID: genreID, Name: "Phonk", Slug: "phonk", Translations: map[string]string{"ru": "Фонк"}},
+	}
+
+	s.feedProvider.On("GetTaxonomy", mock.Anything, model.TaxonomyGenres).Return(entries, nil).Once()
+	s.feedProvider.On("GetBeats", mock.Anything, mock.MatchedBy(func(p model.GetBeatsParams) bool {
+		return p.BeatmakerID == nil && assert.ObjectsAreEqual([]string{genreID.String()}, p.Genre)
+	})).Return(nil, nil, nil).Once()
+	s.userProvider.On("GetUsers", mock.Anything, []uuid.UUID{}).Return(map[uuid.UUID]*userv1.GetUserResponse{}).Once()
+
+	genre := "phonk"
+	res, err := s.feedService.GetFeed(context.Background(), model.GetFeedParams{Genre: &genre, Locales: []string{"ru", "en"}})
+	require.NoError(t, err)
+	if assert.NotNil(t, res.Genre) {
+		assert.Equal(t, genreID, res.Genre.ID)
+		assert.Equal(t, "Фонк", res.Genre.Name)
+	}
+}
+
+func TestGetFeed_FailGenreNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := createFeedService(t)
+
+	s.feedProvider.On("GetTaxonomy", mock.Anything, model.TaxonomyGenres).
+		Return([]model.TaxonomyEntry{{ID: uuid.New(), Name: "Trap", Slug: "trap"}}, nil).Once()
+
+	genre := "polka"
+	_, err := s.feedService.GetFeed(context.Background(), model.GetFeedParams{Genre: &genre})
+	assert.ErrorIs(t, err, model.ErrTaxonomyNotFound)
+}
@@ -0,0 +1,346 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type LicenseServiceConfig struct {
+	currency string
+}
+
+func NewLicenseServiceConfig(currency string) *LicenseServiceConfig {
+	return &LicenseServiceConfig{
+		currency: currency,
+	}
+}
+
+//go:generate mockery --name LicenseModifier
+type LicenseModifier interface {
+	SetLicenseTiers(ctx context.Context, beatID uuid.UUID, tiers []generated.SaveLicenseTierParams) ([]generated.BeatsLicenseTier, error)
+	RevokeLicense(ctx context.Context, revocation model.RevokeLicense) (*generated.BeatLicense, error)
+}
+
+//go:generate mockery --name LicenseProvider
+type LicenseProvider interface {
+	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
+	GetLicenseTiers(ctx context.Context, beatID uuid.UUID) ([]generated.BeatsLicenseTier, error)
+	GetLicenseByNumber(ctx context.Context, number string) (*generated.BeatLicense, error)
+	GetPaidLicenseOrders(ctx context.Context, arg generated.GetPaidLicenseOrdersParams) ([]generated.Order, error)
+}
+
+type LicenseService struct {
+	licenseModifier LicenseModifier
+	licenseProvider LicenseProvider
+	userProvider    UserProvider
+	paymentProvider PaymentProvider
+	config          *LicenseServiceConfig
+	log             *slog.Logger
+}
+
+func NewLicenseService(
+	licenseModifier LicenseModifier,
+	licenseProvider LicenseProvider,
+	userProvider UserProvider,
+	paymentProvider PaymentProvider,
+	config *LicenseServiceConfig,
+	log *slog.Logger,
+) *LicenseService {
+	return &LicenseService{
+		licenseModifier: licenseModifier,
+		licenseProvider: licenseProvider,
+		userProvider:    userProvider,
+		paymentProvider: paymentProvider,
+		config:          config,
+		log:             log,
+	}
+}
+
+// GetLicenseTiers returns the tiers the beat is sold under, from the cheapest lease to
+// the exclusive rights.
+func (s *LicenseService) GetLicenseTiers(ctx context.Context, beatID uuid.UUID) ([]model.LicenseTier, error) {
+	if _, err := s.getBeat(ctx, beatID); err != nil {
+		return nil, err
+	}
+
+	tiers, err := s.licenseProvider.GetLicenseTiers(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get license tiers", sl.Err(err))
+		return nil, err
+	}
+
+	return s.toLicenseTiers(tiers), nil
+}
+
+// SetLicenseTiers replaces the tiers the beat is sold under. Only the beatmaker of the
+// beat or an admin can set them. Licenses already sold keep the terms they were sold under.
+func (s *LicenseService) SetLicenseTiers(ctx context.Context, userID uuid.UUID, isAdmin bool, beatID uuid.UUID, tiers []model.LicenseTier) ([]model.LicenseTier, error) {
+	beat, err := s.getBeat(ctx, beatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin && beat.BeatmakerID != userID {
+		return nil, &model.ModelError{Err: model.ErrNotBeatOwner}
+	}
+
+	params := make([]generated.SaveLicenseTierParams, 0, len(tiers))
+	seen := make(map[generated.LicenseTier]struct{}, len(tiers))
+	for _, t := range tiers {
+		if _, ok := seen[t.Tier]; ok {
+			return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("license tier %s is given twice", t.Tier))
+		}
+		seen[t.Tier] = struct{}{}
+
+		deliverables, err := validateLicenseTier(t)
+		if err != nil {
+			return nil, err
+		}
+
+		params = append(params, generated.SaveLicenseTierParams{
+			BeatID:          beatID,
+			Tier:            t.Tier,
+			Price:           t.Price,
+			Deliverables:    deliverables,
+			StreamCap:       t.StreamCap,
+			DistributionCap: t.DistributionCap,
+			SalesCap:        t.SalesCap,
+		})
+	}
+
+	res, err := s.licenseModifier.SetLicenseTiers(ctx, beatID, params)
+	if err != nil {
+		s.log.Error("failed to set license tiers", sl.Err(err))
+		return nil, err
+	}
+
+	return s.toLicenseTiers(res), nil
+}
+
+// VerifyLicense returns the public record of the license of payload, a license number or
+// the verification URL of its QR code, for anyone to check that the license was issued.
+func (s *LicenseService) VerifyLicense(ctx context.Context, payload string) (*model.LicenseVerification, error) {
+	number, err := model.ParseLicenseNumber(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	license, err := s.licenseProvider.GetLicenseByNumber(ctx, number)
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to get license", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	// The beat of an exclusive license is deleted by its sale, its licenses stay valid.
+	beat, err := s.licenseProvider.GetBeatByID(ctx, license.BeatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return nil, err
+	}
+
+	users := s.userProvider.GetUsers(ctx, []uuid.UUID{license.UserID, beat.BeatmakerID})
+
+	var revokedAt *time.Time
+	if license.RevokedAt.Valid {
+		revokedAt = &license.RevokedAt.Time
+	}
+
+	return &model.LicenseVerification{
+		Number:          license.Number,
+		BeatID:          beat.ID,
+		Beat:            beat.Name,
+		Beatmaker:       displayName(users[beat.BeatmakerID]),
+		Tier:            license.Tier,
+		Licensee:        displayName(users[license.UserID]),
+		IssuedAt:        license.CreatedAt.Time,
+		Valid:           revokedAt == nil,
+		RevokedAt:       revokedAt,
+		StreamCap:       license.StreamCap,
+		DistributionCap: license.DistributionCap,
+	}, nil
+}
+
+// RevokeLicense revokes the license of the number on behalf of the admin after a refund
+// or chargeback, for the reason recorded in its audit log. The license is no longer valid
+// and delivers nothing, its paid orders are marked refunded. With refund the payments
+// of the orders are returned through the payment provider first, a revocation that fails
+// after them can be retried since refunds are idempotent. Without it the money is taken
+// to be back already, e.g. after a chargeback. With restoreBeat the beat of an exclusive
+// license returns to the catalog.
+func (s *LicenseService) RevokeLicense(ctx context.Context, adminID uuid.UUID, number, reason string, restoreBeat, refund bool) (*generated.BeatLicense, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, model.NewErr(model.ErrValidationFailed, "reason must not be empty")
+	}
+
+	number, err := model.ParseLicenseNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	license, err := s.licenseProvider.GetLicenseByNumber(ctx, number)
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to get license", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	if restoreBeat && model.IsLease(license.Tier) {
+		return nil, model.NewErr(model.ErrValidationFailed, "only the beat of an exclusive license can be restored")
+	}
+
+	if refund {
+		if s.paymentProvider == nil {
+			return nil, model.NewErr(model.ErrValidationFailed, "no payment provider to refund through")
+		}
+		if err := s.refundOrders(ctx, license); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := s.licenseModifier.RevokeLicense(ctx, model.RevokeLicense{
+		Audit: generated.SaveLicenseAuditParams{
+			BeatID:  license.BeatID,
+			UserID:  license.UserID,
+			Tier:    license.Tier,
+			Action:  generated.LicenseAuditActionRevoke,
+			ActorID: pgtype.UUID{Bytes: adminID, Valid: true},
+			Reason:  &reason,
+		},
+		RestoreBeat: restoreBeat,
+	})
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to revoke license", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// refundOrders returns the payments of the paid orders of the license to its buyer.
+func (s *LicenseService) refundOrders(ctx context.Context, license *generated.BeatLicense) error {
+	orders, err := s.licenseProvider.GetPaidLicenseOrders(ctx, generated.GetPaidLicenseOrdersParams{
+		BeatID: license.BeatID,
+		UserID: license.UserID,
+		Tier:   license.Tier,
+	})
+	if err != nil {
+		s.log.Error("failed to get paid orders", sl.Err(err))
+		return err
+	}
+
+	for _, o := range orders {
+		if o.Provider != s.paymentProvider.Name() || o.CheckoutID == nil {
+			err := fmt.Errorf("order %s cannot be refunded through %s", o.ID, s.paymentProvider.Name())
+			s.log.Error("failed to refund order", sl.Err(err))
+			return err
+		}
+
+		if err := s.paymentProvider.Refund(ctx, *o.CheckoutID); err != nil {
+			s.log.Error("failed to refund order", slog.String("order_id", o.ID.String()), sl.Err(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *LicenseService) getBeat(ctx context.Context, beatID uuid.UUID) (*generated.Beat, error) {
+	beat, err := s.licenseProvider.GetBeatByID(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return nil, err
+	}
+
+	if beat.IsDeleted {
+		return nil, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	return beat, nil
+}
+
+func (s *LicenseService) toLicenseTiers(tiers []generated.BeatsLicenseTier) []model.LicenseTier {
+	res := make([]model.LicenseTier, 0, len(tiers))
+	for _, t := range tiers {
+		deliverables := make([]model.MediaType, 0, len(t.Deliverables))
+		for _, d := range t.Deliverables {
+			deliverables = append(deliverables, model.MediaType(d))
+		}
+
+		res = append(res, model.LicenseTier{
+			Tier:            t.Tier,
+			Price:           t.Price,
+			Currency:        s.config.currency,
+			Deliverables:    deliverables,
+			StreamCap:       t.StreamCap,
+			DistributionCap: t.DistributionCap,
+			SalesCap:        t.SalesCap,
+		})
+	}
+
+	return res
+}
+
+// validateLicenseTier checks the terms of the tier and returns its deliverables without
+// duplicates.
+func validateLicenseTier(t model.LicenseTier) ([]string, error) {
+	if _, err := model.ParseLicenseTier(string(t.Tier)); err != nil {
+		return nil, err
+	}
+
+	if t.Price < 0 {
+		return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("price of %s must not be negative", t.Tier))
+	}
+
+	if t.StreamCap != nil && *t.StreamCap <= 0 {
+		return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("stream cap of %s must be positive", t.Tier))
+	}
+
+	if t.DistributionCap != nil && *t.DistributionCap <= 0 {
+		return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("distribution cap of %s must be positive", t.Tier))
+	}
+
+	if t.SalesCap != nil {
+		if !model.IsLease(t.Tier) {
+			return nil, model.NewErr(model.ErrValidationFailed, "exclusive license is sold once, it takes no sales cap")
+		}
+		if *t.SalesCap <= 0 {
+			return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("sales cap of %s must be positive", t.Tier))
+		}
+	}
+
+	res := make([]string, 0, len(t.Deliverables))
+	for _, d := range t.Deliverables {
+		if d != model.MediaTypeFile && d != model.MediaTypeArchive {
+			return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("deliverables of %s must be file or archive", t.Tier))
+		}
+		if !slices.Contains(res, string(d)) {
+			res = append(res, string(d))
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("%s must deliver file or archive", t.Tier))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,343 @@
+package beat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/payment"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type licenseDependencies struct {
+	licenseService  *LicenseService
+	licenseModifier *mocks.LicenseModifier
+	licenseProvider *mocks.LicenseProvider
+	userProvider    *mocks.UserProvider
+	paymentProvider *mocks.PaymentProvider
+}
+
+func createLicenseService(t *testing.T) licenseDependencies {
+	t.Helper()
+
+	licenseModifier := mocks.NewLicenseModifier(t)
+	licenseProvider := mocks.NewLicenseProvider(t)
+	userProvider := mocks.NewUserProvider(t)
+	paymentProvider := mocks.NewPaymentProvider(t)
+
+	return licenseDependencies{
+		licenseService:  NewLicenseService(licenseModifier, licenseProvider, userProvider, paymentProvider, NewLicenseServiceConfig("RUB"), slogdiscard.NewDiscardLogger()),
+		licenseModifier: licenseModifier,
+		licenseProvider: licenseProvider,
+		userProvider:    userProvider,
+		paymentProvider: paymentProvider,
+	}
+}
+
+func TestSetLicenseTiers_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createLicenseService(t)
+
+	userID := uuid.New()
+	beatID := uuid.New()
+	streamCap := int32(100000)
+	tiers := []model.LicenseTier{
+		{Tier: generated.LicenseTierMp3Lease, Price: 2000, Deliverables: []model.MediaType{model.MediaTypeFile, model.MediaTypeFile}, StreamCap: &streamCap},
+		{Tier: generated.LicenseTierExclusive, Price: 50000, Deliverables: []model.MediaType{model.MediaTypeFile, model.MediaTypeArchive}},
+	}
+	saved := []generated.BeatsLicenseTier{
+		{BeatID: beatID, Tier: generated.LicenseTierMp3Lease, Price: 2000, Deliverables: []string{"file"}, StreamCap: &streamCap},
+		{BeatID: beatID, Tier: generated.LicenseTierExclusive, Price: 50000, Deliverables: []string{"file", "archive"}},
+	}
+
+	s.licenseProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID, BeatmakerID: userID}, nil).Once()
+	s.licenseModifier.On("SetLicenseTiers", mock.Anything, beatID, []generated.SaveLicenseTierParams{
+		{BeatID: beatID, Tier: generated.LicenseTierMp3Lease, Price: 2000, Deliverables: []string{"file"}, StreamCap: &streamCap},
+		{BeatID: beatID, Tier: generated.LicenseTierExclusive, Price: 50000, Deliverables: []string{"file", "archive"}},
+	}).Return(saved, nil).Once()
+
+	res, err := s.licenseService.SetLicenseTiers(context.Background(), userID, false, beatID, tiers)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "RUB", res[0].Currency)
+	assert.Equal(t, []model.MediaType{model.MediaTypeFile}, res[0].Deliverables)
+}
+
+func TestSetLicenseTiers_FailNotBeatOwner(t *testing.T) {
+	t.Parallel()
+
+	s := createLicenseService(t)
+
+	beatID := uuid.New()
+	s.licenseProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID, BeatmakerID: uuid.New()}, nil).Once()
+
+	_, err := s.licenseService.SetLicenseTiers(context.Background(), uuid.New(), false, beatID, nil)
+	assert.ErrorIs(t, err, model.ErrNotBeatOwner)
+}
+
+func TestSetLicenseTiers_FailValidation(t *testing.T) {
+	t.Parallel()
+
+	zero := int32(0)
+	tests := []struct {
+		name  string
+		tiers []model.LicenseTier
+	}{
+		{
+			name:  "unknown tier",
+			tiers: []model.LicenseTier{{Tier: "stems", Deliverables: []model.MediaType{model.MediaTypeArchive}}},
+		},
+		{
+			name: "duplicate tier",
+			tiers: []model.LicenseTier{
+				{Tier: generated.LicenseTierWavLease, Deliverables: []model.MediaType{model.MediaTypeFile}},
+				{Tier: generated.LicenseTierWavLease, Deliverables: []model.MediaType{model.MediaTypeFile}},
+			},
+		},
+		{
+			name:  "negative price",
+			tiers: []model.LicenseTier{{Tier: generated.LicenseTierWavLease, Price: -1, Deliverables: []model.MediaType{model.MediaTypeFile}}},
+		},
+		{
+			name:  "zero cap",
+			tiers: []model.LicenseTier{{Tier: generated.LicenseTierWavLease, Deliverables: []model.MediaType{model.MediaTypeFile}, DistributionCap: &zero}},
+		},
+		{
+			name:  "exclusive sales cap",
+			tiers: []model.LicenseTier{{Tier: generated.LicenseTierExclusive, Deliverables: []model.MediaType{model.MediaTypeArchive}, SalesCap: &zero}},
+		},
+		{
+			name:  "zero sales cap",
+			tiers: []model.LicenseTier{{Tier: generated.LicenseTierMp3Lease, Deliverables: []model.MediaType{model.MediaTypeFile}, SalesCap: &zero}},
+		},
+		{
+			name:  "no deliverables",
+			tiers: []model.LicenseTier{{Tier: generated.LicenseTierTrackout}},
+		},
+		{
+			name:  "image deliverable",
+			tiers: []model.LicenseTier{{Tier: generated.LicenseTierTrackout, Deliverables: []model.MediaType{model.MediaTypeImage}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createLicenseService(t)
+			s.licenseProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{}, nil).Once()
+
+			_, err := s.licenseService.SetLicenseTiers(context.Background(), uuid.New(), true, uuid.New(), tt.tiers)
+			assert.ErrorIs(t, err, model.ErrValidationFailed)
+		})
+	}
+}
+
+func TestGetLicenseTiers_FailDeleted(t *testing.T) {
+	t.Parallel()
+
+	s := createLicenseService(t)
+
+	s.licenseProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{IsDeleted: true}, nil).Once()
+
+	_, err := s.licenseService.GetLicenseTiers(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, model.ErrBeatNotFound)
+}
+
+func TestVerifyLicense_Success(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "number", payload: "BF-0123456789AB"},
+		{name: "lowercase number", payload: " bf-0123456789ab "},
+		{name: "qr payload", payload: model.LicenseVerificationURL("https://beatflow.example", "BF-0123456789AB")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createLicenseService(t)
+
+			license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierExclusive, Number: "BF-0123456789AB", Price: 50000}
+			beat := generated.Beat{ID: license.BeatID, BeatmakerID: uuid.New(), Name: "Night Drive", IsDeleted: true}
+			users := map[uuid.UUID]*userv1.GetUserResponse{
+				license.UserID:   {Username: "artist", FirstName: "Ivan", LastName: "Petrov"},
+				beat.BeatmakerID: {Username: "maker", Pseudonym: "DJ Maker"},
+			}
+
+			s.licenseProvider.On("GetLicenseByNumber", mock.Anything, "BF-0123456789AB").Return(&license, nil).Once()
+			s.licenseProvider.On("GetBeatByID", mock.Anything, license.BeatID).Return(&beat, nil).Once()
+			s.userProvider.On("GetUsers", mock.Anything, []uuid.UUID{license.UserID, beat.BeatmakerID}).Return(users).Once()
+
+			res, err := s.licenseService.VerifyLicense(context.Background(), tt.payload)
+			require.NoError(t, err)
+			assert.Equal(t, "Night Drive", res.Beat)
+			assert.Equal(t, "DJ Maker", res.Beatmaker)
+			assert.Equal(t, "artist", res.Licensee)
+			assert.Equal(t, generated.LicenseTierExclusive, res.Tier)
+			assert.True(t, res.Valid)
+		})
+	}
+}
+
+func TestVerifyLicense_SuccessRevoked(t *testing.T) {
+	t.Parallel()
+
+	s := createLicenseService(t)
+
+	revokedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierWavLease, Number: "BF-0123456789AB",
+		RevokedAt: pgtype.Timestamp{Time: revokedAt, Valid: true}}
+
+	s.licenseProvider.On("GetLicenseByNumber", mock.Anything, license.Number).Return(&license, nil).Once()
+	s.licenseProvider.On("GetBeatByID", mock.Anything, license.BeatID).Return(&generated.Beat{ID: license.BeatID}, nil).Once()
+	s.userProvider.On("GetUsers", mock.Anything, mock.Anything).Return(nil).Once()
+
+	res, err := s.licenseService.VerifyLicense(context.Background(), license.Number)
+	require.NoError(t, err)
+	assert.False(t, res.Valid)
+	assert.Equal(t, revokedAt, *res.RevokedAt)
+}
+
+func TestVerifyLicense_Fail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid number", func(t *testing.T) {
+		t.Parallel()
+
+		s := createLicenseService(t)
+
+		_, err := s.licenseService.VerifyLicense(context.Background(), "https://beatflow.example/v1/beats/search?number=BF-0123456789AB")
+		assert.ErrorIs(t, err, model.ErrValidationFailed)
+	})
+
+	t.Run("license not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := createLicenseService(t)
+		s.licenseProvider.On("GetLicenseByNumber", mock.Anything, mock.Anything).Return(nil, &model.ModelError{Err: model.ErrLicenseNotFound}).Once()
+
+		_, err := s.licenseService.VerifyLicense(context.Background(), "BF-0123456789AB")
+		assert.ErrorIs(t, err, model.ErrLicenseNotFound)
+	})
+}
+
+func TestRevokeLicense_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createLicenseService(t)
+
+	adminID := uuid.New()
+	license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierExclusive, Number: "BF-0123456789AB"}
+	revoked := license
+	revoked.RevokedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+	reason := "chargeback"
+	checkoutID := "cs_1"
+	paid := generated.Order{ID: uuid.New(), Provider: payment.FakeName, CheckoutID: &checkoutID, Status: generated.OrderStatusPaid}
+
+	s.licenseProvider.On("GetLicenseByNumber", mock.Anything, license.Number).Return(&license, nil).Once()
+	s.licenseProvider.On("GetPaidLicenseOrders", mock.Anything, generated.GetPaidLicenseOrdersParams{BeatID: license.BeatID, UserID: license.UserID, Tier: license.Tier}).
+		Return([]generated.Order{paid}, nil).Once()
+	s.paymentProvider.On("Name").Return(payment.FakeName).Once()
+	s.paymentProvider.On("Refund", mock.Anything, checkoutID).Return(nil).Once()
+	s.licenseModifier.On("RevokeLicense", mock.Anything, model.RevokeLicense{
+		Audit: generated.SaveLicenseAuditParams{
+			BeatID:  license.BeatID,
+			UserID:  license.UserID,
+			Tier:    license.Tier,
+			Action:  generated.LicenseAuditActionRevoke,
+			ActorID: pgtype.UUID{Bytes: adminID, Valid: true},
+			Reason:  &reason,
+		},
+		RestoreBeat: true,
+	}).Return(&revoked, nil).Once()
+
+	res, err := s.licenseService.RevokeLicense(context.Background(), adminID, license.Number, " chargeback ", true, true)
+	require.NoError(t, err)
+	assert.True(t, res.RevokedAt.Valid)
+}
+
+func TestRevokeLicense_SuccessWithoutRefund(t *testing.T) {
+	t.Parallel()
+
+	s := createLicenseService(t)
+
+	license := generated.BeatLicense{BeatID: uuid.New(), UserID: uuid.New(), Tier: generated.LicenseTierMp3Lease, Number: "BF-0123456789AB"}
+	revoked := license
+	revoked.RevokedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	// The chargeback returned the money already, the payment provider is not asked.
+	s.licenseProvider.On("GetLicenseByNumber", mock.Anything, license.Number).Return(&license, nil).Once()
+	s.licenseModifier.On("RevokeLicense", mock.Anything, mock.Anything).Return(&revoked, nil).Once()
+
+	res, err := s.licenseService.RevokeLicense(context.Background(), uuid.New(), license.Number, "chargeback", false, false)
+	require.NoError(t, err)
+	assert.True(t, res.RevokedAt.Valid)
+}
+
+func TestRevokeLicense_Fail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty reason", func(t *testing.T) {
+		t.Parallel()
+
+		s := createLicenseService(t)
+
+		_, err := s.licenseService.RevokeLicense(context.Background(), uuid.New(), "BF-0123456789AB", " ", false, false)
+		assert.ErrorIs(t, err, model.ErrValidationFailed)
+	})
+
+	t.Run("restore beat of lease", func(t *testing.T) {
+		t.Parallel()
+
+		s := createLicenseService(t)
+		license := generated.BeatLicense{Tier: generated.LicenseTierMp3Lease, Number: "BF-0123456789AB"}
+		s.licenseProvider.On("GetLicenseByNumber", mock.Anything, license.Number).Return(&license, nil).Once()
+
+		_, err := s.licenseService.RevokeLicense(context.Background(), uuid.New(), license.Number, "refund", true, false)
+		assert.ErrorIs(t, err, model.ErrValidationFailed)
+	})
+
+	t.Run("already revoked", func(t *testing.T) {
+		t.Parallel()
+
+		s := createLicenseService(t)
+		license := generated.BeatLicense{Tier: generated.LicenseTierMp3Lease, Number: "BF-0123456789AB"}
+		s.licenseProvider.On("GetLicenseByNumber", mock.Anything, license.Number).Return(&license, nil).Once()
+		s.licenseModifier.On("RevokeLicense", mock.Anything, mock.Anything).Return(nil, &model.ModelError{Err: model.ErrLicenseRevoked}).Once()
+
+		_, err := s.licenseService.RevokeLicense(context.Background(), uuid.New(), license.Number, "chargeback", false, false)
+		assert.ErrorIs(t, err, model.ErrLicenseRevoked)
+	})
+
+	t.Run("refund failed", func(t *testing.T) {
+		t.Parallel()
+
+		s := createLicenseService(t)
+		checkoutID := "cs_1"
+		license := generated.BeatLicense{Tier: generated.LicenseTierMp3Lease, Number: "BF-0123456789AB"}
+		s.licenseProvider.On("GetLicenseByNumber", mock.Anything, license.Number).Return(&license, nil).Once()
+		s.licenseProvider.On("GetPaidLicenseOrders", mock.Anything, mock.Anything).
+			Return([]generated.Order{{Provider: payment.FakeName, CheckoutID: &checkoutID}}, nil).Once()
+		s.paymentProvider.On("Name").Return(payment.FakeName).Once()
+		s.paymentProvider.On("Refund", mock.Anything, checkoutID).Return(payment.ErrCheckoutNotFound).Once()
+
+		// The license stays valid until its buyer got the money back.
+		_, err := s.licenseService.RevokeLicense(context.Background(), uuid.New(), license.Number, "refund", false, true)
+		assert.ErrorIs(t, err, payment.ErrCheckoutNotFound)
+	})
+}
@@ -0,0 +1,98 @@
+package beat
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name LikeModifier
+type LikeModifier interface {
+	SaveLike(ctx context.Context, arg generated.SaveLikeParams) error
+	DeleteLike(ctx context.Context, arg generated.DeleteLikeParams) error
+}
+
+//go:generate mockery --name LikeProvider
+type LikeProvider interface {
+	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
+	GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
+	CountBeatLikes(ctx context.Context, beatID uuid.UUID) (int64, error)
+}
+
+type LikeService struct {
+	likeModifier LikeModifier
+	likeProvider LikeProvider
+	log          *slog.Logger
+}
+
+func NewLikeService(
+	likeModifier LikeModifier,
+	likeProvider LikeProvider,
+	log *slog.Logger,
+) *LikeService {
+	return &LikeService{
+		likeModifier: likeModifier,
+		likeProvider: likeProvider,
+		log:          log,
+	}
+}
+
+// LikeBeat likes the beat on behalf of the user and returns the like count of the beat.
+// Liking a beat twice is a no-op.
+func (s *LikeService) LikeBeat(ctx context.Context, userID, beatID uuid.UUID) (int64, error) {
+	beat, err := s.likeProvider.GetBeatByID(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return 0, err
+	}
+
+	if beat.IsDeleted {
+		s.log.Debug("beat is deleted", slog.String("beat_id", beatID.String()))
+		return 0, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	if err := s.likeModifier.SaveLike(ctx, generated.SaveLikeParams{BeatID: beatID, UserID: userID}); err != nil {
+		s.log.Error("failed to save like", sl.Err(err))
+		return 0, err
+	}
+
+	return s.countLikes(ctx, beatID)
+}
+
+// UnlikeBeat removes the like of the user and returns the like count of the beat.
+// Unliking a beat that is not liked is a no-op.
+func (s *LikeService) UnlikeBeat(ctx context.Context, userID, beatID uuid.UUID) (int64, error) {
+	if err := s.likeModifier.DeleteLike(ctx, generated.DeleteLikeParams{BeatID: beatID, UserID: userID}); err != nil {
+		s.log.Error("failed to delete like", sl.Err(err))
+		return 0, err
+	}
+
+	return s.countLikes(ctx, beatID)
+}
+
+// GetLikedBeats returns the beats liked by the user that match params.
+func (s *LikeService) GetLikedBeats(ctx context.Context, userID uuid.UUID, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error) {
+	params.LikedBy = &userID
+
+	beats, total, err = s.likeProvider.GetBeats(ctx, params)
+	if err != nil {
+		s.log.Error("failed to get liked beats", sl.Err(err))
+		return nil, nil, err
+	}
+
+	return beats, total, nil
+}
+
+func (s *LikeService) countLikes(ctx context.Context, beatID uuid.UUID) (int64, error) {
+	likes, err := s.likeProvider.CountBeatLikes(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to count likes", sl.Err(err))
+		return 0, err
+	}
+
+	return likes, nil
+}
@@ -0,0 +1,108 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type likeDependencies struct {
+	likeService  *LikeService
+	likeModifier *mocks.LikeModifier
+	likeProvider *mocks.LikeProvider
+}
+
+func createLikeService(t *testing.T) likeDependencies {
+	t.Helper()
+
+	likeModifier := mocks.NewLikeModifier(t)
+	likeProvider := mocks.NewLikeProvider(t)
+
+	return likeDependencies{
+		likeService:  NewLikeService(likeModifier, likeProvider, slogdiscard.NewDiscardLogger()),
+		likeModifier: likeModifier,
+		likeProvider: likeProvider,
+	}
+}
+
+func TestLikeBeat_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createLikeService(t)
+
+	userID, beatID := uuid.New(), uuid.New()
+
+	s.likeProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID}, nil).Once()
+	s.likeModifier.On("SaveLike", mock.Anything, generated.SaveLikeParams{BeatID: beatID, UserID: userID}).Return(nil).Once()
+	s.likeProvider.On("CountBeatLikes", mock.Anything, beatID).Return(int64(3), nil).Once()
+
+	likes, err := s.likeService.LikeBeat(context.Background(), userID, beatID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), likes)
+}
+
+func TestLikeBeat_FailDeleted(t *testing.T) {
+	t.Parallel()
+
+	s := createLikeService(t)
+
+	s.likeProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{IsDeleted: true}, nil).Once()
+
+	_, err := s.likeService.LikeBeat(context.Background(), uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, model.ErrBeatNotFound)
+}
+
+func TestLikeBeat_Fail(t *testing.T) {
+	t.Parallel()
+
+	s := createLikeService(t)
+
+	expErr := errors.New("internal error")
+
+	s.likeProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{}, nil).Once()
+	s.likeModifier.On("SaveLike", mock.Anything, mock.Anything).Return(expErr).Once()
+
+	_, err := s.likeService.LikeBeat(context.Background(), uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, expErr)
+}
+
+func TestUnlikeBeat_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createLikeService(t)
+
+	userID, beatID := uuid.New(), uuid.New()
+
+	s.likeModifier.On("DeleteLike", mock.Anything, generated.DeleteLikeParams{BeatID: beatID, UserID: userID}).Return(nil).Once()
+	s.likeProvider.On("CountBeatLikes", mock.Anything, beatID).Return(int64(0), nil).Once()
+
+	likes, err := s.likeService.UnlikeBeat(context.Background(), userID, beatID)
+	require.NoError(t, err)
+	assert.Zero(t, likes)
+}
+
+func TestGetLikedBeats_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createLikeService(t)
+
+	userID := uuid.New()
+	beats := []model.Beat{{ID: uuid.New(), Likes: 1}}
+	total := uint64(1)
+
+	s.likeProvider.On("GetBeats", mock.Anything, model.GetBeatsParams{Genre: []string{"Trap"}, Limit: 10, LikedBy: &userID}).Return(beats, &total, nil).Once()
+
+	res, resTotal, err := s.likeService.GetLikedBeats(context.Background(), userID, model.GetBeatsParams{Genre: []string{"Trap"}, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, beats, res)
+	assert.Equal(t, total, *resTotal)
+}
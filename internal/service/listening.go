@@ -0,0 +1,135 @@
+package beat
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ListeningServiceConfig struct {
+	qualifiedPlayThreshold time.Duration
+	heartbeatSlack         time.Duration
+}
+
+func NewListeningServiceConfig(qualifiedPlayThreshold, heartbeatSlack time.Duration) *ListeningServiceConfig {
+	return &ListeningServiceConfig{
+		qualifiedPlayThreshold: qualifiedPlayThreshold,
+		heartbeatSlack:         heartbeatSlack,
+	}
+}
+
+//go:generate mockery --name ListeningSessionModifier
+type ListeningSessionModifier interface {
+	SaveListeningSession(ctx context.Context, arg generated.SaveListeningSessionParams) error
+	UpdateListeningSession(ctx context.Context, arg generated.UpdateListeningSessionParams) (*generated.UpdateListeningSessionRow, error)
+	SaveSignal(ctx context.Context, signal generated.SaveSignalParams) error
+}
+
+//go:generate mockery --name PlaysProvider
+type PlaysProvider interface {
+	GetBeatPlays(ctx context.Context, beatmakerID uuid.UUID) ([]generated.GetBeatPlaysRow, error)
+}
+
+type ListeningService struct {
+	sessionModifier ListeningSessionModifier
+	playsProvider   PlaysProvider
+	config          *ListeningServiceConfig
+	log             *slog.Logger
+}
+
+func NewListeningService(
+	sessionModifier ListeningSessionModifier,
+	playsProvider PlaysProvider,
+	config *ListeningServiceConfig,
+	log *slog.Logger,
+) *ListeningService {
+	return &ListeningService{
+		sessionModifier: sessionModifier,
+		playsProvider:   playsProvider,
+		config:          config,
+		log:             log,
+	}
+}
+
+// StartSession opens the listening session, or does nothing if a session with the same id
+// is already open, so that range requests of one playback share a session. A new id is
+// generated if session has none.
+func (s *ListeningService) StartSession(ctx context.Context, session model.ListeningSession) (uuid.UUID, error) {
+	if session.ID == uuid.Nil {
+		session.ID = uuid.New()
+	}
+
+	var userID pgtype.UUID
+	if session.UserID != nil {
+		userID = pgtype.UUID{Bytes: *session.UserID, Valid: true}
+	}
+
+	if err := s.sessionModifier.SaveListeningSession(ctx, generated.SaveListeningSessionParams{
+		ID:     session.ID,
+		BeatID: session.BeatID,
+		UserID: userID,
+		Source: session.Source,
+		Client: session.Client,
+	}); err != nil {
+		s.log.Error("failed to save listening session", sl.Err(err))
+		return uuid.Nil, err
+	}
+
+	return session.ID, nil
+}
+
+// Heartbeat records how many seconds of the beat were played in the session. The played
+// seconds never decrease and can not outrun the time since the session started. The
+// session becomes a qualified play once it reaches the threshold, which is counted as a
+// play signal exactly once.
+func (s *ListeningService) Heartbeat(ctx context.Context, hb model.Heartbeat) (*model.ListeningProgress, error) {
+	row, err := s.sessionModifier.UpdateListeningSession(ctx, generated.UpdateListeningSessionParams{
+		ID:            hb.SessionID,
+		BeatID:        hb.BeatID,
+		SecondsPlayed: hb.SecondsPlayed,
+		Slack:         int32(s.config.heartbeatSlack.Seconds()),
+		Threshold:     int32(s.config.qualifiedPlayThreshold.Seconds()),
+	})
+	if err != nil {
+		s.log.Error("failed to update listening session", sl.Err(err))
+		return nil, err
+	}
+
+	if row.IsQualified && !row.WasQualified {
+		if err := s.sessionModifier.SaveSignal(ctx, generated.SaveSignalParams{BeatID: hb.BeatID, Kind: generated.BeatSignalPlay}); err != nil {
+			s.log.Error("failed to save signal", sl.Err(err))
+		}
+	}
+
+	return &model.ListeningProgress{
+		SecondsPlayed: row.SecondsPlayed,
+		IsQualified:   row.IsQualified,
+	}, nil
+}
+
+func (s *ListeningService) GetBeatPlays(ctx context.Context, beatmakerID uuid.UUID) ([]model.BeatPlays, error) {
+	rows, err := s.playsProvider.GetBeatPlays(ctx, beatmakerID)
+	if err != nil {
+		s.log.Error("failed to get beat plays", sl.Err(err))
+		return nil, err
+	}
+
+	plays := make([]model.BeatPlays, 0, len(rows))
+	for _, row := range rows {
+		plays = append(plays, model.BeatPlays{
+			BeatID:        row.ID,
+			Name:          row.Name,
+			Plays:         row.Plays,
+			Sessions:      row.Sessions,
+			SecondsPlayed: row.SecondsPlayed,
+		})
+	}
+
+	return plays, nil
+}
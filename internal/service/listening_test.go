@@ -0,0 +1,153 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type listeningDependencies struct {
+	listeningService *ListeningService
+	sessionModifier  *mocks.ListeningSessionModifier
+	playsProvider    *mocks.PlaysProvider
+}
+
+func createListeningService(t *testing.T) listeningDependencies {
+	t.Helper()
+
+	sessionModifier := mocks.NewListeningSessionModifier(t)
+	playsProvider := mocks.NewPlaysProvider(t)
+	config := NewListeningServiceConfig(30*time.Second, 10*time.Second)
+
+	return listeningDependencies{
+		listeningService: NewListeningService(sessionModifier, playsProvider, config, slogdiscard.NewDiscardLogger()),
+		sessionModifier:  sessionModifier,
+		playsProvider:    playsProvider,
+	}
+}
+
+func TestStartSession_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createListeningService(t)
+
+	userID := uuid.New()
+	session := model.ListeningSession{
+		ID:     uuid.New(),
+		BeatID: uuid.New(),
+		UserID: &userID,
+		Source: "home",
+		Client: "web",
+	}
+
+	s.sessionModifier.On("SaveListeningSession", mock.Anything, generated.SaveListeningSessionParams{
+		ID:     session.ID,
+		BeatID: session.BeatID,
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Source: session.Source,
+		Client: session.Client,
+	}).Return(nil).Once()
+
+	id, err := s.listeningService.StartSession(context.Background(), session)
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, id)
+}
+
+func TestStartSession_SuccessNewID(t *testing.T) {
+	t.Parallel()
+
+	s := createListeningService(t)
+
+	s.sessionModifier.On("SaveListeningSession", mock.Anything, mock.MatchedBy(func(arg generated.SaveListeningSessionParams) bool {
+		return arg.ID != uuid.Nil && !arg.UserID.Valid
+	})).Return(nil).Once()
+
+	id, err := s.listeningService.StartSession(context.Background(), model.ListeningSession{BeatID: uuid.New()})
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, id)
+}
+
+func TestHeartbeat_SuccessQualified(t *testing.T) {
+	t.Parallel()
+
+	s := createListeningService(t)
+
+	hb := model.Heartbeat{SessionID: uuid.New(), BeatID: uuid.New(), SecondsPlayed: 31}
+
+	s.sessionModifier.On("UpdateListeningSession", mock.Anything, generated.UpdateListeningSessionParams{
+		ID:            hb.SessionID,
+		BeatID:        hb.BeatID,
+		SecondsPlayed: 31,
+		Slack:         10,
+		Threshold:     30,
+	}).Return(&generated.UpdateListeningSessionRow{SecondsPlayed: 31, IsQualified: true}, nil).Once()
+	s.sessionModifier.On("SaveSignal", mock.Anything, generated.SaveSignalParams{BeatID: hb.BeatID, Kind: generated.BeatSignalPlay}).Return(nil).Once()
+
+	res, err := s.listeningService.Heartbeat(context.Background(), hb)
+	require.NoError(t, err)
+	assert.Equal(t, model.ListeningProgress{SecondsPlayed: 31, IsQualified: true}, *res)
+}
+
+func TestHeartbeat_SuccessAlreadyQualified(t *testing.T) {
+	t.Parallel()
+
+	s := createListeningService(t)
+
+	s.sessionModifier.On("UpdateListeningSession", mock.Anything, mock.Anything).
+		Return(&generated.UpdateListeningSessionRow{SecondsPlayed: 60, IsQualified: true, WasQualified: true}, nil).Once()
+
+	res, err := s.listeningService.Heartbeat(context.Background(), model.Heartbeat{SecondsPlayed: 60})
+	require.NoError(t, err)
+	assert.True(t, res.IsQualified)
+}
+
+func TestHeartbeat_FailSessionNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := createListeningService(t)
+
+	s.sessionModifier.On("UpdateListeningSession", mock.Anything, mock.Anything).
+		Return(nil, &model.ModelError{Err: model.ErrSessionNotFound}).Once()
+
+	_, err := s.listeningService.Heartbeat(context.Background(), model.Heartbeat{})
+	assert.ErrorIs(t, err, model.ErrSessionNotFound)
+}
+
+func TestGetBeatPlays_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createListeningService(t)
+
+	beatmakerID := uuid.New()
+	row := generated.GetBeatPlaysRow{ID: uuid.New(), Name: "beat", Plays: 3, Sessions: 5, SecondsPlayed: 140}
+
+	s.playsProvider.On("GetBeatPlays", mock.Anything, beatmakerID).Return([]generated.GetBeatPlaysRow{row}, nil).Once()
+
+	res, err := s.listeningService.GetBeatPlays(context.Background(), beatmakerID)
+	require.NoError(t, err)
+	assert.Equal(t, []model.BeatPlays{{BeatID: row.ID, Name: "beat", Plays: 3, Sessions: 5, SecondsPlayed: 140}}, res)
+}
+
+func TestGetBeatPlays_Fail(t *testing.T) {
+	t.Parallel()
+
+	s := createListeningService(t)
+
+	expErr := errors.New("internal error")
+
+	s.playsProvider.On("GetBeatPlays", mock.Anything, mock.Anything).Return(nil, expErr).Once()
+
+	_, err := s.listeningService.GetBeatPlays(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, expErr)
+}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AgreementIssuer is an autogenerated mock type for the AgreementIssuer type
+type AgreementIssuer struct {
+	mock.Mock
+}
+
+// IssueAgreement provides a mock function with given fields: ctx, license
+func (_m *AgreementIssuer) IssueAgreement(ctx context.Context, license generated.BeatLicense) (string, error) {
+	ret := _m.Called(ctx, license)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueAgreement")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.BeatLicense) (string, error)); ok {
+		return rf(ctx, license)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.BeatLicense) string); ok {
+		r0 = rf(ctx, license)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.BeatLicense) error); ok {
+		r1 = rf(ctx, license)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAgreementIssuer creates a new instance of AgreementIssuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAgreementIssuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AgreementIssuer {
+	mock := &AgreementIssuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
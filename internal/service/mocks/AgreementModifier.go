@@ -0,0 +1,47 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AgreementModifier is an autogenerated mock type for the AgreementModifier type
+type AgreementModifier struct {
+	mock.Mock
+}
+
+// SetLicenseAgreement provides a mock function with given fields: ctx, arg
+func (_m *AgreementModifier) SetLicenseAgreement(ctx context.Context, arg generated.SetLicenseAgreementParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLicenseAgreement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SetLicenseAgreementParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAgreementModifier creates a new instance of AgreementModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAgreementModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AgreementModifier {
+	mock := &AgreementModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
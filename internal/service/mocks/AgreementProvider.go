@@ -0,0 +1,61 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// AgreementProvider is an autogenerated mock type for the AgreementProvider type
+type AgreementProvider struct {
+	mock.Mock
+}
+
+// GetBeatByID provides a mock function with given fields: ctx, id
+func (_m *AgreementProvider) GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatByID")
+	}
+
+	var r0 *generated.Beat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*generated.Beat, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *generated.Beat); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAgreementProvider creates a new instance of AgreementProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAgreementProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AgreementProvider {
+	mock := &AgreementProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	agreement "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/agreement"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AgreementRenderer is an autogenerated mock type for the AgreementRenderer type
+type AgreementRenderer struct {
+	mock.Mock
+}
+
+// Render provides a mock function with given fields: w, data
+func (_m *AgreementRenderer) Render(w io.Writer, data agreement.Data) error {
+	ret := _m.Called(w, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Render")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(io.Writer, agreement.Data) error); ok {
+		r0 = rf(w, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAgreementRenderer creates a new instance of AgreementRenderer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAgreementRenderer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AgreementRenderer {
+	mock := &AgreementRenderer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -54,17 +54,54 @@ func (_m *BeatModifier) SaveBeat(ctx context.Context, _a1 model.SaveBeat) error
 	return r0
 }
 
-// SaveOwner provides a mock function with given fields: ctx, owner
-func (_m *BeatModifier) SaveOwner(ctx context.Context, owner generated.SaveOwnerParams) error {
-	ret := _m.Called(ctx, owner)
+// SaveLicense provides a mock function with given fields: ctx, sale
+func (_m *BeatModifier) SaveLicense(ctx context.Context, sale model.SaveLicense) (*generated.BeatLicense, bool, error) {
+	ret := _m.Called(ctx, sale)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveOwner")
+		panic("no return value specified for SaveLicense")
+	}
+
+	var r0 *generated.BeatLicense
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SaveLicense) (*generated.BeatLicense, bool, error)); ok {
+		return rf(ctx, sale)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.SaveLicense) *generated.BeatLicense); ok {
+		r0 = rf(ctx, sale)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.BeatLicense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.SaveLicense) bool); ok {
+		r1 = rf(ctx, sale)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.SaveLicense) error); ok {
+		r2 = rf(ctx, sale)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SaveSignal provides a mock function with given fields: ctx, signal
+func (_m *BeatModifier) SaveSignal(ctx context.Context, signal generated.SaveSignalParams) error {
+	ret := _m.Called(ctx, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSignal")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveOwnerParams) error); ok {
-		r0 = rf(ctx, owner)
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveSignalParams) error); ok {
+		r0 = rf(ctx, signal)
 	} else {
 		r0 = ret.Error(0)
 	}
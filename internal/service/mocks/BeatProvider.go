@@ -18,6 +18,36 @@ type BeatProvider struct {
 	mock.Mock
 }
 
+// GetAcquisition provides a mock function with given fields: ctx, arg
+func (_m *BeatProvider) GetAcquisition(ctx context.Context, arg generated.GetAcquisitionParams) (*generated.BeatAcquisition, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAcquisition")
+	}
+
+	var r0 *generated.BeatAcquisition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetAcquisitionParams) (*generated.BeatAcquisition, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetAcquisitionParams) *generated.BeatAcquisition); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.BeatAcquisition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.GetAcquisitionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBeatByID provides a mock function with given fields: ctx, id
 func (_m *BeatProvider) GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error) {
 	ret := _m.Called(ctx, id)
@@ -48,9 +78,69 @@ func (_m *BeatProvider) GetBeatByID(ctx context.Context, id uuid.UUID) (*generat
 	return r0, r1
 }
 
-// GetBeatParams provides a mock function with given fields: ctx
-func (_m *BeatProvider) GetBeatParams(ctx context.Context) (*model.BeatAttributes, error) {
-	ret := _m.Called(ctx)
+// GetBeatFacets provides a mock function with given fields: ctx, params
+func (_m *BeatProvider) GetBeatFacets(ctx context.Context, params model.GetBeatsParams) (*model.Facets, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatFacets")
+	}
+
+	var r0 *model.Facets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) (*model.Facets, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) *model.Facets); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Facets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetBeatsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeatLicenses provides a mock function with given fields: ctx, beatID
+func (_m *BeatProvider) GetBeatLicenses(ctx context.Context, beatID uuid.UUID) ([]generated.BeatLicense, error) {
+	ret := _m.Called(ctx, beatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatLicenses")
+	}
+
+	var r0 []generated.BeatLicense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]generated.BeatLicense, error)); ok {
+		return rf(ctx, beatID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []generated.BeatLicense); ok {
+		r0 = rf(ctx, beatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.BeatLicense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, beatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeatParams provides a mock function with given fields: ctx, locales
+func (_m *BeatProvider) GetBeatParams(ctx context.Context, locales []string) (*model.BeatAttributes, error) {
+	ret := _m.Called(ctx, locales)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBeatParams")
@@ -58,19 +148,19 @@ func (_m *BeatProvider) GetBeatParams(ctx context.Context) (*model.BeatAttribute
 
 	var r0 *model.BeatAttributes
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*model.BeatAttributes, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (*model.BeatAttributes, error)); ok {
+		return rf(ctx, locales)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *model.BeatAttributes); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) *model.BeatAttributes); ok {
+		r0 = rf(ctx, locales)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.BeatAttributes)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, locales)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -117,24 +207,24 @@ func (_m *BeatProvider) GetBeats(ctx context.Context, params model.GetBeatsParam
 	return r0, r1, r2
 }
 
-// GetOwnerByBeatID provides a mock function with given fields: ctx, beatID
-func (_m *BeatProvider) GetOwnerByBeatID(ctx context.Context, beatID uuid.UUID) (*generated.BeatsOwner, error) {
+// GetLicenseTiers provides a mock function with given fields: ctx, beatID
+func (_m *BeatProvider) GetLicenseTiers(ctx context.Context, beatID uuid.UUID) ([]generated.BeatsLicenseTier, error) {
 	ret := _m.Called(ctx, beatID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOwnerByBeatID")
+		panic("no return value specified for GetLicenseTiers")
 	}
 
-	var r0 *generated.BeatsOwner
+	var r0 []generated.BeatsLicenseTier
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*generated.BeatsOwner, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]generated.BeatsLicenseTier, error)); ok {
 		return rf(ctx, beatID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *generated.BeatsOwner); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []generated.BeatsLicenseTier); ok {
 		r0 = rf(ctx, beatID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*generated.BeatsOwner)
+			r0 = ret.Get(0).([]generated.BeatsLicenseTier)
 		}
 	}
 
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BeatmakerModifier is an autogenerated mock type for the BeatmakerModifier type
+type BeatmakerModifier struct {
+	mock.Mock
+}
+
+// SetPinnedBeats provides a mock function with given fields: ctx, beatmakerID, beatIDs
+func (_m *BeatmakerModifier) SetPinnedBeats(ctx context.Context, beatmakerID uuid.UUID, beatIDs []uuid.UUID) error {
+	ret := _m.Called(ctx, beatmakerID, beatIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPinnedBeats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID) error); ok {
+		r0 = rf(ctx, beatmakerID, beatIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBeatmakerModifier creates a new instance of BeatmakerModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBeatmakerModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BeatmakerModifier {
+	mock := &BeatmakerModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// BeatmakerProvider is an autogenerated mock type for the BeatmakerProvider type
+type BeatmakerProvider struct {
+	mock.Mock
+}
+
+// GetBeatmakerGenres provides a mock function with given fields: ctx, beatmakerID, locales
+func (_m *BeatmakerProvider) GetBeatmakerGenres(ctx context.Context, beatmakerID uuid.UUID, locales []string) ([]model.FacetCount, error) {
+	ret := _m.Called(ctx, beatmakerID, locales)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatmakerGenres")
+	}
+
+	var r0 []model.FacetCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) ([]model.FacetCount, error)); ok {
+		return rf(ctx, beatmakerID, locales)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) []model.FacetCount); ok {
+		r0 = rf(ctx, beatmakerID, locales)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FacetCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []string) error); ok {
+		r1 = rf(ctx, beatmakerID, locales)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeatmakerStats provides a mock function with given fields: ctx, beatmakerID
+func (_m *BeatmakerProvider) GetBeatmakerStats(ctx context.Context, beatmakerID uuid.UUID) (generated.GetBeatmakerStatsRow, error) {
+	ret := _m.Called(ctx, beatmakerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatmakerStats")
+	}
+
+	var r0 generated.GetBeatmakerStatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (generated.GetBeatmakerStatsRow, error)); ok {
+		return rf(ctx, beatmakerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) generated.GetBeatmakerStatsRow); ok {
+		r0 = rf(ctx, beatmakerID)
+	} else {
+		r0 = ret.Get(0).(generated.GetBeatmakerStatsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, beatmakerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeats provides a mock function with given fields: ctx, params
+func (_m *BeatmakerProvider) GetBeats(ctx context.Context, params model.GetBeatsParams) ([]model.Beat, *uint64, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeats")
+	}
+
+	var r0 []model.Beat
+	var r1 *uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) ([]model.Beat, *uint64, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) []model.Beat); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetBeatsParams) *uint64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.GetBeatsParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewBeatmakerProvider creates a new instance of BeatmakerProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBeatmakerProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BeatmakerProvider {
+	mock := &BeatmakerProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
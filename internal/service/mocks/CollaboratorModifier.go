@@ -0,0 +1,61 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// CollaboratorModifier is an autogenerated mock type for the CollaboratorModifier type
+type CollaboratorModifier struct {
+	mock.Mock
+}
+
+// SetBeatCollaborators provides a mock function with given fields: ctx, beatID, collaborators
+func (_m *CollaboratorModifier) SetBeatCollaborators(ctx context.Context, beatID uuid.UUID, collaborators []generated.SaveBeatCollaboratorParams) ([]generated.BeatsCollaborator, error) {
+	ret := _m.Called(ctx, beatID, collaborators)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBeatCollaborators")
+	}
+
+	var r0 []generated.BeatsCollaborator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []generated.SaveBeatCollaboratorParams) ([]generated.BeatsCollaborator, error)); ok {
+		return rf(ctx, beatID, collaborators)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []generated.SaveBeatCollaboratorParams) []generated.BeatsCollaborator); ok {
+		r0 = rf(ctx, beatID, collaborators)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.BeatsCollaborator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []generated.SaveBeatCollaboratorParams) error); ok {
+		r1 = rf(ctx, beatID, collaborators)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCollaboratorModifier creates a new instance of CollaboratorModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCollaboratorModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CollaboratorModifier {
+	mock := &CollaboratorModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
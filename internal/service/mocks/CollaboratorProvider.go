@@ -0,0 +1,121 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// CollaboratorProvider is an autogenerated mock type for the CollaboratorProvider type
+type CollaboratorProvider struct {
+	mock.Mock
+}
+
+// GetBeatByID provides a mock function with given fields: ctx, id
+func (_m *CollaboratorProvider) GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatByID")
+	}
+
+	var r0 *generated.Beat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*generated.Beat, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *generated.Beat); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeatCollaborators provides a mock function with given fields: ctx, beatID
+func (_m *CollaboratorProvider) GetBeatCollaborators(ctx context.Context, beatID uuid.UUID) ([]generated.BeatsCollaborator, error) {
+	ret := _m.Called(ctx, beatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatCollaborators")
+	}
+
+	var r0 []generated.BeatsCollaborator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]generated.BeatsCollaborator, error)); ok {
+		return rf(ctx, beatID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []generated.BeatsCollaborator); ok {
+		r0 = rf(ctx, beatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.BeatsCollaborator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, beatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCollaboratorEarnings provides a mock function with given fields: ctx, collaboratorID
+func (_m *CollaboratorProvider) GetCollaboratorEarnings(ctx context.Context, collaboratorID uuid.UUID) ([]generated.GetCollaboratorEarningsRow, error) {
+	ret := _m.Called(ctx, collaboratorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollaboratorEarnings")
+	}
+
+	var r0 []generated.GetCollaboratorEarningsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]generated.GetCollaboratorEarningsRow, error)); ok {
+		return rf(ctx, collaboratorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []generated.GetCollaboratorEarningsRow); ok {
+		r0 = rf(ctx, collaboratorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.GetCollaboratorEarningsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, collaboratorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCollaboratorProvider creates a new instance of CollaboratorProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCollaboratorProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CollaboratorProvider {
+	mock := &CollaboratorProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
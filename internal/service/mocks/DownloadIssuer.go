@@ -0,0 +1,59 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+)
+
+// DownloadIssuer is an autogenerated mock type for the DownloadIssuer type
+type DownloadIssuer struct {
+	mock.Mock
+}
+
+// IssueDownload provides a mock function with given fields: ctx, license, _a2, client
+func (_m *DownloadIssuer) IssueDownload(ctx context.Context, license generated.BeatLicense, _a2 *generated.Beat, client model.DownloadClient) (string, error) {
+	ret := _m.Called(ctx, license, _a2, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueDownload")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.BeatLicense, *generated.Beat, model.DownloadClient) (string, error)); ok {
+		return rf(ctx, license, _a2, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.BeatLicense, *generated.Beat, model.DownloadClient) string); ok {
+		r0 = rf(ctx, license, _a2, client)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.BeatLicense, *generated.Beat, model.DownloadClient) error); ok {
+		r1 = rf(ctx, license, _a2, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDownloadIssuer creates a new instance of DownloadIssuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDownloadIssuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DownloadIssuer {
+	mock := &DownloadIssuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+)
+
+// DownloadModifier is an autogenerated mock type for the DownloadModifier type
+type DownloadModifier struct {
+	mock.Mock
+}
+
+// IssueDownload provides a mock function with given fields: ctx, issue
+func (_m *DownloadModifier) IssueDownload(ctx context.Context, issue model.IssueDownload) (string, error) {
+	ret := _m.Called(ctx, issue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueDownload")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.IssueDownload) (string, error)); ok {
+		return rf(ctx, issue)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.IssueDownload) string); ok {
+		r0 = rf(ctx, issue)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.IssueDownload) error); ok {
+		r1 = rf(ctx, issue)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetDownloads provides a mock function with given fields: ctx, audit
+func (_m *DownloadModifier) ResetDownloads(ctx context.Context, audit generated.SaveLicenseAuditParams) error {
+	ret := _m.Called(ctx, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetDownloads")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveLicenseAuditParams) error); ok {
+		r0 = rf(ctx, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDownloadModifier creates a new instance of DownloadModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDownloadModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DownloadModifier {
+	mock := &DownloadModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,151 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// DownloadProvider is an autogenerated mock type for the DownloadProvider type
+type DownloadProvider struct {
+	mock.Mock
+}
+
+// GetBeatByID provides a mock function with given fields: ctx, id
+func (_m *DownloadProvider) GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatByID")
+	}
+
+	var r0 *generated.Beat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*generated.Beat, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *generated.Beat); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeatLicenses provides a mock function with given fields: ctx, beatID
+func (_m *DownloadProvider) GetBeatLicenses(ctx context.Context, beatID uuid.UUID) ([]generated.BeatLicense, error) {
+	ret := _m.Called(ctx, beatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatLicenses")
+	}
+
+	var r0 []generated.BeatLicense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]generated.BeatLicense, error)); ok {
+		return rf(ctx, beatID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []generated.BeatLicense); ok {
+		r0 = rf(ctx, beatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.BeatLicense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, beatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLicenseAudit provides a mock function with given fields: ctx, arg
+func (_m *DownloadProvider) GetLicenseAudit(ctx context.Context, arg generated.GetLicenseAuditParams) ([]generated.LicenseAudit, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLicenseAudit")
+	}
+
+	var r0 []generated.LicenseAudit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetLicenseAuditParams) ([]generated.LicenseAudit, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetLicenseAuditParams) []generated.LicenseAudit); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.LicenseAudit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.GetLicenseAuditParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLicenseByNumber provides a mock function with given fields: ctx, number
+func (_m *DownloadProvider) GetLicenseByNumber(ctx context.Context, number string) (*generated.BeatLicense, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLicenseByNumber")
+	}
+
+	var r0 *generated.BeatLicense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*generated.BeatLicense, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *generated.BeatLicense); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.BeatLicense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDownloadProvider creates a new instance of DownloadProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDownloadProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DownloadProvider {
+	mock := &DownloadProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FeedProvider is an autogenerated mock type for the FeedProvider type
+type FeedProvider struct {
+	mock.Mock
+}
+
+// GetBeats provides a mock function with given fields: ctx, params
+func (_m *FeedProvider) GetBeats(ctx context.Context, params model.GetBeatsParams) ([]model.Beat, *uint64, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeats")
+	}
+
+	var r0 []model.Beat
+	var r1 *uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) ([]model.Beat, *uint64, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) []model.Beat); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetBeatsParams) *uint64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.GetBeatsParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTaxonomy provides a mock function with given fields: ctx, kind
+func (_m *FeedProvider) GetTaxonomy(ctx context.Context, kind model.TaxonomyKind) ([]model.TaxonomyEntry, error) {
+	ret := _m.Called(ctx, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaxonomy")
+	}
+
+	var r0 []model.TaxonomyEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind) ([]model.TaxonomyEntry, error)); ok {
+		return rf(ctx, kind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind) []model.TaxonomyEntry); ok {
+		r0 = rf(ctx, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TaxonomyEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TaxonomyKind) error); ok {
+		r1 = rf(ctx, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFeedProvider creates a new instance of FeedProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFeedProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FeedProvider {
+	mock := &FeedProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// LicenseModifier is an autogenerated mock type for the LicenseModifier type
+type LicenseModifier struct {
+	mock.Mock
+}
+
+// RevokeLicense provides a mock function with given fields: ctx, revocation
+func (_m *LicenseModifier) RevokeLicense(ctx context.Context, revocation model.RevokeLicense) (*generated.BeatLicense, error) {
+	ret := _m.Called(ctx, revocation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeLicense")
+	}
+
+	var r0 *generated.BeatLicense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.RevokeLicense) (*generated.BeatLicense, error)); ok {
+		return rf(ctx, revocation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.RevokeLicense) *generated.BeatLicense); ok {
+		r0 = rf(ctx, revocation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.BeatLicense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.RevokeLicense) error); ok {
+		r1 = rf(ctx, revocation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetLicenseTiers provides a mock function with given fields: ctx, beatID, tiers
+func (_m *LicenseModifier) SetLicenseTiers(ctx context.Context, beatID uuid.UUID, tiers []generated.SaveLicenseTierParams) ([]generated.BeatsLicenseTier, error) {
+	ret := _m.Called(ctx, beatID, tiers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLicenseTiers")
+	}
+
+	var r0 []generated.BeatsLicenseTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []generated.SaveLicenseTierParams) ([]generated.BeatsLicenseTier, error)); ok {
+		return rf(ctx, beatID, tiers)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []generated.SaveLicenseTierParams) []generated.BeatsLicenseTier); ok {
+		r0 = rf(ctx, beatID, tiers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.BeatsLicenseTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []generated.SaveLicenseTierParams) error); ok {
+		r1 = rf(ctx, beatID, tiers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLicenseModifier creates a new instance of LicenseModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLicenseModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LicenseModifier {
+	mock := &LicenseModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,151 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// LicenseProvider is an autogenerated mock type for the LicenseProvider type
+type LicenseProvider struct {
+	mock.Mock
+}
+
+// GetBeatByID provides a mock function with given fields: ctx, id
+func (_m *LicenseProvider) GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatByID")
+	}
+
+	var r0 *generated.Beat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*generated.Beat, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *generated.Beat); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLicenseByNumber provides a mock function with given fields: ctx, number
+func (_m *LicenseProvider) GetLicenseByNumber(ctx context.Context, number string) (*generated.BeatLicense, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLicenseByNumber")
+	}
+
+	var r0 *generated.BeatLicense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*generated.BeatLicense, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *generated.BeatLicense); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.BeatLicense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLicenseTiers provides a mock function with given fields: ctx, beatID
+func (_m *LicenseProvider) GetLicenseTiers(ctx context.Context, beatID uuid.UUID) ([]generated.BeatsLicenseTier, error) {
+	ret := _m.Called(ctx, beatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLicenseTiers")
+	}
+
+	var r0 []generated.BeatsLicenseTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]generated.BeatsLicenseTier, error)); ok {
+		return rf(ctx, beatID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []generated.BeatsLicenseTier); ok {
+		r0 = rf(ctx, beatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.BeatsLicenseTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, beatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaidLicenseOrders provides a mock function with given fields: ctx, arg
+func (_m *LicenseProvider) GetPaidLicenseOrders(ctx context.Context, arg generated.GetPaidLicenseOrdersParams) ([]generated.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaidLicenseOrders")
+	}
+
+	var r0 []generated.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetPaidLicenseOrdersParams) ([]generated.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetPaidLicenseOrdersParams) []generated.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.GetPaidLicenseOrdersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLicenseProvider creates a new instance of LicenseProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLicenseProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LicenseProvider {
+	mock := &LicenseProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LikeModifier is an autogenerated mock type for the LikeModifier type
+type LikeModifier struct {
+	mock.Mock
+}
+
+// DeleteLike provides a mock function with given fields: ctx, arg
+func (_m *LikeModifier) DeleteLike(ctx context.Context, arg generated.DeleteLikeParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLike")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.DeleteLikeParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveLike provides a mock function with given fields: ctx, arg
+func (_m *LikeModifier) SaveLike(ctx context.Context, arg generated.SaveLikeParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLike")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveLikeParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLikeModifier creates a new instance of LikeModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLikeModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LikeModifier {
+	mock := &LikeModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
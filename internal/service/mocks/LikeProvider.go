@@ -0,0 +1,130 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// LikeProvider is an autogenerated mock type for the LikeProvider type
+type LikeProvider struct {
+	mock.Mock
+}
+
+// CountBeatLikes provides a mock function with given fields: ctx, beatID
+func (_m *LikeProvider) CountBeatLikes(ctx context.Context, beatID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, beatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBeatLikes")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, beatID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, beatID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, beatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeatByID provides a mock function with given fields: ctx, id
+func (_m *LikeProvider) GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatByID")
+	}
+
+	var r0 *generated.Beat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*generated.Beat, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *generated.Beat); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeats provides a mock function with given fields: ctx, params
+func (_m *LikeProvider) GetBeats(ctx context.Context, params model.GetBeatsParams) ([]model.Beat, *uint64, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeats")
+	}
+
+	var r0 []model.Beat
+	var r1 *uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) ([]model.Beat, *uint64, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) []model.Beat); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetBeatsParams) *uint64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.GetBeatsParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewLikeProvider creates a new instance of LikeProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLikeProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LikeProvider {
+	mock := &LikeProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
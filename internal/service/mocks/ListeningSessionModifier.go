@@ -0,0 +1,95 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ListeningSessionModifier is an autogenerated mock type for the ListeningSessionModifier type
+type ListeningSessionModifier struct {
+	mock.Mock
+}
+
+// SaveListeningSession provides a mock function with given fields: ctx, arg
+func (_m *ListeningSessionModifier) SaveListeningSession(ctx context.Context, arg generated.SaveListeningSessionParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveListeningSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveListeningSessionParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSignal provides a mock function with given fields: ctx, signal
+func (_m *ListeningSessionModifier) SaveSignal(ctx context.Context, signal generated.SaveSignalParams) error {
+	ret := _m.Called(ctx, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSignal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveSignalParams) error); ok {
+		r0 = rf(ctx, signal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateListeningSession provides a mock function with given fields: ctx, arg
+func (_m *ListeningSessionModifier) UpdateListeningSession(ctx context.Context, arg generated.UpdateListeningSessionParams) (*generated.UpdateListeningSessionRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateListeningSession")
+	}
+
+	var r0 *generated.UpdateListeningSessionRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.UpdateListeningSessionParams) (*generated.UpdateListeningSessionRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.UpdateListeningSessionParams) *generated.UpdateListeningSessionRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.UpdateListeningSessionRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.UpdateListeningSessionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewListeningSessionModifier creates a new instance of ListeningSessionModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListeningSessionModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListeningSessionModifier {
+	mock := &ListeningSessionModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
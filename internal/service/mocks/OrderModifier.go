@@ -0,0 +1,166 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// OrderModifier is an autogenerated mock type for the OrderModifier type
+type OrderModifier struct {
+	mock.Mock
+}
+
+// ConfirmOrder provides a mock function with given fields: ctx, arg
+func (_m *OrderModifier) ConfirmOrder(ctx context.Context, arg model.ConfirmOrder) (*generated.Order, bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmOrder")
+	}
+
+	var r0 *generated.Order
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ConfirmOrder) (*generated.Order, bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.ConfirmOrder) *generated.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.ConfirmOrder) bool); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.ConfirmOrder) error); ok {
+		r2 = rf(ctx, arg)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FailOrder provides a mock function with given fields: ctx, id
+func (_m *OrderModifier) FailOrder(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveOrder provides a mock function with given fields: ctx, arg
+func (_m *OrderModifier) SaveOrder(ctx context.Context, arg generated.SaveOrderParams) (*generated.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveOrder")
+	}
+
+	var r0 *generated.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveOrderParams) (*generated.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveOrderParams) *generated.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.SaveOrderParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveSignal provides a mock function with given fields: ctx, signal
+func (_m *OrderModifier) SaveSignal(ctx context.Context, signal generated.SaveSignalParams) error {
+	ret := _m.Called(ctx, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSignal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveSignalParams) error); ok {
+		r0 = rf(ctx, signal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetOrderCheckout provides a mock function with given fields: ctx, arg
+func (_m *OrderModifier) SetOrderCheckout(ctx context.Context, arg generated.SetOrderCheckoutParams) (*generated.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOrderCheckout")
+	}
+
+	var r0 *generated.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SetOrderCheckoutParams) (*generated.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SetOrderCheckoutParams) *generated.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.SetOrderCheckoutParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOrderModifier creates a new instance of OrderModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrderModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrderModifier {
+	mock := &OrderModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
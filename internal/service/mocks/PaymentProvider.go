@@ -0,0 +1,127 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+
+	payment "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/payment"
+)
+
+// PaymentProvider is an autogenerated mock type for the PaymentProvider type
+type PaymentProvider struct {
+	mock.Mock
+}
+
+// CreateCheckout provides a mock function with given fields: ctx, checkout
+func (_m *PaymentProvider) CreateCheckout(ctx context.Context, checkout payment.Checkout) (*payment.Session, error) {
+	ret := _m.Called(ctx, checkout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCheckout")
+	}
+
+	var r0 *payment.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, payment.Checkout) (*payment.Session, error)); ok {
+		return rf(ctx, checkout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, payment.Checkout) *payment.Session); ok {
+		r0 = rf(ctx, checkout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, payment.Checkout) error); ok {
+		r1 = rf(ctx, checkout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with no fields
+func (_m *PaymentProvider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ParseWebhook provides a mock function with given fields: payload, header
+func (_m *PaymentProvider) ParseWebhook(payload []byte, header http.Header) (*payment.Event, error) {
+	ret := _m.Called(payload, header)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseWebhook")
+	}
+
+	var r0 *payment.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, http.Header) (*payment.Event, error)); ok {
+		return rf(payload, header)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, http.Header) *payment.Event); ok {
+		r0 = rf(payload, header)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, http.Header) error); ok {
+		r1 = rf(payload, header)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refund provides a mock function with given fields: ctx, checkoutID
+func (_m *PaymentProvider) Refund(ctx context.Context, checkoutID string) error {
+	ret := _m.Called(ctx, checkoutID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, checkoutID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentProvider creates a new instance of PaymentProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentProvider {
+	mock := &PaymentProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
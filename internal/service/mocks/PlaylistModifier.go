@@ -0,0 +1,163 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// PlaylistModifier is an autogenerated mock type for the PlaylistModifier type
+type PlaylistModifier struct {
+	mock.Mock
+}
+
+// AddPlaylistBeat provides a mock function with given fields: ctx, arg
+func (_m *PlaylistModifier) AddPlaylistBeat(ctx context.Context, arg model.AddPlaylistBeat) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPlaylistBeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.AddPlaylistBeat) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePlaylist provides a mock function with given fields: ctx, id
+func (_m *PlaylistModifier) DeletePlaylist(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePlaylist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemovePlaylistBeat provides a mock function with given fields: ctx, playlistID, beatID
+func (_m *PlaylistModifier) RemovePlaylistBeat(ctx context.Context, playlistID uuid.UUID, beatID uuid.UUID) error {
+	ret := _m.Called(ctx, playlistID, beatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePlaylistBeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, playlistID, beatID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderPlaylistBeats provides a mock function with given fields: ctx, playlistID, beatIDs
+func (_m *PlaylistModifier) ReorderPlaylistBeats(ctx context.Context, playlistID uuid.UUID, beatIDs []uuid.UUID) error {
+	ret := _m.Called(ctx, playlistID, beatIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderPlaylistBeats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID) error); ok {
+		r0 = rf(ctx, playlistID, beatIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavePlaylist provides a mock function with given fields: ctx, arg
+func (_m *PlaylistModifier) SavePlaylist(ctx context.Context, arg generated.SavePlaylistParams) (generated.Playlist, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePlaylist")
+	}
+
+	var r0 generated.Playlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SavePlaylistParams) (generated.Playlist, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SavePlaylistParams) generated.Playlist); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(generated.Playlist)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.SavePlaylistParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePlaylist provides a mock function with given fields: ctx, arg
+func (_m *PlaylistModifier) UpdatePlaylist(ctx context.Context, arg generated.UpdatePlaylistParams) (*generated.Playlist, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePlaylist")
+	}
+
+	var r0 *generated.Playlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.UpdatePlaylistParams) (*generated.Playlist, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.UpdatePlaylistParams) *generated.Playlist); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.Playlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.UpdatePlaylistParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPlaylistModifier creates a new instance of PlaylistModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPlaylistModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PlaylistModifier {
+	mock := &PlaylistModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
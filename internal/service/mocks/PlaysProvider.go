@@ -0,0 +1,61 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// PlaysProvider is an autogenerated mock type for the PlaysProvider type
+type PlaysProvider struct {
+	mock.Mock
+}
+
+// GetBeatPlays provides a mock function with given fields: ctx, beatmakerID
+func (_m *PlaysProvider) GetBeatPlays(ctx context.Context, beatmakerID uuid.UUID) ([]generated.GetBeatPlaysRow, error) {
+	ret := _m.Called(ctx, beatmakerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatPlays")
+	}
+
+	var r0 []generated.GetBeatPlaysRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]generated.GetBeatPlaysRow, error)); ok {
+		return rf(ctx, beatmakerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []generated.GetBeatPlaysRow); ok {
+		r0 = rf(ctx, beatmakerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.GetBeatPlaysRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, beatmakerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPlaysProvider creates a new instance of PlaysProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPlaysProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PlaysProvider {
+	mock := &PlaysProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// SalesProvider is an autogenerated mock type for the SalesProvider type
+type SalesProvider struct {
+	mock.Mock
+}
+
+// GetSalesReport provides a mock function with given fields: ctx, arg
+func (_m *SalesProvider) GetSalesReport(ctx context.Context, arg generated.GetSalesReportParams) ([]generated.GetSalesReportRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSalesReport")
+	}
+
+	var r0 []generated.GetSalesReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetSalesReportParams) ([]generated.GetSalesReportRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetSalesReportParams) []generated.GetSalesReportRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.GetSalesReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.GetSalesReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserPurchases provides a mock function with given fields: ctx, userID
+func (_m *SalesProvider) GetUserPurchases(ctx context.Context, userID uuid.UUID) ([]generated.GetUserPurchasesRow, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserPurchases")
+	}
+
+	var r0 []generated.GetUserPurchasesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]generated.GetUserPurchasesRow, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []generated.GetUserPurchasesRow); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.GetUserPurchasesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSalesProvider creates a new instance of SalesProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSalesProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SalesProvider {
+	mock := &SalesProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SimilarBeatsProvider is an autogenerated mock type for the SimilarBeatsProvider type
+type SimilarBeatsProvider struct {
+	mock.Mock
+}
+
+// GetBeats provides a mock function with given fields: ctx, params
+func (_m *SimilarBeatsProvider) GetBeats(ctx context.Context, params model.GetBeatsParams) ([]model.Beat, *uint64, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeats")
+	}
+
+	var r0 []model.Beat
+	var r1 *uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) ([]model.Beat, *uint64, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetBeatsParams) []model.Beat); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetBeatsParams) *uint64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.GetBeatsParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSimilarBeats provides a mock function with given fields: ctx, _a1, weights, limit, locales
+func (_m *SimilarBeatsProvider) GetSimilarBeats(ctx context.Context, _a1 model.Beat, weights model.SimilarityWeights, limit uint64, locales []string) ([]model.Beat, error) {
+	ret := _m.Called(ctx, _a1, weights, limit, locales)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSimilarBeats")
+	}
+
+	var r0 []model.Beat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Beat, model.SimilarityWeights, uint64, []string) ([]model.Beat, error)); ok {
+		return rf(ctx, _a1, weights, limit, locales)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Beat, model.SimilarityWeights, uint64, []string) []model.Beat); ok {
+		r0 = rf(ctx, _a1, weights, limit, locales)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Beat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Beat, model.SimilarityWeights, uint64, []string) error); ok {
+		r1 = rf(ctx, _a1, weights, limit, locales)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSimilarBeatsProvider creates a new instance of SimilarBeatsProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSimilarBeatsProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SimilarBeatsProvider {
+	mock := &SimilarBeatsProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SuggestModifier is an autogenerated mock type for the SuggestModifier type
+type SuggestModifier struct {
+	mock.Mock
+}
+
+// SaveBeatmaker provides a mock function with given fields: ctx, arg
+func (_m *SuggestModifier) SaveBeatmaker(ctx context.Context, arg generated.SaveBeatmakerParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBeatmaker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveBeatmakerParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSuggestModifier creates a new instance of SuggestModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSuggestModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SuggestModifier {
+	mock := &SuggestModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
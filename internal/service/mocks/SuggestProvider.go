@@ -0,0 +1,90 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SuggestProvider is an autogenerated mock type for the SuggestProvider type
+type SuggestProvider struct {
+	mock.Mock
+}
+
+// GetBeatmakerIDs provides a mock function with given fields: ctx
+func (_m *SuggestProvider) GetBeatmakerIDs(ctx context.Context) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeatmakerIDs")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]uuid.UUID, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []uuid.UUID); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSuggestions provides a mock function with given fields: ctx, params
+func (_m *SuggestProvider) GetSuggestions(ctx context.Context, params model.GetSuggestionsParams) (*model.Suggestions, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuggestions")
+	}
+
+	var r0 *model.Suggestions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetSuggestionsParams) (*model.Suggestions, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetSuggestionsParams) *model.Suggestions); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Suggestions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetSuggestionsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSuggestProvider creates a new instance of SuggestProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSuggestProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SuggestProvider {
+	mock := &SuggestProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,61 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// TagModifier is an autogenerated mock type for the TagModifier type
+type TagModifier struct {
+	mock.Mock
+}
+
+// SetBeatTags provides a mock function with given fields: ctx, beatID, tags
+func (_m *TagModifier) SetBeatTags(ctx context.Context, beatID uuid.UUID, tags []generated.SaveTagParams) ([]generated.Tag, error) {
+	ret := _m.Called(ctx, beatID, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBeatTags")
+	}
+
+	var r0 []generated.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []generated.SaveTagParams) ([]generated.Tag, error)); ok {
+		return rf(ctx, beatID, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []generated.SaveTagParams) []generated.Tag); ok {
+		r0 = rf(ctx, beatID, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []generated.SaveTagParams) error); ok {
+		r1 = rf(ctx, beatID, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTagModifier creates a new instance of TagModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTagModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TagModifier {
+	mock := &TagModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
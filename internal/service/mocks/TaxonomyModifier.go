@@ -0,0 +1,172 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TaxonomyModifier is an autogenerated mock type for the TaxonomyModifier type
+type TaxonomyModifier struct {
+	mock.Mock
+}
+
+// DeleteTaxonomyEntry provides a mock function with given fields: ctx, kind, id
+func (_m *TaxonomyModifier) DeleteTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID) error {
+	ret := _m.Called(ctx, kind, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTaxonomyEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, uuid.UUID) error); ok {
+		r0 = rf(ctx, kind, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTaxonomyTranslation provides a mock function with given fields: ctx, kind, id, locale
+func (_m *TaxonomyModifier) DeleteTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, locale string) error {
+	ret := _m.Called(ctx, kind, id, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTaxonomyTranslation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, kind, id, locale)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeTaxonomyEntries provides a mock function with given fields: ctx, kind, sourceID, targetID
+func (_m *TaxonomyModifier) MergeTaxonomyEntries(ctx context.Context, kind model.TaxonomyKind, sourceID uuid.UUID, targetID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, kind, sourceID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeTaxonomyEntries")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, uuid.UUID, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, kind, sourceID, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, uuid.UUID, uuid.UUID) int64); ok {
+		r0 = rf(ctx, kind, sourceID, targetID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TaxonomyKind, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, kind, sourceID, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveTaxonomyEntry provides a mock function with given fields: ctx, kind, entry
+func (_m *TaxonomyModifier) SaveTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, entry model.SaveTaxonomyEntry) (*model.TaxonomyEntry, error) {
+	ret := _m.Called(ctx, kind, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTaxonomyEntry")
+	}
+
+	var r0 *model.TaxonomyEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, model.SaveTaxonomyEntry) (*model.TaxonomyEntry, error)); ok {
+		return rf(ctx, kind, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, model.SaveTaxonomyEntry) *model.TaxonomyEntry); ok {
+		r0 = rf(ctx, kind, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaxonomyEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TaxonomyKind, model.SaveTaxonomyEntry) error); ok {
+		r1 = rf(ctx, kind, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveTaxonomyTranslation provides a mock function with given fields: ctx, kind, id, locale, name
+func (_m *TaxonomyModifier) SaveTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, locale string, name string) error {
+	ret := _m.Called(ctx, kind, id, locale, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTaxonomyTranslation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, kind, id, locale, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTaxonomyEntry provides a mock function with given fields: ctx, kind, update
+func (_m *TaxonomyModifier) UpdateTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, update model.UpdateTaxonomyEntry) (*model.TaxonomyEntry, error) {
+	ret := _m.Called(ctx, kind, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTaxonomyEntry")
+	}
+
+	var r0 *model.TaxonomyEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, model.UpdateTaxonomyEntry) (*model.TaxonomyEntry, error)); ok {
+		return rf(ctx, kind, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind, model.UpdateTaxonomyEntry) *model.TaxonomyEntry); ok {
+		r0 = rf(ctx, kind, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaxonomyEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TaxonomyKind, model.UpdateTaxonomyEntry) error); ok {
+		r1 = rf(ctx, kind, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTaxonomyModifier creates a new instance of TaxonomyModifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaxonomyModifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaxonomyModifier {
+	mock := &TaxonomyModifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TaxonomyProvider is an autogenerated mock type for the TaxonomyProvider type
+type TaxonomyProvider struct {
+	mock.Mock
+}
+
+// GetTaxonomy provides a mock function with given fields: ctx, kind
+func (_m *TaxonomyProvider) GetTaxonomy(ctx context.Context, kind model.TaxonomyKind) ([]model.TaxonomyEntry, error) {
+	ret := _m.Called(ctx, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaxonomy")
+	}
+
+	var r0 []model.TaxonomyEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind) ([]model.TaxonomyEntry, error)); ok {
+		return rf(ctx, kind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TaxonomyKind) []model.TaxonomyEntry); ok {
+		r0 = rf(ctx, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TaxonomyEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TaxonomyKind) error); ok {
+		r1 = rf(ctx, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTaxonomyProvider creates a new instance of TaxonomyProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaxonomyProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaxonomyProvider {
+	mock := &TaxonomyProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
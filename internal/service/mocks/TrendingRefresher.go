@@ -0,0 +1,47 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	generated "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TrendingRefresher is an autogenerated mock type for the TrendingRefresher type
+type TrendingRefresher struct {
+	mock.Mock
+}
+
+// RefreshTrending provides a mock function with given fields: ctx, arg
+func (_m *TrendingRefresher) RefreshTrending(ctx context.Context, arg generated.RefreshTrendingParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshTrending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.RefreshTrendingParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTrendingRefresher creates a new instance of TrendingRefresher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTrendingRefresher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TrendingRefresher {
+	mock := &TrendingRefresher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
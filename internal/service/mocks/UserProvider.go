@@ -0,0 +1,51 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// UserProvider is an autogenerated mock type for the UserProvider type
+type UserProvider struct {
+	mock.Mock
+}
+
+// GetUsers provides a mock function with given fields: ctx, ids
+func (_m *UserProvider) GetUsers(ctx context.Context, ids []uuid.UUID) map[uuid.UUID]*userv1.GetUserResponse {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 map[uuid.UUID]*userv1.GetUserResponse
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) map[uuid.UUID]*userv1.GetUserResponse); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]*userv1.GetUserResponse)
+		}
+	}
+
+	return r0
+}
+
+// NewUserProvider creates a new instance of UserProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserProvider {
+	mock := &UserProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
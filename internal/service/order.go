@@ -0,0 +1,352 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/payment"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type OrderServiceConfig struct {
+	currency    string
+	urlTTL      int
+	checkoutTTL time.Duration
+}
+
+func NewOrderServiceConfig(currency string, urlTTL int, checkoutTTL time.Duration) *OrderServiceConfig {
+	return &OrderServiceConfig{
+		currency:    currency,
+		urlTTL:      urlTTL,
+		checkoutTTL: checkoutTTL,
+	}
+}
+
+//go:generate mockery --name OrderModifier
+type OrderModifier interface {
+	SaveOrder(ctx context.Context, arg generated.SaveOrderParams) (*generated.Order, error)
+	SetOrderCheckout(ctx context.Context, arg generated.SetOrderCheckoutParams) (*generated.Order, error)
+	FailOrder(ctx context.Context, id uuid.UUID) error
+	ConfirmOrder(ctx context.Context, arg model.ConfirmOrder) (*generated.Order, bool, error)
+	SaveSignal(ctx context.Context, signal generated.SaveSignalParams) error
+}
+
+//go:generate mockery --name OrderProvider
+type OrderProvider interface {
+	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
+	GetBeatLicenses(ctx context.Context, beatID uuid.UUID) ([]generated.BeatLicense, error)
+	GetLicenseTiers(ctx context.Context, beatID uuid.UUID) ([]generated.BeatsLicenseTier, error)
+	GetOrderByID(ctx context.Context, id uuid.UUID) (*generated.Order, error)
+	GetPendingOrder(ctx context.Context, arg generated.GetPendingOrderParams) (*generated.Order, error)
+}
+
+//go:generate mockery --name PaymentProvider
+type PaymentProvider interface {
+	Name() string
+	CreateCheckout(ctx context.Context, checkout payment.Checkout) (*payment.Session, error)
+	ParseWebhook(payload []byte, header http.Header) (*payment.Event, error)
+	Refund(ctx context.Context, checkoutID string) error
+}
+
+type OrderService struct {
+	orderModifier   OrderModifier
+	orderProvider   OrderProvider
+	urlProvider     URLProvider
+	paymentProvider PaymentProvider
+	downloadIssuer  DownloadIssuer
+	agreementIssuer AgreementIssuer
+	config          *OrderServiceConfig
+	log             *slog.Logger
+}
+
+func NewOrderService(
+	orderModifier OrderModifier,
+	orderProvider OrderProvider,
+	urlProvider URLProvider,
+	paymentProvider PaymentProvider,
+	downloadIssuer DownloadIssuer,
+	agreementIssuer AgreementIssuer,
+	config *OrderServiceConfig,
+	log *slog.Logger,
+) *OrderService {
+	return &OrderService{
+		orderModifier:   orderModifier,
+		orderProvider:   orderProvider,
+		urlProvider:     urlProvider,
+		paymentProvider: paymentProvider,
+		downloadIssuer:  downloadIssuer,
+		agreementIssuer: agreementIssuer,
+		config:          config,
+		log:             log,
+	}
+}
+
+// CreateOrder quotes the current terms of the tier of the beat to the user and starts
+// the checkout of the order with the payment provider. The order fails early if the
+// license cannot be sold now, the sale itself is checked again once the order is paid.
+// An order of the tier the user opened within the checkout TTL is returned instead of a
+// new one while its checkout is open. An order whose checkout could not be started fails.
+func (s *OrderService) CreateOrder(ctx context.Context, params model.CreateOrder) (*model.Order, error) {
+	beat, err := s.orderProvider.GetBeatByID(ctx, params.BeatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return nil, err
+	}
+
+	if beat.IsDeleted {
+		return nil, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	licenses, err := s.orderProvider.GetBeatLicenses(ctx, params.BeatID)
+	if err != nil {
+		s.log.Error("failed to get licenses", sl.Err(err))
+		return nil, err
+	}
+
+	tier, err := s.getLicenseTier(ctx, params.BeatID, params.Tier)
+	if err != nil {
+		return nil, err
+	}
+
+	sold := 0
+	for _, l := range licenses {
+		if l.UserID == params.UserID && l.Tier == params.Tier {
+			if l.RevokedAt.Valid {
+				return nil, &model.ModelError{Err: model.ErrLicenseRevoked}
+			}
+			return nil, &model.ModelError{Err: model.ErrLicenseOwned}
+		}
+		if l.RevokedAt.Valid {
+			continue
+		}
+		if !model.IsLease(l.Tier) {
+			return nil, model.NewErr(model.ErrInvalidOwner, "beat acquired by another owner")
+		}
+		if l.Tier == params.Tier {
+			sold++
+		}
+	}
+
+	if tier.SalesCap != nil && sold >= int(*tier.SalesCap) {
+		return nil, model.NewErr(model.ErrLicenseSoldOut, string(tier.Tier))
+	}
+
+	pending, err := s.orderProvider.GetPendingOrder(ctx, generated.GetPendingOrderParams{
+		UserID:    params.UserID,
+		BeatID:    params.BeatID,
+		Tier:      params.Tier,
+		CreatedAt: pgtype.Timestamp{Time: time.Now().UTC().Add(-s.config.checkoutTTL), Valid: true},
+	})
+	if err == nil {
+		if pending.CheckoutUrl != nil {
+			res := model.ToDomainOrder(*pending)
+			return &res, nil
+		}
+		// The checkout of the order is being started by another request.
+		return nil, model.NewErr(model.ErrOrderPending, fmt.Sprintf("order %s is not paid yet", pending.ID))
+	}
+	if !errors.Is(err, model.ErrOrderNotFound) {
+		s.log.Error("failed to get pending order", sl.Err(err))
+		return nil, err
+	}
+
+	if _, ok := deliverable(beat, tier.Deliverables); !ok {
+		return nil, &model.ModelError{Err: model.ErrArchiveNotFound}
+	}
+
+	order, err := s.orderModifier.SaveOrder(ctx, generated.SaveOrderParams{
+		UserID:          params.UserID,
+		BeatID:          params.BeatID,
+		Tier:            tier.Tier,
+		Price:           tier.Price,
+		Currency:        s.config.currency,
+		Deliverables:    tier.Deliverables,
+		StreamCap:       tier.StreamCap,
+		DistributionCap: tier.DistributionCap,
+		SalesCap:        tier.SalesCap,
+		Provider:        s.paymentProvider.Name(),
+	})
+	if err != nil {
+		s.log.Error("failed to save order", sl.Err(err))
+		return nil, err
+	}
+
+	session, err := s.paymentProvider.CreateCheckout(ctx, payment.Checkout{
+		OrderID:     order.ID,
+		Amount:      order.Price,
+		Currency:    order.Currency,
+		Description: fmt.Sprintf("%s, %s license", beat.Name, order.Tier),
+	})
+	if err != nil {
+		s.log.Error("failed to create checkout", sl.Err(err))
+		s.failOrder(ctx, order.ID)
+		return nil, err
+	}
+
+	withCheckout, err := s.orderModifier.SetOrderCheckout(ctx, generated.SetOrderCheckoutParams{
+		ID:          order.ID,
+		CheckoutID:  &session.ID,
+		CheckoutUrl: &session.URL,
+	})
+	if err != nil {
+		s.log.Error("failed to set order checkout", sl.Err(err))
+		s.failOrder(ctx, order.ID)
+		return nil, err
+	}
+	order = withCheckout
+
+	res := model.ToDomainOrder(*order)
+	return &res, nil
+}
+
+// GetOrder returns the order to its buyer or an admin. Once the order is paid, the buyer
+// gets the download URLs of the license and its agreement, issued to the client within
+// the download limit of the license.
+func (s *OrderService) GetOrder(ctx context.Context, userID uuid.UUID, isAdmin bool, id uuid.UUID, client model.DownloadClient) (*model.Order, *model.BeatArchive, error) {
+	order, err := s.orderProvider.GetOrderByID(ctx, id)
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to get order", sl.Err(err))
+		}
+		return nil, nil, err
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, nil, &model.ModelError{Err: model.ErrNotOrderOwner}
+	}
+
+	res := model.ToDomainOrder(*order)
+	if order.Status != generated.OrderStatusPaid || order.UserID != userID {
+		return &res, nil, nil
+	}
+
+	license, ok := s.getLicense(ctx, order)
+	if !ok {
+		return &res, nil, nil
+	}
+
+	beat, err := s.orderProvider.GetBeatByID(ctx, order.BeatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return nil, nil, err
+	}
+
+	url, err := s.downloadIssuer.IssueDownload(ctx, *license, beat, client)
+	if err != nil {
+		// The order is shown without the download once the deliverable is gone or the
+		// downloads are used up.
+		if errors.Is(err, model.ErrArchiveNotFound) || errors.Is(err, model.ErrDownloadLimit) {
+			return &res, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	archive := &model.BeatArchive{ArchiveURL: url}
+	if agreementPath, err := s.agreementIssuer.IssueAgreement(ctx, *license); err == nil {
+		ttl := time.Minute * time.Duration(s.config.urlTTL)
+		if archive.AgreementURL, err = s.urlProvider.GetDownloadMediaURL(ctx, agreementPath, ttl); err != nil {
+			s.log.Error("failed to get agreement url", sl.Err(err))
+		}
+	}
+
+	return &res, archive, nil
+}
+
+// HandleWebhook verifies the webhook of the payment provider and confirms the order of
+// its checkout. Redelivered webhooks return the order as the first one left it. The
+// payment of a rejected order is refunded, again on every redelivery until it succeeds.
+func (s *OrderService) HandleWebhook(ctx context.Context, provider string, payload []byte, header http.Header) (*model.Order, error) {
+	if provider != s.paymentProvider.Name() {
+		return nil, model.NewErr(model.ErrProviderNotFound, provider)
+	}
+
+	event, err := s.paymentProvider.ParseWebhook(payload, header)
+	if err != nil {
+		s.log.Debug("invalid webhook", sl.Err(err))
+		return nil, model.NewErr(model.ErrInvalidWebhook, err.Error())
+	}
+
+	order, confirmed, err := s.orderModifier.ConfirmOrder(ctx, model.ConfirmOrder{
+		Provider:   provider,
+		CheckoutID: event.CheckoutID,
+		Paid:       event.Kind == payment.EventPaid,
+	})
+	if err != nil {
+		var modelErr *model.ModelError
+		if !errors.As(err, &modelErr) {
+			s.log.Error("failed to confirm order", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	if order.Status == generated.OrderStatusRejected && event.Kind == payment.EventPaid {
+		if err := s.paymentProvider.Refund(ctx, event.CheckoutID); err != nil {
+			s.log.Error("failed to refund rejected order", slog.String("order_id", order.ID.String()), sl.Err(err))
+			return nil, err
+		}
+	}
+
+	if confirmed && order.Status == generated.OrderStatusPaid {
+		if err := s.orderModifier.SaveSignal(ctx, generated.SaveSignalParams{BeatID: order.BeatID, Kind: generated.BeatSignalAcquisition}); err != nil {
+			s.log.Error("failed to save signal", sl.Err(err))
+		}
+		// A failed agreement is rendered again on the next download, it does not fail the order.
+		if license, ok := s.getLicense(ctx, order); ok {
+			_, _ = s.agreementIssuer.IssueAgreement(ctx, *license)
+		}
+	}
+
+	res := model.ToDomainOrder(*order)
+	return &res, nil
+}
+
+// failOrder marks the order failed, so it does not hold back new orders of its tier.
+func (s *OrderService) failOrder(ctx context.Context, id uuid.UUID) {
+	if err := s.orderModifier.FailOrder(ctx, id); err != nil {
+		s.log.Error("failed to fail order", slog.String("order_id", id.String()), sl.Err(err))
+	}
+}
+
+// getLicense returns the license sold by the paid order, if it is still held.
+func (s *OrderService) getLicense(ctx context.Context, order *generated.Order) (*generated.BeatLicense, bool) {
+	licenses, err := s.orderProvider.GetBeatLicenses(ctx, order.BeatID)
+	if err != nil {
+		s.log.Error("failed to get licenses", sl.Err(err))
+		return nil, false
+	}
+
+	i := slices.IndexFunc(licenses, func(l generated.BeatLicense) bool {
+		return l.UserID == order.UserID && l.Tier == order.Tier
+	})
+	if i < 0 {
+		return nil, false
+	}
+
+	return &licenses[i], true
+}
+
+// getLicenseTier returns the tier of the beat. Unlike acquisitions by admins, orders
+// are only taken for the tiers the beatmaker has priced.
+func (s *OrderService) getLicenseTier(ctx context.Context, beatID uuid.UUID, tier generated.LicenseTier) (*generated.BeatsLicenseTier, error) {
+	tiers, err := s.orderProvider.GetLicenseTiers(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get license tiers", sl.Err(err))
+		return nil, err
+	}
+
+	if i := slices.IndexFunc(tiers, func(t generated.BeatsLicenseTier) bool { return t.Tier == tier }); i >= 0 {
+		return &tiers[i], nil
+	}
+
+	return nil, model.NewErr(model.ErrLicenseTierNotFound, fmt.Sprintf("beat is not sold under %s license", tier))
+}
@@ -0,0 +1,401 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/payment"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type orderDependencies struct {
+	orderService    *OrderService
+	orderModifier   *mocks.OrderModifier
+	orderProvider   *mocks.OrderProvider
+	urlProvider     *mocks.URLProvider
+	paymentProvider *mocks.PaymentProvider
+	downloadIssuer  *mocks.DownloadIssuer
+	agreementIssuer *mocks.AgreementIssuer
+}
+
+func createOrderService(t *testing.T) orderDependencies {
+	t.Helper()
+
+	orderModifier := mocks.NewOrderModifier(t)
+	orderProvider := mocks.NewOrderProvider(t)
+	urlProvider := mocks.NewURLProvider(t)
+	paymentProvider := mocks.NewPaymentProvider(t)
+	downloadIssuer := mocks.NewDownloadIssuer(t)
+	agreementIssuer := mocks.NewAgreementIssuer(t)
+
+	return orderDependencies{
+		orderService:    NewOrderService(orderModifier, orderProvider, urlProvider, paymentProvider, downloadIssuer, agreementIssuer, NewOrderServiceConfig("RUB", 5, 30*time.Minute), slogdiscard.NewDiscardLogger()),
+		orderModifier:   orderModifier,
+		orderProvider:   orderProvider,
+		urlProvider:     urlProvider,
+		paymentProvider: paymentProvider,
+		downloadIssuer:  downloadIssuer,
+		agreementIssuer: agreementIssuer,
+	}
+}
+
+func TestCreateOrder_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createOrderService(t)
+
+	params := model.CreateOrder{UserID: uuid.New(), BeatID: uuid.New(), Tier: generated.LicenseTierWavLease}
+	beat := generated.Beat{ID: params.BeatID, Name: "Night Drive", IsFileDownloaded: true}
+	tiers := []generated.BeatsLicenseTier{{BeatID: params.BeatID, Tier: generated.LicenseTierWavLease, Price: 3000, Deliverables: []string{"file"}}}
+	order := generated.Order{ID: uuid.New(), UserID: params.UserID, BeatID: params.BeatID, Tier: params.Tier, Price: 3000, Currency: "RUB"}
+	session := payment.Session{ID: "cs_1", URL: "/v1/payments/fake/checkout/cs_1"}
+
+	s.orderProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&beat, nil).Once()
+	s.orderProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(nil, nil).Once()
+	s.orderProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(tiers, nil).Once()
+	// Orders left pending longer than the checkout TTL were abandoned, they are not looked at.
+	s.orderProvider.On("GetPendingOrder", mock.Anything, mock.MatchedBy(func(arg generated.GetPendingOrderParams) bool {
+		cutoff := time.Now().UTC().Add(-30 * time.Minute)
+		return arg.UserID == params.UserID && arg.BeatID == params.BeatID && arg.Tier == params.Tier &&
+			arg.CreatedAt.Valid && cutoff.Sub(arg.CreatedAt.Time).Abs() < time.Minute
+	})).Return(nil, &model.ModelError{Err: model.ErrOrderNotFound}).Once()
+	s.paymentProvider.On("Name").Return(payment.FakeName).Once()
+	s.orderModifier.On("SaveOrder", mock.Anything, generated.SaveOrderParams{
+		UserID:       params.UserID,
+		BeatID:       params.BeatID,
+		Tier:         params.Tier,
+		Price:        3000,
+		Currency:     "RUB",
+		Deliverables: []string{"file"},
+		Provider:     payment.FakeName,
+	}).Return(&order, nil).Once()
+	s.paymentProvider.On("CreateCheckout", mock.Anything, payment.Checkout{
+		OrderID:     order.ID,
+		Amount:      3000,
+		Currency:    "RUB",
+		Description: "Night Drive, wav_lease license",
+	}).Return(&session, nil).Once()
+	withCheckout := order
+	withCheckout.CheckoutUrl = &session.URL
+	s.orderModifier.On("SetOrderCheckout", mock.Anything, generated.SetOrderCheckoutParams{ID: order.ID, CheckoutID: &session.ID, CheckoutUrl: &session.URL}).
+		Return(&withCheckout, nil).Once()
+
+	res, err := s.orderService.CreateOrder(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, session.URL, res.CheckoutURL)
+	assert.Equal(t, int64(3000), res.Price)
+}
+
+func TestCreateOrder_SuccessPending(t *testing.T) {
+	t.Parallel()
+
+	s := createOrderService(t)
+
+	params := model.CreateOrder{UserID: uuid.New(), BeatID: uuid.New(), Tier: generated.LicenseTierWavLease}
+	tiers := []generated.BeatsLicenseTier{{BeatID: params.BeatID, Tier: generated.LicenseTierWavLease, Price: 3000, Deliverables: []string{"file"}}}
+	checkoutURL := "/v1/payments/fake/checkout/cs_1"
+	pending := generated.Order{ID: uuid.New(), UserID: params.UserID, BeatID: params.BeatID, Tier: params.Tier, Status: generated.OrderStatusPending, CheckoutUrl: &checkoutURL}
+
+	s.orderProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&generated.Beat{ID: params.BeatID, IsFileDownloaded: true}, nil).Once()
+	s.orderProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(nil, nil).Once()
+	s.orderProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(tiers, nil).Once()
+	s.orderProvider.On("GetPendingOrder", mock.Anything, mock.Anything).Return(&pending, nil).Once()
+
+	// A retry gets the open checkout back instead of a second order.
+	res, err := s.orderService.CreateOrder(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, pending.ID, res.ID)
+	assert.Equal(t, checkoutURL, res.CheckoutURL)
+}
+
+func TestCreateOrder_FailCheckout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		checkoutErr error
+		setErr      error
+	}{
+		{name: "checkout not created", checkoutErr: errors.New("provider unavailable")},
+		{name: "checkout not saved", setErr: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createOrderService(t)
+
+			params := model.CreateOrder{UserID: uuid.New(), BeatID: uuid.New(), Tier: generated.LicenseTierWavLease}
+			tiers := []generated.BeatsLicenseTier{{BeatID: params.BeatID, Tier: generated.LicenseTierWavLease, Price: 3000, Deliverables: []string{"file"}}}
+			order := generated.Order{ID: uuid.New(), UserID: params.UserID, BeatID: params.BeatID, Tier: params.Tier, Status: generated.OrderStatusPending}
+
+			s.orderProvider.On("GetBeatByID", mock.Anything, params.BeatID).Return(&generated.Beat{ID: params.BeatID, IsFileDownloaded: true}, nil).Once()
+			s.orderProvider.On("GetBeatLicenses", mock.Anything, params.BeatID).Return(nil, nil).Once()
+			s.orderProvider.On("GetLicenseTiers", mock.Anything, params.BeatID).Return(tiers, nil).Once()
+			s.orderProvider.On("GetPendingOrder", mock.Anything, mock.Anything).Return(nil, &model.ModelError{Err: model.ErrOrderNotFound}).Once()
+			s.paymentProvider.On("Name").Return(payment.FakeName).Once()
+			s.orderModifier.On("SaveOrder", mock.Anything, mock.Anything).Return(&order, nil).Once()
+			if tt.checkoutErr != nil {
+				s.paymentProvider.On("CreateCheckout", mock.Anything, mock.Anything).Return(nil, tt.checkoutErr).Once()
+			} else {
+				s.paymentProvider.On("CreateCheckout", mock.Anything, mock.Anything).Return(&payment.Session{ID: "cs_1", URL: "url"}, nil).Once()
+				s.orderModifier.On("SetOrderCheckout", mock.Anything, mock.Anything).Return(nil, tt.setErr).Once()
+			}
+			// The order fails, so it does not block the next one.
+			s.orderModifier.On("FailOrder", mock.Anything, order.ID).Return(nil).Once()
+
+			_, err := s.orderService.CreateOrder(context.Background(), params)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCreateOrder_Fail(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	salesCap := int32(1)
+	tests := []struct {
+		name     string
+		tier     generated.LicenseTier
+		licenses []generated.BeatLicense
+		tiers    []generated.BeatsLicenseTier
+		pending  *generated.Order
+		err      error
+	}{
+		{
+			name:  "tier not priced",
+			tier:  generated.LicenseTierExclusive,
+			tiers: []generated.BeatsLicenseTier{{Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}},
+			err:   model.ErrLicenseTierNotFound,
+		},
+		{
+			name:     "license owned",
+			tier:     generated.LicenseTierMp3Lease,
+			licenses: []generated.BeatLicense{{UserID: userID, Tier: generated.LicenseTierMp3Lease}},
+			tiers:    []generated.BeatsLicenseTier{{Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}},
+			err:      model.ErrLicenseOwned,
+		},
+		{
+			name:     "license revoked",
+			tier:     generated.LicenseTierMp3Lease,
+			licenses: []generated.BeatLicense{{UserID: userID, Tier: generated.LicenseTierMp3Lease, RevokedAt: pgtype.Timestamp{Time: time.Now(), Valid: true}}},
+			tiers:    []generated.BeatsLicenseTier{{Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}},
+			err:      model.ErrLicenseRevoked,
+		},
+		{
+			name:     "sold exclusively",
+			tier:     generated.LicenseTierMp3Lease,
+			licenses: []generated.BeatLicense{{UserID: uuid.New(), Tier: generated.LicenseTierExclusive}},
+			tiers:    []generated.BeatsLicenseTier{{Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}},
+			err:      model.ErrInvalidOwner,
+		},
+		{
+			name:     "sold out",
+			tier:     generated.LicenseTierMp3Lease,
+			licenses: []generated.BeatLicense{{UserID: uuid.New(), Tier: generated.LicenseTierMp3Lease}},
+			tiers:    []generated.BeatsLicenseTier{{Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}, SalesCap: &salesCap}},
+			err:      model.ErrLicenseSoldOut,
+		},
+		{
+			name:    "order pending",
+			tier:    generated.LicenseTierMp3Lease,
+			tiers:   []generated.BeatsLicenseTier{{Tier: generated.LicenseTierMp3Lease, Deliverables: []string{"file"}}},
+			pending: &generated.Order{ID: uuid.New(), Status: generated.OrderStatusPending},
+			err:     model.ErrOrderPending,
+		},
+		{
+			name:  "deliverable not uploaded",
+			tier:  generated.LicenseTierTrackout,
+			tiers: []generated.BeatsLicenseTier{{Tier: generated.LicenseTierTrackout, Deliverables: []string{"archive"}}},
+			err:   model.ErrArchiveNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createOrderService(t)
+			s.orderProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{IsFileDownloaded: true}, nil).Once()
+			s.orderProvider.On("GetBeatLicenses", mock.Anything, mock.Anything).Return(tt.licenses, nil).Once()
+			s.orderProvider.On("GetLicenseTiers", mock.Anything, mock.Anything).Return(tt.tiers, nil).Once()
+			if tt.pending != nil {
+				s.orderProvider.On("GetPendingOrder", mock.Anything, mock.Anything).Return(tt.pending, nil).Once()
+			} else {
+				s.orderProvider.On("GetPendingOrder", mock.Anything, mock.Anything).Return(nil, &model.ModelError{Err: model.ErrOrderNotFound}).Maybe()
+			}
+
+			_, err := s.orderService.CreateOrder(context.Background(), model.CreateOrder{UserID: userID, BeatID: uuid.New(), Tier: tt.tier})
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestGetOrder_SuccessPaid(t *testing.T) {
+	t.Parallel()
+
+	s := createOrderService(t)
+
+	order := generated.Order{ID: uuid.New(), UserID: uuid.New(), BeatID: uuid.New(), Status: generated.OrderStatusPaid, Deliverables: []string{"file"}}
+	beat := generated.Beat{ID: order.BeatID, FilePath: uuid.NewString(), IsFileDownloaded: true}
+	license := generated.BeatLicense{BeatID: order.BeatID, UserID: order.UserID, Number: "BF-1"}
+	agreementPath := "licenses/BF-1.pdf"
+	client := model.DownloadClient{IP: "203.0.113.7", UserAgent: "curl/8.0"}
+
+	s.orderProvider.On("GetOrderByID", mock.Anything, order.ID).Return(&order, nil).Once()
+	s.orderProvider.On("GetBeatLicenses", mock.Anything, order.BeatID).Return([]generated.BeatLicense{license}, nil).Once()
+	s.orderProvider.On("GetBeatByID", mock.Anything, order.BeatID).Return(&beat, nil).Once()
+	s.downloadIssuer.On("IssueDownload", mock.Anything, license, &beat, client).Return(beat.FilePath, nil).Once()
+	s.agreementIssuer.On("IssueAgreement", mock.Anything, license).Return(agreementPath, nil).Once()
+	s.urlProvider.On("GetDownloadMediaURL", mock.Anything, agreementPath, mock.Anything).Return(&agreementPath, nil).Once()
+
+	res, archive, err := s.orderService.GetOrder(context.Background(), order.UserID, false, order.ID, client)
+	require.NoError(t, err)
+	assert.Equal(t, order.ID, res.ID)
+	assert.Equal(t, beat.FilePath, archive.ArchiveURL)
+	assert.Equal(t, agreementPath, *archive.AgreementURL)
+}
+
+func TestGetOrder_SuccessDownloadLimit(t *testing.T) {
+	t.Parallel()
+
+	s := createOrderService(t)
+
+	order := generated.Order{ID: uuid.New(), UserID: uuid.New(), BeatID: uuid.New(), Status: generated.OrderStatusPaid}
+	license := generated.BeatLicense{BeatID: order.BeatID, UserID: order.UserID}
+
+	s.orderProvider.On("GetOrderByID", mock.Anything, order.ID).Return(&order, nil).Once()
+	s.orderProvider.On("GetBeatLicenses", mock.Anything, order.BeatID).Return([]generated.BeatLicense{license}, nil).Once()
+	s.orderProvider.On("GetBeatByID", mock.Anything, order.BeatID).Return(&generated.Beat{}, nil).Once()
+	s.downloadIssuer.On("IssueDownload", mock.Anything, license, mock.Anything, mock.Anything).
+		Return("", &model.ModelError{Err: model.ErrDownloadLimit}).Once()
+
+	// The used up downloads do not hide the paid order.
+	res, archive, err := s.orderService.GetOrder(context.Background(), order.UserID, false, order.ID, model.DownloadClient{})
+	require.NoError(t, err)
+	assert.Equal(t, order.ID, res.ID)
+	assert.Nil(t, archive)
+}
+
+func TestGetOrder_FailNotOrderOwner(t *testing.T) {
+	t.Parallel()
+
+	s := createOrderService(t)
+
+	order := generated.Order{ID: uuid.New(), UserID: uuid.New(), Status: generated.OrderStatusPaid}
+	s.orderProvider.On("GetOrderByID", mock.Anything, order.ID).Return(&order, nil).Once()
+
+	_, _, err := s.orderService.GetOrder(context.Background(), uuid.New(), false, order.ID, model.DownloadClient{})
+	assert.ErrorIs(t, err, model.ErrNotOrderOwner)
+}
+
+func TestHandleWebhook_Success(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		confirmed bool
+		signal    bool
+		refund    bool
+	}{
+		{name: "paid", confirmed: true, signal: true},
+		{name: "redelivered", confirmed: false},
+		{name: "license held", confirmed: true, refund: true},
+		{name: "license held redelivered", confirmed: false, refund: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createOrderService(t)
+
+			payload := []byte(`{}`)
+			header := http.Header{}
+			order := generated.Order{ID: uuid.New(), BeatID: uuid.New(), Status: generated.OrderStatusPaid}
+			if tt.refund {
+				// The buyer got the license through another order, this payment goes back.
+				order.Status = generated.OrderStatusRejected
+				s.paymentProvider.On("Refund", mock.Anything, "cs_1").Return(nil).Once()
+			}
+
+			s.paymentProvider.On("Name").Return(payment.FakeName).Once()
+			s.paymentProvider.On("ParseWebhook", payload, header).Return(&payment.Event{Kind: payment.EventPaid, CheckoutID: "cs_1"}, nil).Once()
+			s.orderModifier.On("ConfirmOrder", mock.Anything, model.ConfirmOrder{Provider: payment.FakeName, CheckoutID: "cs_1", Paid: true}).
+				Return(&order, tt.confirmed, nil).Once()
+			if tt.signal {
+				s.orderModifier.On("SaveSignal", mock.Anything, generated.SaveSignalParams{BeatID: order.BeatID, Kind: generated.BeatSignalAcquisition}).Return(nil).Once()
+				s.orderProvider.On("GetBeatLicenses", mock.Anything, order.BeatID).Return(nil, nil).Once()
+			}
+
+			res, err := s.orderService.HandleWebhook(context.Background(), payment.FakeName, payload, header)
+			require.NoError(t, err)
+			assert.Equal(t, order.Status, res.Status)
+		})
+	}
+}
+
+func TestHandleWebhook_SuccessPaidAfterFailed(t *testing.T) {
+	t.Parallel()
+
+	s := createOrderService(t)
+
+	order := generated.Order{ID: uuid.New(), BeatID: uuid.New(), Status: generated.OrderStatusFailed}
+	paid := order
+	paid.Status = generated.OrderStatusPaid
+
+	s.paymentProvider.On("Name").Return(payment.FakeName).Twice()
+	s.paymentProvider.On("ParseWebhook", []byte("failed"), mock.Anything).Return(&payment.Event{Kind: payment.EventFailed, CheckoutID: "cs_1"}, nil).Once()
+	s.orderModifier.On("ConfirmOrder", mock.Anything, model.ConfirmOrder{Provider: payment.FakeName, CheckoutID: "cs_1"}).Return(&order, true, nil).Once()
+	s.paymentProvider.On("ParseWebhook", []byte("paid"), mock.Anything).Return(&payment.Event{Kind: payment.EventPaid, CheckoutID: "cs_1"}, nil).Once()
+	// The failed order is confirmed again by the payment and sells the license.
+	s.orderModifier.On("ConfirmOrder", mock.Anything, model.ConfirmOrder{Provider: payment.FakeName, CheckoutID: "cs_1", Paid: true}).Return(&paid, true, nil).Once()
+	s.orderModifier.On("SaveSignal", mock.Anything, generated.SaveSignalParams{BeatID: order.BeatID, Kind: generated.BeatSignalAcquisition}).Return(nil).Once()
+	s.orderProvider.On("GetBeatLicenses", mock.Anything, order.BeatID).Return(nil, nil).Once()
+
+	res, err := s.orderService.HandleWebhook(context.Background(), payment.FakeName, []byte("failed"), http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, generated.OrderStatusFailed, res.Status)
+
+	res, err = s.orderService.HandleWebhook(context.Background(), payment.FakeName, []byte("paid"), http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, generated.OrderStatusPaid, res.Status)
+}
+
+func TestHandleWebhook_Fail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown provider", func(t *testing.T) {
+		t.Parallel()
+
+		s := createOrderService(t)
+		s.paymentProvider.On("Name").Return(payment.FakeName).Once()
+
+		_, err := s.orderService.HandleWebhook(context.Background(), "stripe", nil, nil)
+		assert.ErrorIs(t, err, model.ErrProviderNotFound)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+
+		s := createOrderService(t)
+		s.paymentProvider.On("Name").Return(payment.FakeName).Once()
+		s.paymentProvider.On("ParseWebhook", mock.Anything, mock.Anything).Return(nil, payment.ErrInvalidWebhook).Once()
+
+		_, err := s.orderService.HandleWebhook(context.Background(), payment.FakeName, nil, nil)
+		assert.ErrorIs(t, err, model.ErrInvalidWebhook)
+	})
+}
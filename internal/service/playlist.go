@@ -0,0 +1,277 @@
+package beat
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"log/slog"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const shareTokenBytes = 16
+
+type PlaylistServiceConfig struct {
+	maxBeats int64
+}
+
+func NewPlaylistServiceConfig(maxBeats int64) *PlaylistServiceConfig {
+	return &PlaylistServiceConfig{
+		maxBeats: maxBeats,
+	}
+}
+
+//go:generate mockery --name PlaylistModifier
+type PlaylistModifier interface {
+	SavePlaylist(ctx context.Context, arg generated.SavePlaylistParams) (generated.Playlist, error)
+	UpdatePlaylist(ctx context.Context, arg generated.UpdatePlaylistParams) (*generated.Playlist, error)
+	DeletePlaylist(ctx context.Context, id uuid.UUID) error
+	AddPlaylistBeat(ctx context.Context, arg model.AddPlaylistBeat) error
+	RemovePlaylistBeat(ctx context.Context, playlistID, beatID uuid.UUID) error
+	ReorderPlaylistBeats(ctx context.Context, playlistID uuid.UUID, beatIDs []uuid.UUID) error
+}
+
+//go:generate mockery --name PlaylistProvider
+type PlaylistProvider interface {
+	GetPlaylistByID(ctx context.Context, id uuid.UUID) (*generated.Playlist, error)
+	GetPlaylistByShareToken(ctx context.Context, shareToken string) (*generated.Playlist, error)
+	GetPlaylistsByUserID(ctx context.Context, userID uuid.UUID) ([]generated.Playlist, error)
+	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
+	GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
+}
+
+type PlaylistService struct {
+	playlistModifier PlaylistModifier
+	playlistProvider PlaylistProvider
+	config           *PlaylistServiceConfig
+	log              *slog.Logger
+}
+
+func NewPlaylistService(
+	playlistModifier PlaylistModifier,
+	playlistProvider PlaylistProvider,
+	config *PlaylistServiceConfig,
+	log *slog.Logger,
+) *PlaylistService {
+	return &PlaylistService{
+		playlistModifier: playlistModifier,
+		playlistProvider: playlistProvider,
+		config:           config,
+		log:              log,
+	}
+}
+
+func (s *PlaylistService) CreatePlaylist(ctx context.Context, userID uuid.UUID, name string, visibility model.PlaylistVisibility) (*model.Playlist, error) {
+	token, err := newShareToken()
+	if err != nil {
+		s.log.Error("failed to generate share token", sl.Err(err))
+		return nil, err
+	}
+
+	playlist, err := s.playlistModifier.SavePlaylist(ctx, generated.SavePlaylistParams{
+		UserID:     userID,
+		Name:       name,
+		Visibility: generated.PlaylistVisibility(visibility),
+		ShareToken: token,
+	})
+	if err != nil {
+		s.log.Error("failed to save playlist", sl.Err(err))
+		return nil, err
+	}
+
+	res := model.ToDomainPlaylist(playlist)
+	return &res, nil
+}
+
+// GetUserPlaylists returns all playlists of the user, newest first.
+func (s *PlaylistService) GetUserPlaylists(ctx context.Context, userID uuid.UUID) ([]model.Playlist, error) {
+	playlists, err := s.playlistProvider.GetPlaylistsByUserID(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get playlists", sl.Err(err))
+		return nil, err
+	}
+
+	res := make([]model.Playlist, 0, len(playlists))
+	for _, p := range playlists {
+		res = append(res, model.ToDomainPlaylist(p))
+	}
+
+	return res, nil
+}
+
+// GetPlaylistBeats returns the playlist with its beats that match params, in playlist order
+// unless params sets another one. Only public playlists are visible to other users than
+// the owner, viewer is nil for anonymous requests.
+func (s *PlaylistService) GetPlaylistBeats(ctx context.Context, id uuid.UUID, viewer *uuid.UUID, params model.GetBeatsParams) (*model.Playlist, []model.Beat, *uint64, error) {
+	playlist, err := s.playlistProvider.GetPlaylistByID(ctx, id)
+	if err != nil {
+		s.log.Error("failed to get playlist", sl.Err(err))
+		return nil, nil, nil, err
+	}
+
+	return s.playlistBeats(ctx, playlist, viewer, false, params)
+}
+
+// GetSharedPlaylistBeats is GetPlaylistBeats for share links, which also open
+// unlisted playlists.
+func (s *PlaylistService) GetSharedPlaylistBeats(ctx context.Context, shareToken string, viewer *uuid.UUID, params model.GetBeatsParams) (*model.Playlist, []model.Beat, *uint64, error) {
+	playlist, err := s.playlistProvider.GetPlaylistByShareToken(ctx, shareToken)
+	if err != nil {
+		s.log.Error("failed to get playlist", sl.Err(err))
+		return nil, nil, nil, err
+	}
+
+	return s.playlistBeats(ctx, playlist, viewer, true, params)
+}
+
+func (s *PlaylistService) playlistBeats(ctx context.Context, playlist *generated.Playlist, viewer *uuid.UUID, shared bool, params model.GetBeatsParams) (*model.Playlist, []model.Beat, *uint64, error) {
+	if !canView(playlist, viewer, shared) {
+		s.log.Debug("playlist is not visible", slog.String("playlist_id", playlist.ID.String()))
+		return nil, nil, nil, &model.ModelError{Err: model.ErrPlaylistNotFound}
+	}
+
+	params.PlaylistID = &playlist.ID
+
+	beats, total, err := s.playlistProvider.GetBeats(ctx, params)
+	if err != nil {
+		s.log.Error("failed to get playlist beats", sl.Err(err))
+		return nil, nil, nil, err
+	}
+
+	res := model.ToDomainPlaylist(*playlist)
+	return &res, beats, total, nil
+}
+
+func canView(playlist *generated.Playlist, viewer *uuid.UUID, shared bool) bool {
+	switch {
+	case viewer != nil && *viewer == playlist.UserID:
+		return true
+	case playlist.Visibility == generated.PlaylistVisibilityPublic:
+		return true
+	case playlist.Visibility == generated.PlaylistVisibilityUnlisted:
+		return shared
+	}
+	return false
+}
+
+// UpdatePlaylist renames the playlist or changes its visibility, nil fields are left as is.
+func (s *PlaylistService) UpdatePlaylist(ctx context.Context, userID uuid.UUID, update model.UpdatePlaylist) (*model.Playlist, error) {
+	if err := s.checkOwner(ctx, userID, update.ID); err != nil {
+		return nil, err
+	}
+
+	arg := generated.UpdatePlaylistParams{ID: update.ID, Name: update.Name}
+	if update.Visibility != nil {
+		arg.Visibility = generated.NullPlaylistVisibility{PlaylistVisibility: generated.PlaylistVisibility(*update.Visibility), Valid: true}
+	}
+
+	playlist, err := s.playlistModifier.UpdatePlaylist(ctx, arg)
+	if err != nil {
+		s.log.Error("failed to update playlist", sl.Err(err))
+		return nil, err
+	}
+
+	res := model.ToDomainPlaylist(*playlist)
+	return &res, nil
+}
+
+func (s *PlaylistService) DeletePlaylist(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.checkOwner(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.playlistModifier.DeletePlaylist(ctx, id); err != nil {
+		s.log.Error("failed to delete playlist", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+// AddPlaylistBeat adds the beat to the playlist at position, or to its end if position is nil.
+func (s *PlaylistService) AddPlaylistBeat(ctx context.Context, userID, id, beatID uuid.UUID, position *int32) error {
+	if err := s.checkOwner(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if position != nil && *position < 1 {
+		return model.NewErr(model.ErrValidationFailed, "position must be positive")
+	}
+
+	beat, err := s.playlistProvider.GetBeatByID(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return err
+	}
+
+	if beat.IsDeleted {
+		s.log.Debug("beat is deleted", slog.String("beat_id", beatID.String()))
+		return &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	if err := s.playlistModifier.AddPlaylistBeat(ctx, model.AddPlaylistBeat{
+		PlaylistID: id,
+		BeatID:     beatID,
+		Position:   position,
+		MaxBeats:   s.config.maxBeats,
+	}); err != nil {
+		s.log.Error("failed to add playlist beat", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *PlaylistService) RemovePlaylistBeat(ctx context.Context, userID, id, beatID uuid.UUID) error {
+	if err := s.checkOwner(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.playlistModifier.RemovePlaylistBeat(ctx, id, beatID); err != nil {
+		s.log.Error("failed to remove playlist beat", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+// ReorderPlaylistBeats puts the beats of the playlist in the order of beatIDs.
+func (s *PlaylistService) ReorderPlaylistBeats(ctx context.Context, userID, id uuid.UUID, beatIDs []uuid.UUID) error {
+	if err := s.checkOwner(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.playlistModifier.ReorderPlaylistBeats(ctx, id, beatIDs); err != nil {
+		s.log.Error("failed to reorder playlist beats", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *PlaylistService) checkOwner(ctx context.Context, userID, id uuid.UUID) error {
+	playlist, err := s.playlistProvider.GetPlaylistByID(ctx, id)
+	if err != nil {
+		s.log.Error("failed to get playlist", sl.Err(err))
+		return err
+	}
+
+	if playlist.UserID != userID {
+		s.log.Debug("user is not playlist owner", slog.String("playlist_id", id.String()), slog.String("user_id", userID.String()))
+		return &model.ModelError{Err: model.ErrNotPlaylistOwner}
+	}
+
+	return nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
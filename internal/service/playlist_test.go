@@ -0,0 +1,246 @@
+package beat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type playlistDependencies struct {
+	playlistService  *PlaylistService
+	playlistModifier *mocks.PlaylistModifier
+	playlistProvider *mocks.PlaylistProvider
+}
+
+func createPlaylistService(t *testing.T) playlistDependencies {
+	t.Helper()
+
+	playlistModifier := mocks.NewPlaylistModifier(t)
+	playlistProvider := mocks.NewPlaylistProvider(t)
+	config := NewPlaylistServiceConfig(500)
+
+	return playlistDependencies{
+		playlistService:  NewPlaylistService(playlistModifier, playlistProvider, config, slogdiscard.NewDiscardLogger()),
+		playlistModifier: playlistModifier,
+		playlistProvider: playlistProvider,
+	}
+}
+
+func TestCreatePlaylist_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	userID := uuid.New()
+
+	s.playlistModifier.On("SavePlaylist", mock.Anything, mock.MatchedBy(func(arg generated.SavePlaylistParams) bool {
+		return arg.UserID == userID && arg.Name == "shortlist" && arg.Visibility == generated.PlaylistVisibilityUnlisted && len(arg.ShareToken) == 22
+	})).Return(generated.Playlist{ID: uuid.New(), UserID: userID, Name: "shortlist", Visibility: generated.PlaylistVisibilityUnlisted}, nil).Once()
+
+	res, err := s.playlistService.CreatePlaylist(context.Background(), userID, "shortlist", model.PlaylistVisibilityUnlisted)
+	require.NoError(t, err)
+	assert.Equal(t, "shortlist", res.Name)
+	assert.Equal(t, model.PlaylistVisibilityUnlisted, res.Visibility)
+}
+
+func TestGetPlaylistBeats_Success(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility generated.PlaylistVisibility
+		owner      bool
+	}{
+		{name: "public", visibility: generated.PlaylistVisibilityPublic},
+		{name: "private owner", visibility: generated.PlaylistVisibilityPrivate, owner: true},
+		{name: "unlisted owner", visibility: generated.PlaylistVisibilityUnlisted, owner: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createPlaylistService(t)
+
+			playlist := generated.Playlist{ID: uuid.New(), UserID: uuid.New(), Visibility: tt.visibility}
+			viewer := uuid.New()
+			if tt.owner {
+				viewer = playlist.UserID
+			}
+			beats := []model.Beat{{ID: uuid.New()}}
+			total := uint64(1)
+
+			s.playlistProvider.On("GetPlaylistByID", mock.Anything, playlist.ID).Return(&playlist, nil).Once()
+			s.playlistProvider.On("GetBeats", mock.Anything, model.GetBeatsParams{Limit: 10, PlaylistID: &playlist.ID}).Return(beats, &total, nil).Once()
+
+			res, resBeats, resTotal, err := s.playlistService.GetPlaylistBeats(context.Background(), playlist.ID, &viewer, model.GetBeatsParams{Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, playlist.ID, res.ID)
+			assert.Equal(t, beats, resBeats)
+			assert.Equal(t, total, *resTotal)
+		})
+	}
+}
+
+func TestGetPlaylistBeats_FailNotVisible(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility generated.PlaylistVisibility
+	}{
+		{name: "private", visibility: generated.PlaylistVisibilityPrivate},
+		{name: "unlisted", visibility: generated.PlaylistVisibilityUnlisted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createPlaylistService(t)
+
+			playlist := generated.Playlist{ID: uuid.New(), UserID: uuid.New(), Visibility: tt.visibility}
+
+			s.playlistProvider.On("GetPlaylistByID", mock.Anything, playlist.ID).Return(&playlist, nil).Once()
+
+			_, _, _, err := s.playlistService.GetPlaylistBeats(context.Background(), playlist.ID, nil, model.GetBeatsParams{Limit: 10})
+			assert.ErrorIs(t, err, model.ErrPlaylistNotFound)
+		})
+	}
+}
+
+func TestGetSharedPlaylistBeats_SuccessUnlisted(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	playlist := generated.Playlist{ID: uuid.New(), UserID: uuid.New(), Visibility: generated.PlaylistVisibilityUnlisted, ShareToken: "token"}
+	total := uint64(0)
+
+	s.playlistProvider.On("GetPlaylistByShareToken", mock.Anything, "token").Return(&playlist, nil).Once()
+	s.playlistProvider.On("GetBeats", mock.Anything, model.GetBeatsParams{Limit: 10, PlaylistID: &playlist.ID}).Return([]model.Beat{}, &total, nil).Once()
+
+	res, _, _, err := s.playlistService.GetSharedPlaylistBeats(context.Background(), "token", nil, model.GetBeatsParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, playlist.ID, res.ID)
+}
+
+func TestGetSharedPlaylistBeats_FailPrivate(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	playlist := generated.Playlist{ID: uuid.New(), UserID: uuid.New(), Visibility: generated.PlaylistVisibilityPrivate}
+
+	s.playlistProvider.On("GetPlaylistByShareToken", mock.Anything, "token").Return(&playlist, nil).Once()
+
+	_, _, _, err := s.playlistService.GetSharedPlaylistBeats(context.Background(), "token", nil, model.GetBeatsParams{Limit: 10})
+	assert.ErrorIs(t, err, model.ErrPlaylistNotFound)
+}
+
+func TestUpdatePlaylist_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	userID := uuid.New()
+	playlist := generated.Playlist{ID: uuid.New(), UserID: userID}
+	visibility := model.PlaylistVisibilityPublic
+	updated := playlist
+	updated.Visibility = generated.PlaylistVisibilityPublic
+
+	s.playlistProvider.On("GetPlaylistByID", mock.Anything, playlist.ID).Return(&playlist, nil).Once()
+	s.playlistModifier.On("UpdatePlaylist", mock.Anything, generated.UpdatePlaylistParams{
+		ID:         playlist.ID,
+		Visibility: generated.NullPlaylistVisibility{PlaylistVisibility: generated.PlaylistVisibilityPublic, Valid: true},
+	}).Return(&updated, nil).Once()
+
+	res, err := s.playlistService.UpdatePlaylist(context.Background(), userID, model.UpdatePlaylist{ID: playlist.ID, Visibility: &visibility})
+	require.NoError(t, err)
+	assert.Equal(t, model.PlaylistVisibilityPublic, res.Visibility)
+}
+
+func TestDeletePlaylist_FailNotOwner(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	playlist := generated.Playlist{ID: uuid.New(), UserID: uuid.New()}
+
+	s.playlistProvider.On("GetPlaylistByID", mock.Anything, playlist.ID).Return(&playlist, nil).Once()
+
+	err := s.playlistService.DeletePlaylist(context.Background(), uuid.New(), playlist.ID)
+	assert.ErrorIs(t, err, model.ErrNotPlaylistOwner)
+}
+
+func TestAddPlaylistBeat_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	userID, beatID := uuid.New(), uuid.New()
+	playlist := generated.Playlist{ID: uuid.New(), UserID: userID}
+	position := int32(2)
+
+	s.playlistProvider.On("GetPlaylistByID", mock.Anything, playlist.ID).Return(&playlist, nil).Once()
+	s.playlistProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID}, nil).Once()
+	s.playlistModifier.On("AddPlaylistBeat", mock.Anything, model.AddPlaylistBeat{
+		PlaylistID: playlist.ID,
+		BeatID:     beatID,
+		Position:   &position,
+		MaxBeats:   500,
+	}).Return(nil).Once()
+
+	err := s.playlistService.AddPlaylistBeat(context.Background(), userID, playlist.ID, beatID, &position)
+	require.NoError(t, err)
+}
+
+func TestAddPlaylistBeat_FailDeleted(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	userID := uuid.New()
+	playlist := generated.Playlist{ID: uuid.New(), UserID: userID}
+
+	s.playlistProvider.On("GetPlaylistByID", mock.Anything, playlist.ID).Return(&playlist, nil).Once()
+	s.playlistProvider.On("GetBeatByID", mock.Anything, mock.Anything).Return(&generated.Beat{IsDeleted: true}, nil).Once()
+
+	err := s.playlistService.AddPlaylistBeat(context.Background(), userID, playlist.ID, uuid.New(), nil)
+	assert.ErrorIs(t, err, model.ErrBeatNotFound)
+}
+
+func TestAddPlaylistBeat_FailInvalidPosition(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	userID := uuid.New()
+	playlist := generated.Playlist{ID: uuid.New(), UserID: userID}
+	position := int32(0)
+
+	s.playlistProvider.On("GetPlaylistByID", mock.Anything, playlist.ID).Return(&playlist, nil).Once()
+
+	err := s.playlistService.AddPlaylistBeat(context.Background(), userID, playlist.ID, uuid.New(), &position)
+	assert.ErrorIs(t, err, model.ErrValidationFailed)
+}
+
+func TestReorderPlaylistBeats_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createPlaylistService(t)
+
+	userID := uuid.New()
+	playlist := generated.Playlist{ID: uuid.New(), UserID: userID}
+	beatIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	s.playlistProvider.On("GetPlaylistByID", mock.Anything, playlist.ID).Return(&playlist, nil).Once()
+	s.playlistModifier.On("ReorderPlaylistBeats", mock.Anything, playlist.ID, beatIDs).Return(nil).Once()
+
+	err := s.playlistService.ReorderPlaylistBeats(context.Background(), userID, playlist.ID, beatIDs)
+	require.NoError(t, err)
+}
@@ -0,0 +1,57 @@
+package beat
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name SimilarBeatsProvider
+type SimilarBeatsProvider interface {
+	GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error)
+	GetSimilarBeats(ctx context.Context, beat model.Beat, weights model.SimilarityWeights, limit uint64, locales []string) ([]model.Beat, error)
+}
+
+type RecommendationService struct {
+	beatProvider SimilarBeatsProvider
+	weights      model.SimilarityWeights
+	log          *slog.Logger
+}
+
+func NewRecommendationService(
+	beatProvider SimilarBeatsProvider,
+	weights model.SimilarityWeights,
+	log *slog.Logger,
+) *RecommendationService {
+	return &RecommendationService{
+		beatProvider: beatProvider,
+		weights:      weights,
+		log:          log,
+	}
+}
+
+// GetSimilarBeats returns up to limit beats similar to the beat with their names in locales.
+func (s *RecommendationService) GetSimilarBeats(ctx context.Context, beatID uuid.UUID, limit uint64, locales []string) ([]model.Beat, error) {
+	// The beat is compared by the names of its attributes, so they are not localized.
+	beats, _, err := s.beatProvider.GetBeats(ctx, model.GetBeatsParams{BeatID: &beatID, Limit: 1})
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return nil, err
+	}
+
+	if len(beats) == 0 {
+		s.log.Debug("beat not found")
+		return nil, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	similar, err := s.beatProvider.GetSimilarBeats(ctx, beats[0], s.weights, limit, locales)
+	if err != nil {
+		s.log.Error("failed to get similar beats", sl.Err(err))
+		return nil, err
+	}
+
+	return similar, nil
+}
@@ -0,0 +1,76 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type recommendationDependencies struct {
+	recommendationService *RecommendationService
+	beatProvider          *mocks.SimilarBeatsProvider
+	weights               model.SimilarityWeights
+}
+
+func createRecommendationService(t *testing.T) recommendationDependencies {
+	t.Helper()
+
+	beatProvider := mocks.NewSimilarBeatsProvider(t)
+	weights := model.SimilarityWeights{Bpm: 1, BpmRange: 20, Key: 1, Genres: 1, Moods: 0.5, Tags: 0.5, Beatmaker: 0.25}
+
+	return recommendationDependencies{
+		recommendationService: NewRecommendationService(beatProvider, weights, slogdiscard.NewDiscardLogger()),
+		beatProvider:          beatProvider,
+		weights:               weights,
+	}
+}
+
+func TestGetSimilarBeats_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createRecommendationService(t)
+
+	beatID := uuid.New()
+	beat := model.Beat{ID: beatID, Bpm: 140, Genres: []string{"Trap"}}
+	similar := []model.Beat{{ID: uuid.New(), Bpm: 142, Genres: []string{"Trap"}}}
+
+	s.beatProvider.On("GetBeats", mock.Anything, model.GetBeatsParams{BeatID: &beatID, Limit: 1}).Return([]model.Beat{beat}, new(uint64), nil).Once()
+	s.beatProvider.On("GetSimilarBeats", mock.Anything, beat, s.weights, uint64(10), []string{"ru", "en"}).Return(similar, nil).Once()
+
+	res, err := s.recommendationService.GetSimilarBeats(context.Background(), beatID, 10, []string{"ru", "en"})
+	require.NoError(t, err)
+	assert.Equal(t, similar, res)
+}
+
+func TestGetSimilarBeats_FailNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := createRecommendationService(t)
+
+	s.beatProvider.On("GetBeats", mock.Anything, mock.Anything).Return(nil, new(uint64), nil).Once()
+
+	_, err := s.recommendationService.GetSimilarBeats(context.Background(), uuid.New(), 10, nil)
+	assert.ErrorIs(t, err, model.ErrBeatNotFound)
+}
+
+func TestGetSimilarBeats_Fail(t *testing.T) {
+	t.Parallel()
+
+	s := createRecommendationService(t)
+
+	expErr := errors.New("internal error")
+
+	s.beatProvider.On("GetBeats", mock.Anything, mock.Anything).Return([]model.Beat{{ID: uuid.New()}}, new(uint64), nil).Once()
+	s.beatProvider.On("GetSimilarBeats", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, expErr).Once()
+
+	_, err := s.recommendationService.GetSimilarBeats(context.Background(), uuid.New(), 10, nil)
+	assert.ErrorIs(t, err, expErr)
+}
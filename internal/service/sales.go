@@ -0,0 +1,150 @@
+package beat
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SalesServiceConfig struct {
+	currency      string
+	downloadLimit int32
+	urlTTL        int
+}
+
+func NewSalesServiceConfig(currency string, downloadLimit int32, urlTTL int) *SalesServiceConfig {
+	return &SalesServiceConfig{
+		currency:      currency,
+		downloadLimit: downloadLimit,
+		urlTTL:        urlTTL,
+	}
+}
+
+//go:generate mockery --name SalesProvider
+type SalesProvider interface {
+	GetUserPurchases(ctx context.Context, userID uuid.UUID) ([]generated.GetUserPurchasesRow, error)
+	GetSalesReport(ctx context.Context, arg generated.GetSalesReportParams) ([]generated.GetSalesReportRow, error)
+}
+
+type SalesService struct {
+	salesProvider SalesProvider
+	urlProvider   URLProvider
+	config        *SalesServiceConfig
+	log           *slog.Logger
+}
+
+func NewSalesService(
+	salesProvider SalesProvider,
+	urlProvider URLProvider,
+	config *SalesServiceConfig,
+	log *slog.Logger,
+) *SalesService {
+	return &SalesService{
+		salesProvider: salesProvider,
+		urlProvider:   urlProvider,
+		config:        config,
+		log:           log,
+	}
+}
+
+// GetPurchases returns the licenses bought by the user, the latest first, with their
+// downloads and fresh URLs of their agreements. Download links are not issued here, they
+// count against the download limit.
+func (s *SalesService) GetPurchases(ctx context.Context, userID uuid.UUID) ([]model.Purchase, error) {
+	rows, err := s.salesProvider.GetUserPurchases(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get purchases", sl.Err(err))
+		return nil, err
+	}
+
+	ttl := time.Minute * time.Duration(s.config.urlTTL)
+	res := make([]model.Purchase, 0, len(rows))
+	for _, row := range rows {
+		beat := &generated.Beat{
+			FilePath:            row.FilePath,
+			ArchivePath:         row.ArchivePath,
+			IsFileDownloaded:    row.IsFileDownloaded,
+			IsArchiveDownloaded: row.IsArchiveDownloaded,
+		}
+		_, available := deliverable(beat, row.Deliverables)
+
+		p := model.Purchase{
+			BeatID:        row.BeatID,
+			Beat:          row.Name,
+			Tier:          row.Tier,
+			Number:        row.Number,
+			Price:         row.Price,
+			Currency:      s.config.currency,
+			PurchasedAt:   row.CreatedAt.Time,
+			Available:     available && !row.RevokedAt.Valid,
+			Revoked:       row.RevokedAt.Valid,
+			Downloads:     row.Downloads,
+			DownloadLimit: s.config.downloadLimit,
+		}
+
+		if row.AgreementPath != nil {
+			if p.AgreementURL, err = s.urlProvider.GetDownloadMediaURL(ctx, *row.AgreementPath, ttl); err != nil {
+				s.log.Error("failed to get agreement url", sl.Err(err))
+				return nil, err
+			}
+		}
+
+		res = append(res, p)
+	}
+
+	return res, nil
+}
+
+// GetSalesReport returns the licenses of the beats of the beatmaker sold in the range of
+// params, by period and beat, with the gross revenue and units of each. The beats the
+// beatmaker collaborated on count as theirs, with the whole price of their licenses: the
+// share of each collaborator is in the earnings.
+func (s *SalesService) GetSalesReport(ctx context.Context, params model.GetSalesReportParams) (*model.SalesReport, error) {
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, model.NewErr(model.ErrValidationFailed, "from must be before to")
+	}
+
+	arg := generated.GetSalesReportParams{
+		Period:      string(params.Period),
+		BeatmakerID: params.BeatmakerID,
+	}
+	if params.BeatID != nil {
+		arg.BeatID = pgtype.UUID{Bytes: *params.BeatID, Valid: true}
+	}
+	if params.From != nil {
+		arg.From = pgtype.Timestamp{Time: *params.From, Valid: true}
+	}
+	if params.To != nil {
+		arg.To = pgtype.Timestamp{Time: *params.To, Valid: true}
+	}
+
+	rows, err := s.salesProvider.GetSalesReport(ctx, arg)
+	if err != nil {
+		s.log.Error("failed to get sales report", sl.Err(err))
+		return nil, err
+	}
+
+	res := &model.SalesReport{
+		Currency: s.config.currency,
+		Rows:     make([]model.SalesReportRow, 0, len(rows)),
+	}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, model.SalesReportRow{
+			Period: row.Period.Time,
+			BeatID: row.ID,
+			Beat:   row.Name,
+			Units:  row.Units,
+			Gross:  row.Gross,
+		})
+		res.Units += row.Units
+		res.Gross += row.Gross
+	}
+
+	return res, nil
+}
@@ -0,0 +1,138 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type salesDependencies struct {
+	salesService  *SalesService
+	salesProvider *mocks.SalesProvider
+	urlProvider   *mocks.URLProvider
+}
+
+func createSalesService(t *testing.T) salesDependencies {
+	t.Helper()
+
+	salesProvider := mocks.NewSalesProvider(t)
+	urlProvider := mocks.NewURLProvider(t)
+
+	return salesDependencies{
+		salesService:  NewSalesService(salesProvider, urlProvider, NewSalesServiceConfig("RUB", 3, 5), slogdiscard.NewDiscardLogger()),
+		salesProvider: salesProvider,
+		urlProvider:   urlProvider,
+	}
+}
+
+func TestGetPurchases_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createSalesService(t)
+
+	userID := uuid.New()
+	agreementPath := "licenses/BF-0123456789AB.pdf"
+	rows := []generated.GetUserPurchasesRow{
+		{
+			BeatID:              uuid.New(),
+			Name:                "Night Drive",
+			Tier:                generated.LicenseTierTrackout,
+			Number:              "BF-0123456789AB",
+			Price:               5000,
+			Deliverables:        []string{"file", "archive"},
+			AgreementPath:       &agreementPath,
+			FilePath:            uuid.NewString(),
+			ArchivePath:         uuid.NewString(),
+			IsFileDownloaded:    true,
+			IsArchiveDownloaded: true,
+			Downloads:           2,
+		},
+		// The archive of the beat is not uploaded anymore: it is not available.
+		{BeatID: uuid.New(), Name: "Sunrise", Tier: generated.LicenseTierTrackout, Deliverables: []string{"archive"}, IsFileDownloaded: true},
+	}
+
+	s.salesProvider.On("GetUserPurchases", mock.Anything, userID).Return(rows, nil).Once()
+	s.urlProvider.On("GetDownloadMediaURL", mock.Anything, agreementPath, 5*time.Minute).Return(&agreementPath, nil).Once()
+
+	res, err := s.salesService.GetPurchases(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.True(t, res[0].Available)
+	assert.Equal(t, int32(2), res[0].Downloads)
+	assert.Equal(t, int32(3), res[0].DownloadLimit)
+	assert.Equal(t, agreementPath, *res[0].AgreementURL)
+	assert.Equal(t, "RUB", res[0].Currency)
+	assert.False(t, res[1].Available)
+	assert.Nil(t, res[1].AgreementURL)
+}
+
+func TestGetPurchases_Fail(t *testing.T) {
+	t.Parallel()
+
+	s := createSalesService(t)
+
+	expErr := errors.New("error")
+	s.salesProvider.On("GetUserPurchases", mock.Anything, mock.Anything).Return(nil, expErr).Once()
+
+	_, err := s.salesService.GetPurchases(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, expErr)
+}
+
+func TestGetSalesReport_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createSalesService(t)
+
+	beatmakerID := uuid.New()
+	beatID := uuid.New()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	rows := []generated.GetSalesReportRow{
+		{Period: pgtype.Timestamp{Time: from, Valid: true}, ID: beatID, Name: "Night Drive", Units: 2, Gross: 4000},
+		{Period: pgtype.Timestamp{Time: from.AddDate(0, 1, 0), Valid: true}, ID: beatID, Name: "Night Drive", Units: 1, Gross: 50000},
+	}
+
+	s.salesProvider.On("GetSalesReport", mock.Anything, generated.GetSalesReportParams{
+		Period:      "month",
+		BeatmakerID: beatmakerID,
+		BeatID:      pgtype.UUID{Bytes: beatID, Valid: true},
+		From:        pgtype.Timestamp{Time: from, Valid: true},
+		To:          pgtype.Timestamp{Time: to, Valid: true},
+	}).Return(rows, nil).Once()
+
+	res, err := s.salesService.GetSalesReport(context.Background(), model.GetSalesReportParams{
+		BeatmakerID: beatmakerID,
+		BeatID:      &beatID,
+		From:        &from,
+		To:          &to,
+		Period:      model.SalesPeriodMonth,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+	assert.Equal(t, int64(3), res.Units)
+	assert.Equal(t, int64(54000), res.Gross)
+	assert.Equal(t, "RUB", res.Currency)
+}
+
+func TestGetSalesReport_FailInvalidRange(t *testing.T) {
+	t.Parallel()
+
+	s := createSalesService(t)
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, -1, 0)
+
+	_, err := s.salesService.GetSalesReport(context.Background(), model.GetSalesReportParams{From: &from, To: &to, Period: model.SalesPeriodMonth})
+	assert.ErrorIs(t, err, model.ErrValidationFailed)
+}
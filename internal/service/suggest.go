@@ -0,0 +1,126 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/slug"
+	"github.com/google/uuid"
+)
+
+type SuggestServiceConfig struct {
+	timeout time.Duration
+}
+
+func NewSuggestServiceConfig(timeout time.Duration) *SuggestServiceConfig {
+	return &SuggestServiceConfig{
+		timeout: timeout,
+	}
+}
+
+//go:generate mockery --name SuggestModifier
+type SuggestModifier interface {
+	SaveBeatmaker(ctx context.Context, arg generated.SaveBeatmakerParams) error
+}
+
+//go:generate mockery --name SuggestProvider
+type SuggestProvider interface {
+	GetSuggestions(ctx context.Context, params model.GetSuggestionsParams) (*model.Suggestions, error)
+	GetBeatmakerIDs(ctx context.Context) ([]uuid.UUID, error)
+}
+
+//go:generate mockery --name UserProvider
+type UserProvider interface {
+	GetUsers(ctx context.Context, ids []uuid.UUID) map[uuid.UUID]*userv1.GetUserResponse
+}
+
+type SuggestService struct {
+	suggestModifier SuggestModifier
+	suggestProvider SuggestProvider
+	userProvider    UserProvider
+	config          *SuggestServiceConfig
+	log             *slog.Logger
+}
+
+func NewSuggestService(
+	suggestModifier SuggestModifier,
+	suggestProvider SuggestProvider,
+	userProvider UserProvider,
+	config *SuggestServiceConfig,
+	log *slog.Logger,
+) *SuggestService {
+	return &SuggestService{
+		suggestModifier: suggestModifier,
+		suggestProvider: suggestProvider,
+		userProvider:    userProvider,
+		config:          config,
+		log:             log,
+	}
+}
+
+// Suggest returns up to limit beats, beatmakers, genres and tags of each kind that start
+// with prefix. A typeahead must not hold the search box, so the lookup that takes longer
+// than the configured timeout gives no suggestions instead of an error.
+func (s *SuggestService) Suggest(ctx context.Context, prefix string, limit uint64, locales []string) (*model.Suggestions, error) {
+	name := normalizeTag(prefix)
+	if name == "" {
+		return &model.Suggestions{}, nil
+	}
+
+	namePattern := escapeLike(name) + "%"
+	slugPattern := namePattern
+	if sp := slug.Make(name); sp != "" {
+		slugPattern = sp + "%"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.timeout)
+	defer cancel()
+
+	res, err := s.suggestProvider.GetSuggestions(ctx, model.GetSuggestionsParams{
+		NamePattern: namePattern,
+		SlugPattern: slugPattern,
+		Limit:       limit,
+		Locales:     locales,
+	})
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			s.log.Warn("suggestions timed out", slog.String("prefix", name))
+			return &model.Suggestions{}, nil
+		}
+		s.log.Error("failed to get suggestions", sl.Err(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RefreshBeatmakers copies the usernames and pseudonyms of the beatmakers from the user
+// service, so that they can be suggested without calling it. Beatmakers that can not be
+// resolved keep their previous names.
+func (s *SuggestService) RefreshBeatmakers(ctx context.Context) error {
+	ids, err := s.suggestProvider.GetBeatmakerIDs(ctx)
+	if err != nil {
+		s.log.Error("failed to get beatmaker ids", sl.Err(err))
+		return err
+	}
+
+	users := s.userProvider.GetUsers(ctx, ids)
+	for id, user := range users {
+		if err := s.suggestModifier.SaveBeatmaker(ctx, generated.SaveBeatmakerParams{
+			ID:        id,
+			Username:  user.GetUsername(),
+			Pseudonym: user.GetPseudonym(),
+		}); err != nil {
+			s.log.Error("failed to save beatmaker", sl.Err(err))
+			return err
+		}
+	}
+
+	return nil
+}
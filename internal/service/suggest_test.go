@@ -0,0 +1,101 @@
+package beat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type suggestDependencies struct {
+	suggestService  *SuggestService
+	suggestModifier *mocks.SuggestModifier
+	suggestProvider *mocks.SuggestProvider
+	userProvider    *mocks.UserProvider
+}
+
+func createSuggestService(t *testing.T) suggestDependencies {
+	t.Helper()
+
+	suggestModifier := mocks.NewSuggestModifier(t)
+	suggestProvider := mocks.NewSuggestProvider(t)
+	userProvider := mocks.NewUserProvider(t)
+
+	return suggestDependencies{
+		suggestService:  NewSuggestService(suggestModifier, suggestProvider, userProvider, NewSuggestServiceConfig(50*time.Millisecond), slogdiscard.NewDiscardLogger()),
+		suggestModifier: suggestModifier,
+		suggestProvider: suggestProvider,
+		userProvider:    userProvider,
+	}
+}
+
+func TestSuggest_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createSuggestService(t)
+
+	suggestions := &model.Suggestions{Genres: []model.TaxonomySuggestion{{ID: uuid.New(), Name: "Фонк", Slug: "fonk"}}}
+
+	s.suggestProvider.On("GetSuggestions", mock.Anything, model.GetSuggestionsParams{
+		NamePattern: "фо%",
+		SlugPattern: "fo%",
+		Limit:       5,
+		Locales:     []string{"ru", "en"},
+	}).Return(suggestions, nil).Once()
+
+	res, err := s.suggestService.Suggest(context.Background(), " ФО", 5, []string{"ru", "en"})
+	require.NoError(t, err)
+	assert.Equal(t, suggestions, res)
+}
+
+func TestSuggest_EmptyPrefix(t *testing.T) {
+	t.Parallel()
+
+	s := createSuggestService(t)
+
+	res, err := s.suggestService.Suggest(context.Background(), "  ", 5, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &model.Suggestions{}, res)
+}
+
+func TestSuggest_Timeout(t *testing.T) {
+	t.Parallel()
+
+	s := createSuggestService(t)
+
+	s.suggestProvider.On("GetSuggestions", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, _ model.GetSuggestionsParams) (*model.Suggestions, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).Once()
+
+	res, err := s.suggestService.Suggest(context.Background(), "dark", 5, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &model.Suggestions{}, res)
+}
+
+func TestRefreshBeatmakers_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createSuggestService(t)
+
+	resolved, missing := uuid.New(), uuid.New()
+
+	s.suggestProvider.On("GetBeatmakerIDs", mock.Anything).Return([]uuid.UUID{resolved, missing}, nil).Once()
+	s.userProvider.On("GetUsers", mock.Anything, []uuid.UUID{resolved, missing}).
+		Return(map[uuid.UUID]*userv1.GetUserResponse{resolved: {Username: "lilbeat", Pseudonym: "Lil Beat"}}).Once()
+	s.suggestModifier.On("SaveBeatmaker", mock.Anything, generated.SaveBeatmakerParams{ID: resolved, Username: "lilbeat", Pseudonym: "Lil Beat"}).
+		Return(nil).Once()
+
+	err := s.suggestService.RefreshBeatmakers(context.Background())
+	require.NoError(t, err)
+}
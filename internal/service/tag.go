@@ -0,0 +1,147 @@
+package beat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/slug"
+	"github.com/google/uuid"
+)
+
+type TagServiceConfig struct {
+	maxPerBeat int
+}
+
+func NewTagServiceConfig(maxPerBeat int) *TagServiceConfig {
+	return &TagServiceConfig{
+		maxPerBeat: maxPerBeat,
+	}
+}
+
+//go:generate mockery --name TagModifier
+type TagModifier interface {
+	SetBeatTags(ctx context.Context, beatID uuid.UUID, tags []generated.SaveTagParams) ([]generated.Tag, error)
+}
+
+//go:generate mockery --name TagProvider
+type TagProvider interface {
+	GetBeatByID(ctx context.Context, id uuid.UUID) (*generated.Beat, error)
+	GetTagsByPrefix(ctx context.Context, arg generated.GetTagsByPrefixParams) ([]generated.GetTagsByPrefixRow, error)
+}
+
+type TagService struct {
+	tagModifier TagModifier
+	tagProvider TagProvider
+	config      *TagServiceConfig
+	log         *slog.Logger
+}
+
+func NewTagService(
+	tagModifier TagModifier,
+	tagProvider TagProvider,
+	config *TagServiceConfig,
+	log *slog.Logger,
+) *TagService {
+	return &TagService{
+		tagModifier: tagModifier,
+		tagProvider: tagProvider,
+		config:      config,
+		log:         log,
+	}
+}
+
+// SetBeatTags replaces the tags of the beat with free-form tags. Tags are normalized and
+// matched against the existing tags by slug, so "Juice WRLD" and "juice  wrld" are the same
+// tag, and the tags that do not exist yet are created. Only the beatmaker of the beat
+// or an admin can set its tags.
+func (s *TagService) SetBeatTags(ctx context.Context, userID uuid.UUID, isAdmin bool, beatID uuid.UUID, tags []string) ([]generated.Tag, error) {
+	beat, err := s.tagProvider.GetBeatByID(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get beat", sl.Err(err))
+		return nil, err
+	}
+
+	if beat.IsDeleted {
+		return nil, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	if !isAdmin && beat.BeatmakerID != userID {
+		return nil, &model.ModelError{Err: model.ErrNotBeatOwner}
+	}
+
+	params := make([]generated.SaveTagParams, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		name := normalizeTag(tag)
+		if err := validateTaxonomyName(name); err != nil {
+			return nil, err
+		}
+
+		tagSlug := slug.Make(name)
+		if tagSlug == "" {
+			return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("tag %q must have letters or digits", tag))
+		}
+
+		if _, ok := seen[tagSlug]; ok {
+			continue
+		}
+		seen[tagSlug] = struct{}{}
+
+		params = append(params, generated.SaveTagParams{Name: name, Slug: tagSlug})
+	}
+
+	if len(params) > s.config.maxPerBeat {
+		return nil, model.NewErr(model.ErrValidationFailed, fmt.Sprintf("beat can have at most %d tags", s.config.maxPerBeat))
+	}
+
+	res, err := s.tagModifier.SetBeatTags(ctx, beatID, params)
+	if err != nil {
+		s.log.Error("failed to set beat tags", sl.Err(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AutocompleteTags returns up to limit tags whose name or slug starts with prefix, the tags
+// of the most beats first. An empty prefix gives the most used tags.
+func (s *TagService) AutocompleteTags(ctx context.Context, prefix string, limit int32) ([]generated.GetTagsByPrefixRow, error) {
+	name := normalizeTag(prefix)
+
+	namePattern := escapeLike(name) + "%"
+	slugPattern := namePattern
+	if sp := slug.Make(name); sp != "" {
+		slugPattern = sp + "%"
+	}
+
+	tags, err := s.tagProvider.GetTagsByPrefix(ctx, generated.GetTagsByPrefixParams{
+		NamePattern: namePattern,
+		SlugPattern: slugPattern,
+		MaxTags:     limit,
+	})
+	if err != nil {
+		s.log.Error("failed to get tags by prefix", sl.Err(err))
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// normalizeTag lowercases a free-form tag, drops the leading hash and collapses
+// whitespace, e.g. " #Juice  WRLD " becomes "juice wrld".
+func normalizeTag(tag string) string {
+	tag = strings.TrimLeft(strings.TrimSpace(tag), "#")
+	return strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes the wildcards of a like pattern in s.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
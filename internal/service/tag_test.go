@@ -0,0 +1,126 @@
+package beat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type tagDependencies struct {
+	tagService  *TagService
+	tagModifier *mocks.TagModifier
+	tagProvider *mocks.TagProvider
+}
+
+func createTagService(t *testing.T) tagDependencies {
+	t.Helper()
+
+	tagModifier := mocks.NewTagModifier(t)
+	tagProvider := mocks.NewTagProvider(t)
+	config := NewTagServiceConfig(3)
+
+	return tagDependencies{
+		tagService:  NewTagService(tagModifier, tagProvider, config, slogdiscard.NewDiscardLogger()),
+		tagModifier: tagModifier,
+		tagProvider: tagProvider,
+	}
+}
+
+func TestSetBeatTags_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createTagService(t)
+
+	userID, beatID := uuid.New(), uuid.New()
+	tags := []generated.Tag{{ID: uuid.New(), Name: "juice wrld", Slug: "juice-wrld"}, {ID: uuid.New(), Name: "фонк", Slug: "fonk"}}
+
+	s.tagProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID, BeatmakerID: userID}, nil).Once()
+	s.tagModifier.On("SetBeatTags", mock.Anything, beatID, []generated.SaveTagParams{
+		{Name: "juice wrld", Slug: "juice-wrld"},
+		{Name: "фонк", Slug: "fonk"},
+	}).Return(tags, nil).Once()
+
+	res, err := s.tagService.SetBeatTags(context.Background(), userID, false, beatID, []string{" #Juice  WRLD ", "juice wrld", "Фонк"})
+	require.NoError(t, err)
+	assert.Equal(t, tags, res)
+}
+
+func TestSetBeatTags_FailNotOwner(t *testing.T) {
+	t.Parallel()
+
+	s := createTagService(t)
+
+	beatID := uuid.New()
+
+	s.tagProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID, BeatmakerID: uuid.New()}, nil).Once()
+
+	_, err := s.tagService.SetBeatTags(context.Background(), uuid.New(), false, beatID, []string{"phonk"})
+	assert.ErrorIs(t, err, model.ErrNotBeatOwner)
+}
+
+func TestSetBeatTags_FailValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+	}{
+		{name: "too many tags", tags: []string{"phonk", "drill", "trap", "808"}},
+		{name: "empty tag", tags: []string{"  "}},
+		{name: "tag without slug characters", tags: []string{"!!!"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createTagService(t)
+
+			beatID := uuid.New()
+
+			s.tagProvider.On("GetBeatByID", mock.Anything, beatID).Return(&generated.Beat{ID: beatID}, nil).Once()
+
+			_, err := s.tagService.SetBeatTags(context.Background(), uuid.New(), true, beatID, tt.tags)
+			assert.ErrorIs(t, err, model.ErrValidationFailed)
+		})
+	}
+}
+
+func TestAutocompleteTags_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createTagService(t)
+
+	rows := []generated.GetTagsByPrefixRow{{ID: uuid.New(), Name: "фонк", Slug: "fonk", Beats: 12}}
+
+	s.tagProvider.On("GetTagsByPrefix", mock.Anything, generated.GetTagsByPrefixParams{
+		NamePattern: "фон%",
+		SlugPattern: "fon%",
+		MaxTags:     10,
+	}).Return(rows, nil).Once()
+
+	res, err := s.tagService.AutocompleteTags(context.Background(), " Фон", 10)
+	require.NoError(t, err)
+	assert.Equal(t, rows, res)
+}
+
+func TestAutocompleteTags_EscapesWildcards(t *testing.T) {
+	t.Parallel()
+
+	s := createTagService(t)
+
+	s.tagProvider.On("GetTagsByPrefix", mock.Anything, generated.GetTagsByPrefixParams{
+		NamePattern: `100\%%`,
+		SlugPattern: "100%",
+		MaxTags:     5,
+	}).Return(nil, nil).Once()
+
+	_, err := s.tagService.AutocompleteTags(context.Background(), "100%", 5)
+	require.NoError(t, err)
+}
@@ -0,0 +1,183 @@
+package beat
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/locale"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/slug"
+	"github.com/google/uuid"
+)
+
+const maxTaxonomyNameLength = 64
+
+//go:generate mockery --name TaxonomyModifier
+type TaxonomyModifier interface {
+	SaveTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, entry model.SaveTaxonomyEntry) (*model.TaxonomyEntry, error)
+	UpdateTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, update model.UpdateTaxonomyEntry) (*model.TaxonomyEntry, error)
+	DeleteTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID) error
+	MergeTaxonomyEntries(ctx context.Context, kind model.TaxonomyKind, sourceID, targetID uuid.UUID) (int64, error)
+	SaveTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, locale, name string) error
+	DeleteTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, locale string) error
+}
+
+//go:generate mockery --name TaxonomyProvider
+type TaxonomyProvider interface {
+	GetTaxonomy(ctx context.Context, kind model.TaxonomyKind) ([]model.TaxonomyEntry, error)
+}
+
+type TaxonomyService struct {
+	taxonomyModifier TaxonomyModifier
+	taxonomyProvider TaxonomyProvider
+	log              *slog.Logger
+}
+
+func NewTaxonomyService(
+	taxonomyModifier TaxonomyModifier,
+	taxonomyProvider TaxonomyProvider,
+	log *slog.Logger,
+) *TaxonomyService {
+	return &TaxonomyService{
+		taxonomyModifier: taxonomyModifier,
+		taxonomyProvider: taxonomyProvider,
+		log:              log,
+	}
+}
+
+// GetTaxonomy returns all entries of kind with the number of beats that have them,
+// archived entries included.
+func (s *TaxonomyService) GetTaxonomy(ctx context.Context, kind model.TaxonomyKind) ([]model.TaxonomyEntry, error) {
+	entries, err := s.taxonomyProvider.GetTaxonomy(ctx, kind)
+	if err != nil {
+		s.log.Error("failed to get taxonomy", sl.Err(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CreateTaxonomyEntry adds an entry of kind. The slug is made from the name if it is empty.
+func (s *TaxonomyService) CreateTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, entry model.SaveTaxonomyEntry) (*model.TaxonomyEntry, error) {
+	entry.Name = strings.TrimSpace(entry.Name)
+	if err := validateTaxonomyName(entry.Name); err != nil {
+		return nil, err
+	}
+
+	if entry.Slug == "" {
+		entry.Slug = slug.Make(entry.Name)
+	}
+	if err := validateTaxonomySlug(entry.Slug); err != nil {
+		return nil, err
+	}
+
+	res, err := s.taxonomyModifier.SaveTaxonomyEntry(ctx, kind, entry)
+	if err != nil {
+		s.log.Error("failed to save taxonomy entry", sl.Err(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateTaxonomyEntry renames, moves, archives or restores an entry of kind.
+func (s *TaxonomyService) UpdateTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, update model.UpdateTaxonomyEntry) (*model.TaxonomyEntry, error) {
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if err := validateTaxonomyName(name); err != nil {
+			return nil, err
+		}
+		update.Name = &name
+	}
+
+	if update.Slug != nil {
+		if err := validateTaxonomySlug(*update.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := s.taxonomyModifier.UpdateTaxonomyEntry(ctx, kind, update)
+	if err != nil {
+		s.log.Error("failed to update taxonomy entry", sl.Err(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *TaxonomyService) DeleteTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID) error {
+	if err := s.taxonomyModifier.DeleteTaxonomyEntry(ctx, kind, id); err != nil {
+		s.log.Error("failed to delete taxonomy entry", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+// MergeTaxonomyEntries moves the beats of the source entry to the target entry and deletes
+// the source entry. It returns the number of beats that were moved.
+func (s *TaxonomyService) MergeTaxonomyEntries(ctx context.Context, kind model.TaxonomyKind, sourceID, targetID uuid.UUID) (int64, error) {
+	if sourceID == targetID {
+		return 0, model.NewErr(model.ErrValidationFailed, "can not merge an entry into itself")
+	}
+
+	moved, err := s.taxonomyModifier.MergeTaxonomyEntries(ctx, kind, sourceID, targetID)
+	if err != nil {
+		s.log.Error("failed to merge taxonomy entries", sl.Err(err))
+		return 0, err
+	}
+
+	return moved, nil
+}
+
+// SetTaxonomyTranslation sets the name of an entry of kind in locale, which must be
+// a BCP 47 tag like ru or pt-BR.
+func (s *TaxonomyService) SetTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, loc, name string) error {
+	loc, ok := locale.Canonical(loc)
+	if !ok {
+		return model.NewErr(model.ErrValidationFailed, "locale must be a BCP 47 tag")
+	}
+
+	name = strings.TrimSpace(name)
+	if err := validateTaxonomyName(name); err != nil {
+		return err
+	}
+
+	if err := s.taxonomyModifier.SaveTaxonomyTranslation(ctx, kind, id, loc, name); err != nil {
+		s.log.Error("failed to save taxonomy translation", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *TaxonomyService) DeleteTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, loc string) error {
+	loc, ok := locale.Canonical(loc)
+	if !ok {
+		return model.NewErr(model.ErrValidationFailed, "locale must be a BCP 47 tag")
+	}
+
+	if err := s.taxonomyModifier.DeleteTaxonomyTranslation(ctx, kind, id, loc); err != nil {
+		s.log.Error("failed to delete taxonomy translation", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func validateTaxonomyName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > maxTaxonomyNameLength {
+		return model.NewErr(model.ErrValidationFailed, "name must be 1 to 64 characters")
+	}
+	return nil
+}
+
+func validateTaxonomySlug(s string) error {
+	if !slug.Valid(s) || len(s) > maxTaxonomyNameLength {
+		return model.NewErr(model.ErrValidationFailed, "slug must be 1 to 64 lowercase latin letters, digits and single dashes")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package beat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type taxonomyDependencies struct {
+	taxonomyService  *TaxonomyService
+	taxonomyModifier *mocks.TaxonomyModifier
+	taxonomyProvider *mocks.TaxonomyProvider
+}
+
+func createTaxonomyService(t *testing.T) taxonomyDependencies {
+	t.Helper()
+
+	taxonomyModifier := mocks.NewTaxonomyModifier(t)
+	taxonomyProvider := mocks.NewTaxonomyProvider(t)
+
+	return taxonomyDependencies{
+		taxonomyService:  NewTaxonomyService(taxonomyModifier, taxonomyProvider, slogdiscard.NewDiscardLogger()),
+		taxonomyModifier: taxonomyModifier,
+		taxonomyProvider: taxonomyProvider,
+	}
+}
+
+func TestCreateTaxonomyEntry_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createTaxonomyService(t)
+
+	entry := &model.TaxonomyEntry{ID: uuid.New(), Name: "Jersey Club", Slug: "jersey-club", Position: 14}
+
+	s.taxonomyModifier.On("SaveTaxonomyEntry", mock.Anything, model.TaxonomyGenres, model.SaveTaxonomyEntry{Name: "Jersey Club", Slug: "jersey-club"}).
+		Return(entry, nil).Once()
+
+	res, err := s.taxonomyService.CreateTaxonomyEntry(context.Background(), model.TaxonomyGenres, model.SaveTaxonomyEntry{Name: " Jersey Club "})
+	require.NoError(t, err)
+	assert.Equal(t, entry, res)
+}
+
+func TestCreateTaxonomyEntry_FailValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry model.SaveTaxonomyEntry
+	}{
+		{name: "empty name", entry: model.SaveTaxonomyEntry{Name: "  "}},
+		{name: "invalid slug", entry: model.SaveTaxonomyEntry{Name: "Phonk", Slug: "Phonk!"}},
+		{name: "name without slug characters", entry: model.SaveTaxonomyEntry{Name: "!!!"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createTaxonomyService(t)
+
+			_, err := s.taxonomyService.CreateTaxonomyEntry(context.Background(), model.TaxonomyTags, tt.entry)
+			assert.ErrorIs(t, err, model.ErrValidationFailed)
+		})
+	}
+}
+
+func TestUpdateTaxonomyEntry_SuccessArchive(t *testing.T) {
+	t.Parallel()
+
+	s := createTaxonomyService(t)
+
+	archived := true
+	update := model.UpdateTaxonomyEntry{ID: uuid.New(), IsArchived: &archived}
+	entry := &model.TaxonomyEntry{ID: update.ID, IsArchived: true}
+
+	s.taxonomyModifier.On("UpdateTaxonomyEntry", mock.Anything, model.TaxonomyMoods, update).Return(entry, nil).Once()
+
+	res, err := s.taxonomyService.UpdateTaxonomyEntry(context.Background(), model.TaxonomyMoods, update)
+	require.NoError(t, err)
+	assert.True(t, res.IsArchived)
+}
+
+func TestMergeTaxonomyEntries_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createTaxonomyService(t)
+
+	sourceID, targetID := uuid.New(), uuid.New()
+
+	s.taxonomyModifier.On("MergeTaxonomyEntries", mock.Anything, model.TaxonomyTags, sourceID, targetID).Return(int64(7), nil).Once()
+
+	moved, err := s.taxonomyService.MergeTaxonomyEntries(context.Background(), model.TaxonomyTags, sourceID, targetID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), moved)
+}
+
+func TestMergeTaxonomyEntries_FailSame(t *testing.T) {
+	t.Parallel()
+
+	s := createTaxonomyService(t)
+
+	id := uuid.New()
+
+	_, err := s.taxonomyService.MergeTaxonomyEntries(context.Background(), model.TaxonomyTags, id, id)
+	assert.ErrorIs(t, err, model.ErrValidationFailed)
+}
+
+func TestDeleteTaxonomyEntry_FailInUse(t *testing.T) {
+	t.Parallel()
+
+	s := createTaxonomyService(t)
+
+	id := uuid.New()
+
+	s.taxonomyModifier.On("DeleteTaxonomyEntry", mock.Anything, model.TaxonomyGenres, id).
+		Return(model.NewErr(model.ErrTaxonomyInUse, "archive or merge it instead")).Once()
+
+	err := s.taxonomyService.DeleteTaxonomyEntry(context.Background(), model.TaxonomyGenres, id)
+	assert.ErrorIs(t, err, model.ErrTaxonomyInUse)
+}
+
+func TestSetTaxonomyTranslation_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createTaxonomyService(t)
+
+	id := uuid.New()
+
+	s.taxonomyModifier.On("SaveTaxonomyTranslation", mock.Anything, model.TaxonomyMoods, id, "pt-BR", "Sombrio").Return(nil).Once()
+
+	err := s.taxonomyService.SetTaxonomyTranslation(context.Background(), model.TaxonomyMoods, id, "pt_br", " Sombrio ")
+	require.NoError(t, err)
+}
+
+func TestSetTaxonomyTranslation_FailValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		value  string
+	}{
+		{name: "invalid locale", locale: "not a locale", value: "Dark"},
+		{name: "empty name", locale: "en", value: " "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := createTaxonomyService(t)
+
+			err := s.taxonomyService.SetTaxonomyTranslation(context.Background(), model.TaxonomyMoods, uuid.New(), tt.locale, tt.value)
+			assert.ErrorIs(t, err, model.ErrValidationFailed)
+		})
+	}
+}
+
+func TestDeleteTaxonomyTranslation_FailNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := createTaxonomyService(t)
+
+	id := uuid.New()
+
+	s.taxonomyModifier.On("DeleteTaxonomyTranslation", mock.Anything, model.TaxonomyGenres, id, "ru").
+		Return(&model.ModelError{Err: model.ErrTranslationNotFound}).Once()
+
+	err := s.taxonomyService.DeleteTaxonomyTranslation(context.Background(), model.TaxonomyGenres, id, "RU")
+	assert.ErrorIs(t, err, model.ErrTranslationNotFound)
+}
@@ -0,0 +1,65 @@
+package beat
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+)
+
+type TrendingServiceConfig struct {
+	playWeight        float64
+	acquisitionWeight float64
+	halfLife          time.Duration
+	window            time.Duration
+}
+
+func NewTrendingServiceConfig(playWeight, acquisitionWeight float64, halfLife, window time.Duration) *TrendingServiceConfig {
+	return &TrendingServiceConfig{
+		playWeight:        playWeight,
+		acquisitionWeight: acquisitionWeight,
+		halfLife:          halfLife,
+		window:            window,
+	}
+}
+
+//go:generate mockery --name TrendingRefresher
+type TrendingRefresher interface {
+	RefreshTrending(ctx context.Context, arg generated.RefreshTrendingParams) error
+}
+
+type TrendingService struct {
+	trendingRefresher TrendingRefresher
+	config            *TrendingServiceConfig
+	log               *slog.Logger
+}
+
+func NewTrendingService(
+	trendingRefresher TrendingRefresher,
+	config *TrendingServiceConfig,
+	log *slog.Logger,
+) *TrendingService {
+	return &TrendingService{
+		trendingRefresher: trendingRefresher,
+		config:            config,
+		log:               log,
+	}
+}
+
+// RefreshTrending recomputes the trending score of every beat from the signals of the
+// configured window. A signal weighs half as much every half life.
+func (s *TrendingService) RefreshTrending(ctx context.Context) error {
+	if err := s.trendingRefresher.RefreshTrending(ctx, generated.RefreshTrendingParams{
+		AcquisitionWeight: s.config.acquisitionWeight,
+		PlayWeight:        s.config.playWeight,
+		HalfLife:          s.config.halfLife.Seconds(),
+		Window:            s.config.window.Seconds(),
+	}); err != nil {
+		s.log.Error("failed to refresh trending", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package beat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func createTrendingService(t *testing.T) (*TrendingService, *mocks.TrendingRefresher) {
+	t.Helper()
+
+	trendingRefresher := mocks.NewTrendingRefresher(t)
+	config := NewTrendingServiceConfig(1, 10, 72*time.Hour, 7*24*time.Hour)
+
+	return NewTrendingService(trendingRefresher, config, slogdiscard.NewDiscardLogger()), trendingRefresher
+}
+
+func TestRefreshTrending_Success(t *testing.T) {
+	t.Parallel()
+
+	s, trendingRefresher := createTrendingService(t)
+
+	trendingRefresher.On("RefreshTrending", mock.Anything, generated.RefreshTrendingParams{
+		AcquisitionWeight: 10,
+		PlayWeight:        1,
+		HalfLife:          (72 * time.Hour).Seconds(),
+		Window:            (7 * 24 * time.Hour).Seconds(),
+	}).Return(nil).Once()
+
+	err := s.RefreshTrending(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRefreshTrending_Fail(t *testing.T) {
+	t.Parallel()
+
+	s, trendingRefresher := createTrendingService(t)
+
+	expErr := errors.New("internal error")
+
+	trendingRefresher.On("RefreshTrending", mock.Anything, mock.Anything).Return(expErr).Once()
+
+	err := s.RefreshTrending(context.Background())
+	assert.ErrorIs(t, err, expErr)
+}
@@ -13,6 +13,7 @@ import (
 	miniolib "github.com/minio/minio-go/v7"
 
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/celsql"
 	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/minio"
 	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/postgres"
@@ -27,6 +28,7 @@ type BeatStore struct {
 	*postgres.Postgres
 	*generated.Queries
 	bucketName string
+	filter     *celsql.Translator
 	log        *slog.Logger
 }
 
@@ -35,7 +37,7 @@ func New(
 	pg *postgres.Postgres,
 	bucketName string,
 	log *slog.Logger) *BeatStore {
-	return &BeatStore{m, pg, generated.New(pg.DB), bucketName, log}
+	return &BeatStore{m, pg, generated.New(pg.DB), bucketName, celsql.MustNew(beatFilterSchema()), log}
 }
 
 func (s *BeatStore) SaveBeat(ctx context.Context, beat model.SaveBeat) (err error) {
@@ -96,6 +98,10 @@ func (s *BeatStore) SaveBeat(ctx context.Context, beat model.SaveBeat) (err erro
 		return err
 	}
 
+	if err = s.checkArchived(ctx, qtx, beat.Genres, beat.Tags, beat.Moods, &beat.Note); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
@@ -124,6 +130,71 @@ func (s *BeatStore) GetDownloadMediaURL(ctx context.Context, path string, expire
 func (s *BeatStore) GetBeats(ctx context.Context, params model.GetBeatsParams) (beats []model.Beat, total *uint64, err error) {
 	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
+	query, err := s.applyBeatsFilters(beatsQuery(builder, params.Locales), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	count := builder.Select("count(distinct b.id)").FromSelect(query, "b")
+	sql, args, err := count.ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, nil, err
+	}
+
+	if err = s.DB.QueryRow(ctx, sql, args...).Scan(&total); err != nil {
+		s.log.Error("failed to count beats", sl.Err(err))
+		return nil, nil, err
+	}
+
+	if params.OrderBy != nil {
+		expr, ok := beatsOrderExprs[params.OrderBy.Field]
+		if !ok {
+			expr = fmt.Sprintf("%q", params.OrderBy.Field)
+		}
+		query = query.OrderBy(fmt.Sprintf("%s %s", expr, params.OrderBy.Order))
+	} else if params.PlaylistID != nil {
+		query = query.OrderByClause("(select pb.position from playlists_beats pb where pb.beat_id = b.id and pb.playlist_id = ?)", *params.PlaylistID)
+	} else if params.PinnedBy != nil {
+		query = query.OrderByClause("(select pb.position from beatmakers_pinned_beats pb where pb.beat_id = b.id and pb.beatmaker_id = ?)", *params.PinnedBy)
+	}
+	query = query.Limit(params.Limit).Offset(params.Offset)
+
+	sql, args, err = query.ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, sql, args...)
+	if err != nil {
+		s.log.Error("failed to get beats", sl.Err(err))
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	beats, err = pgx.CollectRows(rows, pgx.RowToStructByName[model.Beat])
+	if err != nil {
+		s.log.Error("failed to collect beats", sl.Err(err))
+		return nil, nil, err
+	}
+
+	return beats, total, nil
+}
+
+// beatsOrderExprs are the sort keys of GetBeats that are not columns of beatsQuery.
+var beatsOrderExprs = map[string]string{
+	model.OrderByTrending: "coalesce((select tr.score from beats_trending tr where tr.beat_id = b.id), 0)",
+}
+
+// beatsQuery selects the beats with their aggregated attributes as model.Beat. Genre, tag,
+// mood and note names are translated to the first of locales that has a translation.
+func beatsQuery(builder sq.StatementBuilderType, locales []string) sq.SelectBuilder {
+	genre, genreArgs := localizedName(model.TaxonomyGenres, "g", locales)
+	tag, tagArgs := localizedName(model.TaxonomyTags, "t", locales)
+	mood, moodArgs := localizedName(model.TaxonomyMoods, "m", locales)
+	note, noteArgs := localizedName(model.TaxonomyNotes, "n", locales)
+
 	query := builder.Select(
 		"b.id",
 		"b.beatmaker_id",
@@ -137,12 +208,22 @@ func (s *BeatStore) GetBeats(ctx context.Context, params model.GetBeatsParams) (
 		"b.range_start",
 		"b.range_end",
 		"b.created_at",
-		"array_agg(distinct g.name) filter (where g.name is not null) as genres",
-		"array_agg(distinct t.name) filter (where t.name is not null) as tags",
-		"array_agg(distinct m.name) filter (where m.name is not null) as moods",
-		"n.name note_name",
-		"bn.scale note_scale",
-	).From("beats b").
+	).
+		Column(sq.Expr("array_agg(distinct "+genre+") filter (where g.name is not null) as genres", genreArgs...)).
+		Column(sq.Expr("array_agg(distinct "+tag+") filter (where t.name is not null) as tags", tagArgs...)).
+		Column(sq.Expr("array_agg(distinct "+mood+") filter (where m.name is not null) as moods", moodArgs...)).
+		Column(sq.Expr(note+" note_name", noteArgs...)).
+		Column("bn.scale note_scale").
+		Column("(select count(*) from beats_likes bl where bl.beat_id = b.id) as likes").
+		Column("(select coalesce(jsonb_agg(jsonb_build_object('userId', bc.user_id, 'role', bc.role, 'share', bc.share) order by bc.share desc, bc.user_id), '[]') from beats_collaborators bc where bc.beat_id = b.id) as collaborators").
+		From("beats b")
+	return withBeatsJoins(query).
+		Where("b.is_deleted = false").
+		GroupBy("b.id", "n.id", "n.name", "bn.scale")
+}
+
+func withBeatsJoins(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.
 		LeftJoin("beats_genres bg on b.id = bg.beat_id").
 		LeftJoin("beats_tags bt on b.id = bt.beat_id").
 		LeftJoin("beats_moods bm on b.id = bm.beat_id").
@@ -150,15 +231,20 @@ func (s *BeatStore) GetBeats(ctx context.Context, params model.GetBeatsParams) (
 		LeftJoin("genres g on bg.genre_id = g.id").
 		LeftJoin("tags t on bt.tag_id = t.id").
 		LeftJoin("moods m on bm.mood_id = m.id").
-		LeftJoin("notes n on bn.note_id = n.id").
-		Where("b.is_deleted = false").
-		GroupBy("b.id", "n.name", "bn.scale")
+		LeftJoin("notes n on bn.note_id = n.id")
+}
+
+// byBeatmaker matches the beats of the beatmaker and the beats they collaborated on.
+func byBeatmaker(beatmakerID uuid.UUID) sq.Sqlizer {
+	return sq.Expr("(b.beatmaker_id = ? or exists (select 1 from beats_collaborators bc where bc.beat_id = b.id and bc.user_id = ?))", beatmakerID, beatmakerID)
+}
 
+func (s *BeatStore) applyBeatsFilters(query sq.SelectBuilder, params model.GetBeatsParams) (sq.SelectBuilder, error) {
 	if params.BeatID != nil {
 		query = query.Where("b.id = ?", *params.BeatID)
 	}
 	if params.BeatmakerID != nil {
-		query = query.Where("b.beatmaker_id = ?", *params.BeatmakerID)
+		query = query.Where(byBeatmaker(*params.BeatmakerID))
 	}
 	if params.BeatName != nil {
 		query = query.Where("b.name = ?", *params.BeatName)
@@ -175,58 +261,157 @@ func (s *BeatStore) GetBeats(ctx context.Context, params model.GetBeatsParams) (
 	}
 
 	if params.Genre != nil {
-		query = query.Where("g.name = any(?)", params.Genre)
+		query = query.Where(matchAttributes("beats_genres", "genres", "genre_id", params.Genre, params.GenreMatch))
 	}
 	if params.Tag != nil {
-		query = query.Where("t.name = any(?)", params.Tag)
+		query = query.Where(matchAttributes("beats_tags", "tags", "tag_id", params.Tag, params.TagMatch))
 	}
 	if params.Mood != nil {
-		query = query.Where("m.name = any(?)", params.Mood)
+		query = query.Where(matchAttributes("beats_moods", "moods", "mood_id", params.Mood, params.MoodMatch))
 	}
 	if params.Note != nil {
-		query = query.Where("n.name = ? and bn.scale = ?", params.Note.Name, params.Note.Scale)
+		query = query.Where("? in (n.name, n.slug, n.id::text) and bn.scale = ?", params.Note.Name, params.Note.Scale)
+	}
+	if params.LikedBy != nil {
+		query = query.Where("exists (select 1 from beats_likes bl where bl.beat_id = b.id and bl.user_id = ?)", *params.LikedBy)
+	}
+	if params.PlaylistID != nil {
+		query = query.Where("exists (select 1 from playlists_beats pb where pb.beat_id = b.id and pb.playlist_id = ?)", *params.PlaylistID)
+	}
+	if params.PinnedBy != nil {
+		query = query.Where("exists (select 1 from beatmakers_pinned_beats pb where pb.beat_id = b.id and pb.beatmaker_id = ?)", *params.PinnedBy)
+	}
+	if params.Trending {
+		query = query.Where("exists (select 1 from beats_trending tr where tr.beat_id = b.id)")
+	}
+	if params.Filter != nil {
+		cond, err := s.filter.Translate(*params.Filter)
+		if err != nil {
+			s.log.Debug("invalid filter", slog.String("filter", *params.Filter), sl.Err(err))
+			return query, model.NewErr(model.ErrInvalidFilter, err.Error())
+		}
+		query = query.Where(cond)
 	}
 
-	count := builder.Select("count(distinct b.id)").FromSelect(query, "b")
-	sql, args, err := count.ToSql()
-	if err != nil {
-		s.log.Error("failed to convert to sql", sl.Err(err))
-		return nil, nil, err
+	return query, nil
+}
+
+const bpmFacetBucket = 10
+
+// GetBeatFacets counts the beats matching params per genre, mood, tag, note and bpm bucket.
+// Counts of a facet ignore the filter on the facet itself, so that every value shows
+// how many beats would match if it was selected as well.
+func (s *BeatStore) GetBeatFacets(ctx context.Context, params model.GetBeatsParams) (facets *model.Facets, err error) {
+	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	matching := func(exclude func(p *model.GetBeatsParams)) (sq.SelectBuilder, error) {
+		p := params
+		exclude(&p)
+		query := withBeatsJoins(builder.Select("b.id").From("beats b")).Where("b.is_deleted = false")
+		return s.applyBeatsFilters(query, p)
 	}
 
-	if err = s.DB.QueryRow(ctx, sql, args...).Scan(&total); err != nil {
-		s.log.Error("failed to count beats", sl.Err(err))
-		return nil, nil, err
+	genres, err := matching(func(p *model.GetBeatsParams) { p.Genre = nil })
+	if err != nil {
+		return nil, err
+	}
+	moods, err := matching(func(p *model.GetBeatsParams) { p.Mood = nil })
+	if err != nil {
+		return nil, err
+	}
+	tags, err := matching(func(p *model.GetBeatsParams) { p.Tag = nil })
+	if err != nil {
+		return nil, err
+	}
+	notes, err := matching(func(p *model.GetBeatsParams) { p.Note = nil })
+	if err != nil {
+		return nil, err
+	}
+	bpm, err := matching(func(p *model.GetBeatsParams) { p.Bpm = nil })
+	if err != nil {
+		return nil, err
 	}
 
-	if params.OrderBy != nil {
-		query = query.OrderBy(fmt.Sprintf("%q %s", params.OrderBy.Field, params.OrderBy.Order))
+	genre, genreArgs := localizedName(model.TaxonomyGenres, "a", params.Locales)
+	mood, moodArgs := localizedName(model.TaxonomyMoods, "a", params.Locales)
+	tag, tagArgs := localizedName(model.TaxonomyTags, "a", params.Locales)
+	note, noteArgs := localizedName(model.TaxonomyNotes, "a", params.Locales)
+
+	queries := []sq.SelectBuilder{
+		builder.Select("a.name").Column(sq.Expr(genre, genreArgs...)).Column("count(distinct l.beat_id)").
+			From("beats_genres l").Join("genres a on l.genre_id = a.id").
+			Where(genres.Prefix("l.beat_id in (").Suffix(")")).
+			GroupBy("a.id", "a.name").OrderBy("3 desc", "a.name"),
+		builder.Select("a.name").Column(sq.Expr(mood, moodArgs...)).Column("count(distinct l.beat_id)").
+			From("beats_moods l").Join("moods a on l.mood_id = a.id").
+			Where(moods.Prefix("l.beat_id in (").Suffix(")")).
+			GroupBy("a.id", "a.name").OrderBy("3 desc", "a.name"),
+		builder.Select("a.name").Column(sq.Expr(tag, tagArgs...)).Column("count(distinct l.beat_id)").
+			From("beats_tags l").Join("tags a on l.tag_id = a.id").
+			Where(tags.Prefix("l.beat_id in (").Suffix(")")).
+			GroupBy("a.id", "a.name").OrderBy("3 desc", "a.name"),
+		builder.Select("a.name").Column(sq.Expr(note, noteArgs...)).Column("l.scale").Column("count(distinct l.beat_id)").
+			From("beats_notes l").Join("notes a on l.note_id = a.id").
+			Where(notes.Prefix("l.beat_id in (").Suffix(")")).
+			GroupBy("a.id", "a.name", "l.scale").OrderBy("4 desc", "a.name", "l.scale"),
+		builder.Select(fmt.Sprintf("(bb.bpm / %[1]d) * %[1]d", bpmFacetBucket), fmt.Sprintf("(bb.bpm / %[1]d) * %[1]d + %[1]d - 1", bpmFacetBucket), "count(*)").
+			From("beats bb").
+			Where(bpm.Prefix("bb.id in (").Suffix(")")).
+			GroupBy("1", "2").OrderBy("1"),
+	}
+
+	batch := &pgx.Batch{}
+	for _, q := range queries {
+		sql, args, err := q.ToSql()
+		if err != nil {
+			s.log.Error("failed to convert to sql", sl.Err(err))
+			return nil, err
+		}
+		batch.Queue(sql, args...)
 	}
-	query = query.Limit(params.Limit).Offset(params.Offset)
 
-	sql, args, err = query.ToSql()
-	if err != nil {
-		s.log.Error("failed to convert to sql", sl.Err(err))
-		return nil, nil, err
+	br := s.DB.SendBatch(ctx, batch)
+	defer br.Close()
+
+	facets = new(model.Facets)
+	for _, dst := range []*[]model.FacetCount{&facets.Genres, &facets.Moods, &facets.Tags} {
+		rows, err := br.Query()
+		if err != nil {
+			s.log.Error("failed to count facets", sl.Err(err))
+			return nil, err
+		}
+		if *dst, err = pgx.CollectRows(rows, pgx.RowToStructByPos[model.FacetCount]); err != nil {
+			s.log.Error("failed to collect facets", sl.Err(err))
+			return nil, err
+		}
 	}
 
-	rows, err := s.DB.Query(ctx, sql, args...)
+	rows, err := br.Query()
 	if err != nil {
-		s.log.Error("failed to get beats", sl.Err(err))
-		return nil, nil, err
+		s.log.Error("failed to count note facets", sl.Err(err))
+		return nil, err
+	}
+	if facets.Notes, err = pgx.CollectRows(rows, pgx.RowToStructByPos[model.NoteFacetCount]); err != nil {
+		s.log.Error("failed to collect note facets", sl.Err(err))
+		return nil, err
 	}
-	defer rows.Close()
 
-	beats, err = pgx.CollectRows(rows, pgx.RowToStructByName[model.Beat])
+	rows, err = br.Query()
 	if err != nil {
-		s.log.Error("failed to collect beats", sl.Err(err))
-		return nil, nil, err
+		s.log.Error("failed to count bpm facets", sl.Err(err))
+		return nil, err
+	}
+	if facets.Bpm, err = pgx.CollectRows(rows, pgx.RowToStructByPos[model.BpmFacetCount]); err != nil {
+		s.log.Error("failed to collect bpm facets", sl.Err(err))
+		return nil, err
 	}
 
-	return beats, total, nil
+	return facets, nil
 }
 
-func (s *BeatStore) GetBeatParams(ctx context.Context) (attrs *model.BeatAttributes, err error) {
+// GetBeatParams returns the genres, moods, tags and notes that can be given to beats with
+// their names translated to the first of locales that has a translation.
+func (s *BeatStore) GetBeatParams(ctx context.Context, locales []string) (attrs *model.BeatAttributes, err error) {
 	genres, err := s.Queries.GetBeatGenreParams(ctx)
 	if err != nil {
 		s.log.Error("failed to get beat genre params", sl.Err(err))
@@ -251,6 +436,26 @@ func (s *BeatStore) GetBeatParams(ctx context.Context) (attrs *model.BeatAttribu
 		return nil, err
 	}
 
+	if len(locales) > 0 {
+		names, err := s.getTranslatedNames(ctx, locales)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range genres {
+			genres[i].Name = translated(names, genres[i].ID, genres[i].Name)
+		}
+		for i := range moods {
+			moods[i].Name = translated(names, moods[i].ID, moods[i].Name)
+		}
+		for i := range tags {
+			tags[i].Name = translated(names, tags[i].ID, tags[i].Name)
+		}
+		for i := range notes {
+			notes[i].Name = translated(names, notes[i].ID, notes[i].Name)
+		}
+	}
+
 	return &model.BeatAttributes{
 		Genres: genres,
 		Moods:  moods,
@@ -379,56 +584,74 @@ func (s *BeatStore) UpdateBeat(ctx context.Context, updateBeat model.UpdateBeat)
 		}
 	}
 
+	if err = s.checkArchived(ctx, qtx, updateBeat.Genres, updateBeat.Tags, updateBeat.Moods, updateBeat.Note); err != nil {
+		return nil, err
+	}
+
 	return beat, tx.Commit(ctx)
 }
 
-func (s *BeatStore) DeleteBeat(ctx context.Context, id uuid.UUID) error {
-	if err := s.Queries.DeleteBeat(ctx, id); err != nil {
-		return err
+// checkArchived rejects archived genres, tags, moods and notes, which are kept for the
+// beats that already have them but can not be given to beats anymore.
+func (s *BeatStore) checkArchived(
+	ctx context.Context,
+	qtx *generated.Queries,
+	genres []generated.SaveGenresParams,
+	tags []generated.SaveTagsParams,
+	moods []generated.SaveMoodsParams,
+	note *generated.SaveNoteParams,
+) error {
+	var arg generated.HasArchivedTaxonomyParams
+	for _, g := range genres {
+		arg.GenreIds = append(arg.GenreIds, g.GenreID)
+	}
+	for _, t := range tags {
+		arg.TagIds = append(arg.TagIds, t.TagID)
+	}
+	for _, m := range moods {
+		arg.MoodIds = append(arg.MoodIds, m.MoodID)
+	}
+	if note != nil {
+		arg.NoteIds = append(arg.NoteIds, note.NoteID)
 	}
 
-	return nil
-}
-
-func (s *BeatStore) UploadMedia(ctx context.Context, path, contentType string, file io.Reader) error {
-	if _, err := s.Minio.Client.PutObject(ctx, s.bucketName, path, file, -1, miniolib.PutObjectOptions{ContentType: contentType}); err != nil {
+	archived, err := qtx.HasArchivedTaxonomy(ctx, arg)
+	if err != nil {
+		s.log.Error("failed to check archived taxonomy", sl.Err(err))
 		return err
 	}
 
+	if archived {
+		return model.NewErr(model.ErrTaxonomyArchived, "genre, tag, mood or note can not be used anymore")
+	}
+
 	return nil
 }
 
-func (s *BeatStore) SaveOwner(ctx context.Context, owner generated.SaveOwnerParams) error {
-	tx, err := s.DB.Begin(ctx)
-	if err != nil {
-		s.log.Error("failed to start transaction", sl.Err(err))
+func (s *BeatStore) DeleteBeat(ctx context.Context, id uuid.UUID) error {
+	if err := s.Queries.DeleteBeat(ctx, id); err != nil {
 		return err
 	}
 
-	defer tx.Rollback(ctx) // nolint
-
-	qtx := s.Queries.WithTx(tx)
-	if err := qtx.DeleteBeat(ctx, owner.BeatID); err != nil {
-		s.log.Error("failed to delete beat", sl.Err(err))
-		return err
-	}
+	return nil
+}
 
-	if err := qtx.SaveOwner(ctx, owner); err != nil {
-		s.log.Error("failed to save owner", sl.Err(err))
+func (s *BeatStore) UploadMedia(ctx context.Context, path, contentType string, file io.Reader) error {
+	if _, err := s.Minio.Client.PutObject(ctx, s.bucketName, path, file, -1, miniolib.PutObjectOptions{ContentType: contentType}); err != nil {
 		return err
 	}
 
-	return tx.Commit(ctx)
+	return nil
 }
 
-func (s *BeatStore) GetOwnerByBeatID(ctx context.Context, beatID uuid.UUID) (*generated.BeatsOwner, error) {
-	owner, err := s.Queries.GetOwnerByBeatID(ctx, beatID)
+func (s *BeatStore) UpdateListeningSession(ctx context.Context, arg generated.UpdateListeningSessionParams) (*generated.UpdateListeningSessionRow, error) {
+	row, err := s.Queries.UpdateListeningSession(ctx, arg)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, &model.ModelError{Err: model.ErrOwnerNotFound}
+			return nil, &model.ModelError{Err: model.ErrSessionNotFound}
 		}
 		return nil, err
 	}
 
-	return &owner, err
+	return &row, nil
 }
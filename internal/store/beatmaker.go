@@ -0,0 +1,85 @@
+package beat
+
+import (
+	"context"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBeatmakerGenres counts the published beats of the beatmaker per genre, most used
+// first, the beats they collaborated on included. Labels are translated to the first of
+// locales that has a translation.
+func (s *BeatStore) GetBeatmakerGenres(ctx context.Context, beatmakerID uuid.UUID, locales []string) ([]model.FacetCount, error) {
+	genre, genreArgs := localizedName(model.TaxonomyGenres, "a", locales)
+
+	sql, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("a.name").Column(sq.Expr(genre, genreArgs...)).Column("count(distinct l.beat_id)").
+		From("beats_genres l").
+		Join("genres a on l.genre_id = a.id").
+		Join("beats b on l.beat_id = b.id").
+		Where(byBeatmaker(beatmakerID)).
+		Where("b.is_deleted = false and b.is_file_downloaded and b.is_image_downloaded and b.is_archive_downloaded").
+		GroupBy("a.id", "a.name").
+		OrderBy("3 desc", "a.name").
+		ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, sql, args...)
+	if err != nil {
+		s.log.Error("failed to get beatmaker genres", sl.Err(err))
+		return nil, err
+	}
+
+	genres, err := pgx.CollectRows(rows, pgx.RowToStructByPos[model.FacetCount])
+	if err != nil {
+		s.log.Error("failed to collect beatmaker genres", sl.Err(err))
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+// SetPinnedBeats replaces the pinned beats of the beatmaker with beatIDs in their order.
+// Every beat must be a beat of the beatmaker, or one they collaborated on, that is not
+// deleted.
+func (s *BeatStore) SetPinnedBeats(ctx context.Context, beatmakerID uuid.UUID, beatIDs []uuid.UUID) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	owned, err := qtx.CountBeatmakerBeats(ctx, generated.CountBeatmakerBeatsParams{BeatmakerID: beatmakerID, BeatIds: beatIDs})
+	if err != nil {
+		s.log.Error("failed to count beatmaker beats", sl.Err(err))
+		return err
+	}
+
+	if owned != int64(len(beatIDs)) {
+		return model.NewErr(model.ErrNotBeatOwner, "only your own beats and beats you collaborated on can be pinned")
+	}
+
+	if err = qtx.DeletePinnedBeats(ctx, beatmakerID); err != nil {
+		s.log.Error("failed to delete pinned beats", sl.Err(err))
+		return err
+	}
+
+	if err = qtx.SavePinnedBeats(ctx, generated.SavePinnedBeatsParams{BeatmakerID: beatmakerID, BeatIds: beatIDs}); err != nil {
+		s.log.Error("failed to save pinned beats", sl.Err(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,83 @@
+package beat
+
+import (
+	"context"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+// SetBeatCollaborators replaces the collaborators of the beat with collaborators.
+func (s *BeatStore) SetBeatCollaborators(ctx context.Context, beatID uuid.UUID, collaborators []generated.SaveBeatCollaboratorParams) ([]generated.BeatsCollaborator, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+	if err = qtx.DeleteBeatCollaborators(ctx, beatID); err != nil {
+		s.log.Error("failed to delete beat collaborators", sl.Err(err))
+		return nil, err
+	}
+
+	for _, c := range collaborators {
+		if err = qtx.SaveBeatCollaborator(ctx, c); err != nil {
+			s.log.Error("failed to save beat collaborator", sl.Err(err))
+			return nil, err
+		}
+	}
+
+	res, err := qtx.GetBeatCollaborators(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get beat collaborators", sl.Err(err))
+		return nil, err
+	}
+
+	return res, tx.Commit(ctx)
+}
+
+// saveEarnings splits the price of the license between the collaborators of the beat in
+// the transaction of qtx, see model.SplitEarnings. A beat without collaborators earns its
+// beatmaker the whole price.
+func (s *BeatStore) saveEarnings(ctx context.Context, qtx *generated.Queries, beat generated.Beat, license generated.BeatLicense) error {
+	collaborators, err := qtx.GetBeatCollaborators(ctx, beat.ID)
+	if err != nil {
+		s.log.Error("failed to get beat collaborators", sl.Err(err))
+		return err
+	}
+
+	if len(collaborators) == 0 {
+		collaborators = []generated.BeatsCollaborator{{
+			UserID: beat.BeatmakerID,
+			Role:   generated.CollaboratorRoleProducer,
+			Share:  100,
+		}}
+	}
+
+	shares := make([]int32, 0, len(collaborators))
+	for _, c := range collaborators {
+		shares = append(shares, c.Share)
+	}
+
+	for i, amount := range model.SplitEarnings(license.Price, shares) {
+		if err := qtx.SaveLicenseEarning(ctx, generated.SaveLicenseEarningParams{
+			BeatID:         license.BeatID,
+			UserID:         license.UserID,
+			Tier:           license.Tier,
+			CollaboratorID: collaborators[i].UserID,
+			Role:           collaborators[i].Role,
+			Share:          collaborators[i].Share,
+			Amount:         amount,
+		}); err != nil {
+			s.log.Error("failed to save license earning", sl.Err(err))
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package beat
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// IssueDownload gives out a download link of the license of the issue and records it in
+// the audit log with the client of the issue: the link issued before if it is valid past
+// issue.LiveUntil, without counting it, or else the link of the issue, counted against
+// the download limit. The license is locked, so concurrent downloads neither issue two
+// links nor go over the limit.
+func (s *BeatStore) IssueDownload(ctx context.Context, issue model.IssueDownload) (string, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return "", err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	audit := issue.Audit
+	qtx := s.Queries.WithTx(tx)
+	license, err := qtx.GetLicenseForUpdate(ctx, generated.GetLicenseForUpdateParams{
+		BeatID: audit.BeatID,
+		UserID: audit.UserID,
+		Tier:   audit.Tier,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", &model.ModelError{Err: model.ErrLicenseNotFound}
+		}
+		s.log.Error("failed to lock license", sl.Err(err))
+		return "", err
+	}
+
+	if license.RevokedAt.Valid {
+		return "", &model.ModelError{Err: model.ErrLicenseRevoked}
+	}
+
+	live, err := qtx.GetLiveDownload(ctx, generated.GetLiveDownloadParams{
+		BeatID:    audit.BeatID,
+		UserID:    audit.UserID,
+		Tier:      audit.Tier,
+		ExpiresAt: pgtype.Timestamp{Time: issue.LiveUntil, Valid: true},
+	})
+	switch {
+	case err == nil && live.Url != nil:
+		audit.Url, audit.ExpiresAt = live.Url, live.ExpiresAt
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		s.log.Error("failed to get live download", sl.Err(err))
+		return "", err
+	default:
+		if _, err = qtx.IncrementLicenseDownloads(ctx, generated.IncrementLicenseDownloadsParams{
+			BeatID:        audit.BeatID,
+			UserID:        audit.UserID,
+			Tier:          audit.Tier,
+			DownloadLimit: issue.Limit,
+		}); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", &model.ModelError{Err: model.ErrDownloadLimit}
+			}
+			s.log.Error("failed to increment downloads", sl.Err(err))
+			return "", err
+		}
+	}
+
+	if err = qtx.SaveLicenseAudit(ctx, audit); err != nil {
+		s.log.Error("failed to save license audit", sl.Err(err))
+		return "", err
+	}
+
+	return *audit.Url, tx.Commit(ctx)
+}
+
+// ResetDownloads sets the download counter of the license of the audit entry to zero and
+// records the reset.
+func (s *BeatStore) ResetDownloads(ctx context.Context, audit generated.SaveLicenseAuditParams) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+	if err = qtx.ResetLicenseDownloads(ctx, generated.ResetLicenseDownloadsParams{
+		BeatID: audit.BeatID,
+		UserID: audit.UserID,
+		Tier:   audit.Tier,
+	}); err != nil {
+		s.log.Error("failed to reset downloads", sl.Err(err))
+		return err
+	}
+
+	if err = qtx.SaveLicenseAudit(ctx, audit); err != nil {
+		s.log.Error("failed to save license audit", sl.Err(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
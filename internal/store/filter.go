@@ -0,0 +1,64 @@
+package beat
+
+import (
+	"fmt"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/celsql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/cel-go/cel"
+	"github.com/google/uuid"
+)
+
+// beatFilterSchema declares the variables available in GetBeats filter expressions.
+// Scalar variables refer to the columns of the GetBeats query, list variables are
+// checked through subqueries so that they do not depend on the joined rows.
+func beatFilterSchema() celsql.Schema {
+	return celsql.Schema{
+		"name":         {Type: cel.StringType, Expr: "b.name"},
+		"description":  {Type: cel.StringType, Expr: "b.description"},
+		"bpm":          {Type: cel.IntType, Expr: "b.bpm"},
+		"range_start":  {Type: cel.IntType, Expr: "b.range_start"},
+		"range_end":    {Type: cel.IntType, Expr: "b.range_end"},
+		"created_at":   {Type: cel.TimestampType, Expr: "b.created_at"},
+		"beatmaker_id": {Type: cel.StringType, Expr: "b.beatmaker_id", Convert: toUUID},
+		"note":         {Type: cel.StringType, Expr: "n.name"},
+		"scale":        {Type: cel.StringType, Expr: "bn.scale"},
+		"genres":       {Type: cel.ListType(cel.StringType), Contains: hasAttribute("beats_genres", "genres", "genre_id")},
+		"tags":         {Type: cel.ListType(cel.StringType), Contains: hasAttribute("beats_tags", "tags", "tag_id")},
+		"moods":        {Type: cel.ListType(cel.StringType), Contains: hasAttribute("beats_moods", "moods", "mood_id")},
+	}
+}
+
+// hasAttribute checks that a beat has the attribute with the name, slug or id v.
+func hasAttribute(link, table, column string) func(v any) sq.Sqlizer {
+	return func(v any) sq.Sqlizer {
+		return sq.Expr(fmt.Sprintf("exists (select 1 from %s fl join %s fa on fl.%s = fa.id where fl.beat_id = b.id and ? in (fa.name, fa.slug, fa.id::text))", link, table, column), v)
+	}
+}
+
+// matchAttributes checks the attributes of a beat against values in a subquery, so that
+// the joined rows, and so the aggregated genres, tags and moods, are left untouched.
+// A value matches an attribute by its name, slug or id, so filters do not depend on
+// the locale the names are shown in.
+func matchAttributes(link, table, column string, values []string, mode model.MatchMode) sq.Sqlizer {
+	from := fmt.Sprintf("from %s fl join %s fa on fl.%s = fa.id where fl.beat_id = b.id", link, table, column)
+
+	switch mode {
+	case model.MatchAll:
+		return sq.Expr("not exists (select 1 from unnest(?::text[]) v (value) where not exists (select 1 "+from+" and v.value in (fa.name, fa.slug, fa.id::text)))", values)
+	case model.MatchNone:
+		return sq.Expr("not exists (select 1 "+from+" and (fa.name = any(?) or fa.slug = any(?) or fa.id::text = any(?)))", values, values, values)
+	default:
+		return sq.Expr("exists (select 1 "+from+" and (fa.name = any(?) or fa.slug = any(?) or fa.id::text = any(?)))", values, values, values)
+	}
+}
+
+func toUUID(v any) (any, error) {
+	s, _ := v.(string)
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid uuid", s)
+	}
+	return id, nil
+}
@@ -0,0 +1,261 @@
+package beat
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+// SetLicenseTiers replaces the license tiers of the beat with tiers.
+func (s *BeatStore) SetLicenseTiers(ctx context.Context, beatID uuid.UUID, tiers []generated.SaveLicenseTierParams) ([]generated.BeatsLicenseTier, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+	if err = qtx.DeleteLicenseTiers(ctx, beatID); err != nil {
+		s.log.Error("failed to delete license tiers", sl.Err(err))
+		return nil, err
+	}
+
+	for _, t := range tiers {
+		if err = qtx.SaveLicenseTier(ctx, t); err != nil {
+			s.log.Error("failed to save license tier", sl.Err(err))
+			return nil, err
+		}
+	}
+
+	res, err := qtx.GetLicenseTiers(ctx, beatID)
+	if err != nil {
+		s.log.Error("failed to get license tiers", sl.Err(err))
+		return nil, err
+	}
+
+	return res, tx.Commit(ctx)
+}
+
+// SaveLicense sells the license of the sale and returns it. The beat is locked for the
+// sale, so concurrent sales of it are checked one after another: a user that has the
+// license already gets it back, and the sale fails if the license of the user was
+// revoked, the beat is sold exclusively or deleted, or the tier is sold out. Revoked
+// licenses of others do not count. The bool is false if the license was sold before.
+// An exclusive sale also takes the beat off the catalog, leases leave it for sale. The
+// price is split between the collaborators of the beat as of the sale.
+func (s *BeatStore) SaveLicense(ctx context.Context, sale model.SaveLicense) (*generated.BeatLicense, bool, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return nil, false, err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	license, created, err := s.saveLicense(ctx, s.Queries.WithTx(tx), sale)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return license, created, tx.Commit(ctx)
+}
+
+// saveLicense sells the license of the sale in the transaction of qtx, see SaveLicense.
+// The checks of the sale come before any write, so the transaction can go on after
+// they fail.
+func (s *BeatStore) saveLicense(ctx context.Context, qtx *generated.Queries, sale model.SaveLicense) (*generated.BeatLicense, bool, error) {
+	license := sale.License
+
+	beat, err := qtx.GetBeatForUpdate(ctx, license.BeatID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, &model.ModelError{Err: model.ErrBeatNotFound}
+		}
+		s.log.Error("failed to lock beat", sl.Err(err))
+		return nil, false, err
+	}
+
+	licenses, err := qtx.GetBeatLicenses(ctx, license.BeatID)
+	if err != nil {
+		s.log.Error("failed to get licenses", sl.Err(err))
+		return nil, false, err
+	}
+
+	held, sold, err := checkSale(licenses, license)
+	if err != nil {
+		return nil, false, err
+	}
+	if held != nil {
+		return held, false, s.saveAcquisition(ctx, qtx, sale)
+	}
+
+	if beat.IsDeleted {
+		return nil, false, &model.ModelError{Err: model.ErrBeatNotFound}
+	}
+
+	if sale.SalesCap != nil && sold >= int(*sale.SalesCap) {
+		return nil, false, model.NewErr(model.ErrLicenseSoldOut, string(license.Tier))
+	}
+
+	if !model.IsLease(license.Tier) {
+		if err := qtx.DeleteBeat(ctx, license.BeatID); err != nil {
+			s.log.Error("failed to delete beat", sl.Err(err))
+			return nil, false, err
+		}
+	}
+
+	res, err := qtx.SaveLicense(ctx, license)
+	if err != nil {
+		s.log.Error("failed to save license", sl.Err(err))
+		return nil, false, err
+	}
+
+	if err := s.saveEarnings(ctx, qtx, beat, res); err != nil {
+		return nil, false, err
+	}
+
+	return &res, true, s.saveAcquisition(ctx, qtx, sale)
+}
+
+// checkSale checks the sale of license against the licenses of its beat. It returns the
+// license if the user holds it already and the number of its tier sold to others. A
+// revoked license of the user is refused rather than given back, revoked licenses of
+// others do not count.
+func checkSale(licenses []generated.BeatLicense, license generated.SaveLicenseParams) (*generated.BeatLicense, int, error) {
+	sold := 0
+	for _, l := range licenses {
+		if l.UserID == license.UserID && l.Tier == license.Tier {
+			if l.RevokedAt.Valid {
+				return nil, 0, &model.ModelError{Err: model.ErrLicenseRevoked}
+			}
+			return &l, 0, nil
+		}
+		if l.RevokedAt.Valid {
+			continue
+		}
+		if !model.IsLease(l.Tier) {
+			return nil, 0, model.NewErr(model.ErrInvalidOwner, "beat acquired by another owner")
+		}
+		if l.Tier == license.Tier {
+			sold++
+		}
+	}
+
+	return nil, sold, nil
+}
+
+// saveAcquisition records the idempotency key of the sale, if any. A key stored
+// concurrently for another beat or tier is refused with ErrIdempotencyKeyUsed, so the
+// sale rolls back instead of losing its key.
+func (s *BeatStore) saveAcquisition(ctx context.Context, qtx *generated.Queries, sale model.SaveLicense) error {
+	if sale.IdempotencyKey == "" {
+		return nil
+	}
+
+	if err := qtx.SaveAcquisition(ctx, generated.SaveAcquisitionParams{
+		UserID:         sale.License.UserID,
+		IdempotencyKey: sale.IdempotencyKey,
+		BeatID:         sale.License.BeatID,
+		Tier:           sale.License.Tier,
+	}); err != nil {
+		s.log.Error("failed to save acquisition", sl.Err(err))
+		return err
+	}
+
+	acquisition, err := qtx.GetAcquisition(ctx, generated.GetAcquisitionParams{
+		UserID:         sale.License.UserID,
+		IdempotencyKey: sale.IdempotencyKey,
+	})
+	if err != nil {
+		s.log.Error("failed to get acquisition", sl.Err(err))
+		return err
+	}
+
+	if acquisition.BeatID != sale.License.BeatID || acquisition.Tier != sale.License.Tier {
+		return &model.ModelError{Err: model.ErrIdempotencyKeyUsed}
+	}
+
+	return nil
+}
+
+func (s *BeatStore) GetAcquisition(ctx context.Context, arg generated.GetAcquisitionParams) (*generated.BeatAcquisition, error) {
+	acquisition, err := s.Queries.GetAcquisition(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrAcquisitionNotFound}
+		}
+		return nil, err
+	}
+
+	return &acquisition, nil
+}
+
+func (s *BeatStore) GetLicenseByNumber(ctx context.Context, number string) (*generated.BeatLicense, error) {
+	license, err := s.Queries.GetLicenseByNumber(ctx, number)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrLicenseNotFound}
+		}
+		return nil, err
+	}
+
+	return &license, nil
+}
+
+// RevokeLicense revokes the license of the revocation, marks its paid orders refunded and
+// records the revocation in the audit log, returning the beat of an exclusive license to
+// the catalog if asked to. A license is revoked once.
+func (s *BeatStore) RevokeLicense(ctx context.Context, revocation model.RevokeLicense) (*generated.BeatLicense, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	audit := revocation.Audit
+	qtx := s.Queries.WithTx(tx)
+	license, err := qtx.RevokeLicense(ctx, generated.RevokeLicenseParams{
+		BeatID: audit.BeatID,
+		UserID: audit.UserID,
+		Tier:   audit.Tier,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrLicenseRevoked}
+		}
+		s.log.Error("failed to revoke license", sl.Err(err))
+		return nil, err
+	}
+
+	if err = qtx.RefundLicenseOrders(ctx, generated.RefundLicenseOrdersParams{
+		BeatID: audit.BeatID,
+		UserID: audit.UserID,
+		Tier:   audit.Tier,
+	}); err != nil {
+		s.log.Error("failed to refund orders", sl.Err(err))
+		return nil, err
+	}
+
+	if err = qtx.SaveLicenseAudit(ctx, audit); err != nil {
+		s.log.Error("failed to save license audit", sl.Err(err))
+		return nil, err
+	}
+
+	if revocation.RestoreBeat && !model.IsLease(license.Tier) {
+		if err = qtx.RestoreBeat(ctx, license.BeatID); err != nil {
+			s.log.Error("failed to restore beat", sl.Err(err))
+			return nil, err
+		}
+	}
+
+	return &license, tx.Commit(ctx)
+}
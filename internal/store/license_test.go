@@ -0,0 +1,94 @@
+package beat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSale_Success(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	revokedAt := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	license := generated.SaveLicenseParams{UserID: userID, Tier: generated.LicenseTierMp3Lease}
+	held := generated.BeatLicense{UserID: userID, Tier: generated.LicenseTierMp3Lease, Number: "BF-1"}
+
+	tests := []struct {
+		name     string
+		licenses []generated.BeatLicense
+		held     *generated.BeatLicense
+		sold     int
+	}{
+		{name: "first sale"},
+		{name: "held", licenses: []generated.BeatLicense{held}, held: &held},
+		{
+			name: "sold to others",
+			licenses: []generated.BeatLicense{
+				{UserID: uuid.New(), Tier: generated.LicenseTierMp3Lease},
+				{UserID: uuid.New(), Tier: generated.LicenseTierWavLease},
+				{UserID: userID, Tier: generated.LicenseTierWavLease},
+			},
+			sold: 1,
+		},
+		{
+			name: "revoked of others",
+			licenses: []generated.BeatLicense{
+				{UserID: uuid.New(), Tier: generated.LicenseTierMp3Lease, RevokedAt: revokedAt},
+				{UserID: uuid.New(), Tier: generated.LicenseTierExclusive, RevokedAt: revokedAt},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, sold, err := checkSale(tt.licenses, license)
+			require.NoError(t, err)
+			assert.Equal(t, tt.held, res)
+			assert.Equal(t, tt.sold, sold)
+		})
+	}
+}
+
+func TestCheckSale_Fail(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	license := generated.SaveLicenseParams{UserID: userID, Tier: generated.LicenseTierMp3Lease}
+
+	tests := []struct {
+		name     string
+		licenses []generated.BeatLicense
+		err      error
+	}{
+		{
+			// The revoked license is not given back as if it was sold.
+			name:     "revoked",
+			licenses: []generated.BeatLicense{{UserID: userID, Tier: generated.LicenseTierMp3Lease, RevokedAt: pgtype.Timestamp{Time: time.Now(), Valid: true}}},
+			err:      model.ErrLicenseRevoked,
+		},
+		{
+			name:     "sold exclusively",
+			licenses: []generated.BeatLicense{{UserID: uuid.New(), Tier: generated.LicenseTierExclusive}},
+			err:      model.ErrInvalidOwner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, _, err := checkSale(tt.licenses, license)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Nil(t, res)
+		})
+	}
+}
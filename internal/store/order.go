@@ -0,0 +1,150 @@
+package beat
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+func (s *BeatStore) SaveOrder(ctx context.Context, arg generated.SaveOrderParams) (*generated.Order, error) {
+	order, err := s.Queries.SaveOrder(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (s *BeatStore) GetOrderByID(ctx context.Context, id uuid.UUID) (*generated.Order, error) {
+	order, err := s.Queries.GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrOrderNotFound}
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// GetPendingOrder returns the latest pending order of the user for the tier of the beat
+// created after arg.CreatedAt.
+func (s *BeatStore) GetPendingOrder(ctx context.Context, arg generated.GetPendingOrderParams) (*generated.Order, error) {
+	order, err := s.Queries.GetPendingOrder(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrOrderNotFound}
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// FailOrder marks the order failed, e.g. when its checkout could not be started.
+func (s *BeatStore) FailOrder(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.Queries.UpdateOrderStatus(ctx, generated.UpdateOrderStatusParams{Status: generated.OrderStatusFailed, ID: id}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.ModelError{Err: model.ErrOrderNotFound}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *BeatStore) SetOrderCheckout(ctx context.Context, arg generated.SetOrderCheckoutParams) (*generated.Order, error) {
+	order, err := s.Queries.SetOrderCheckout(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrOrderNotFound}
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// ConfirmOrder records the outcome of the checkout of an order and, if it is paid, sells
+// the license of the order under its terms in the same transaction. The order is locked,
+// so redelivered webhooks find it confirmed and leave it as it is. A paid order the
+// license can no longer be sold for, or whose buyer holds the license already, is
+// rejected and must be refunded. A failed order that gets paid after all, e.g. on a
+// second attempt at its checkout, is confirmed like a pending one. The bool is true if
+// the order was confirmed by this call.
+func (s *BeatStore) ConfirmOrder(ctx context.Context, arg model.ConfirmOrder) (*generated.Order, bool, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return nil, false, err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	order, err := qtx.GetOrderByCheckoutForUpdate(ctx, generated.GetOrderByCheckoutForUpdateParams{
+		Provider:   arg.Provider,
+		CheckoutID: &arg.CheckoutID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, &model.ModelError{Err: model.ErrOrderNotFound}
+		}
+		s.log.Error("failed to lock order", sl.Err(err))
+		return nil, false, err
+	}
+
+	if !confirmable(order.Status, arg.Paid) {
+		return &order, false, nil
+	}
+
+	status := generated.OrderStatusFailed
+	if arg.Paid {
+		status = generated.OrderStatusPaid
+		_, created, err := s.saveLicense(ctx, qtx, model.SaveLicense{
+			License: generated.SaveLicenseParams{
+				BeatID:          order.BeatID,
+				UserID:          order.UserID,
+				Tier:            order.Tier,
+				Price:           order.Price,
+				Deliverables:    order.Deliverables,
+				StreamCap:       order.StreamCap,
+				DistributionCap: order.DistributionCap,
+			},
+			SalesCap: order.SalesCap,
+		})
+		var modelErr *model.ModelError
+		switch {
+		case err != nil && !errors.As(err, &modelErr):
+			return nil, false, err
+		case err != nil:
+			s.log.Warn("paid order rejected", slog.String("order_id", order.ID.String()), sl.Err(err))
+			status = generated.OrderStatusRejected
+		case !created:
+			// Another order sold the license to the buyer already, this one pays for nothing.
+			s.log.Warn("paid order rejected, license held", slog.String("order_id", order.ID.String()))
+			status = generated.OrderStatusRejected
+		}
+	}
+
+	order, err = qtx.UpdateOrderStatus(ctx, generated.UpdateOrderStatusParams{Status: status, ID: order.ID})
+	if err != nil {
+		s.log.Error("failed to update order status", sl.Err(err))
+		return nil, false, err
+	}
+
+	return &order, true, tx.Commit(ctx)
+}
+
+// confirmable reports whether the outcome of a checkout changes an order of the status:
+// pending orders take any outcome, failed ones can still be paid.
+func confirmable(status generated.OrderStatus, paid bool) bool {
+	return status == generated.OrderStatusPending || status == generated.OrderStatusFailed && paid
+}
@@ -0,0 +1,36 @@
+package beat
+
+import (
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status generated.OrderStatus
+		paid   bool
+		want   bool
+	}{
+		{name: "pending paid", status: generated.OrderStatusPending, paid: true, want: true},
+		{name: "pending failed", status: generated.OrderStatusPending, want: true},
+		// A paid event after a failed one must not leave the buyer charged for nothing.
+		{name: "failed paid", status: generated.OrderStatusFailed, paid: true, want: true},
+		{name: "failed again", status: generated.OrderStatusFailed},
+		{name: "paid redelivered", status: generated.OrderStatusPaid, paid: true},
+		{name: "rejected redelivered", status: generated.OrderStatusRejected, paid: true},
+		{name: "refunded", status: generated.OrderStatusRefunded, paid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, confirmable(tt.status, tt.paid))
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package beat
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (s *BeatStore) GetPlaylistByID(ctx context.Context, id uuid.UUID) (*generated.Playlist, error) {
+	playlist, err := s.Queries.GetPlaylistByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrPlaylistNotFound}
+		}
+		return nil, err
+	}
+
+	return &playlist, nil
+}
+
+func (s *BeatStore) GetPlaylistByShareToken(ctx context.Context, shareToken string) (*generated.Playlist, error) {
+	playlist, err := s.Queries.GetPlaylistByShareToken(ctx, shareToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrPlaylistNotFound}
+		}
+		return nil, err
+	}
+
+	return &playlist, nil
+}
+
+func (s *BeatStore) UpdatePlaylist(ctx context.Context, arg generated.UpdatePlaylistParams) (*generated.Playlist, error) {
+	playlist, err := s.Queries.UpdatePlaylist(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrPlaylistNotFound}
+		}
+		return nil, err
+	}
+
+	return &playlist, nil
+}
+
+// AddPlaylistBeat inserts the beat into the playlist and moves the beats at and after
+// its position one step down. The playlist row is locked, so that concurrent changes
+// of one playlist keep the positions dense.
+func (s *BeatStore) AddPlaylistBeat(ctx context.Context, arg model.AddPlaylistBeat) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+	if _, err = qtx.GetPlaylistForUpdate(ctx, arg.PlaylistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.ModelError{Err: model.ErrPlaylistNotFound}
+		}
+		s.log.Error("failed to lock playlist", sl.Err(err))
+		return err
+	}
+
+	count, err := qtx.CountPlaylistBeats(ctx, arg.PlaylistID)
+	if err != nil {
+		s.log.Error("failed to count playlist beats", sl.Err(err))
+		return err
+	}
+
+	if count >= arg.MaxBeats {
+		return &model.ModelError{Err: model.ErrPlaylistFull}
+	}
+
+	position := int32(count) + 1
+	if arg.Position != nil && *arg.Position < position {
+		position = *arg.Position
+	}
+
+	if err = qtx.ShiftPlaylistBeats(ctx, generated.ShiftPlaylistBeatsParams{
+		Delta:        1,
+		PlaylistID:   arg.PlaylistID,
+		FromPosition: position,
+	}); err != nil {
+		s.log.Error("failed to shift playlist beats", sl.Err(err))
+		return err
+	}
+
+	if err = qtx.SavePlaylistBeat(ctx, generated.SavePlaylistBeatParams{
+		PlaylistID: arg.PlaylistID,
+		BeatID:     arg.BeatID,
+		Position:   position,
+	}); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return &model.ModelError{Err: model.ErrBeatInPlaylist}
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return &model.ModelError{Err: model.ErrBeatNotFound}
+		}
+		s.log.Error("failed to save playlist beat", sl.Err(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RemovePlaylistBeat removes the beat from the playlist and moves the beats after it
+// one step up.
+func (s *BeatStore) RemovePlaylistBeat(ctx context.Context, playlistID, beatID uuid.UUID) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+	if _, err = qtx.GetPlaylistForUpdate(ctx, playlistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.ModelError{Err: model.ErrPlaylistNotFound}
+		}
+		s.log.Error("failed to lock playlist", sl.Err(err))
+		return err
+	}
+
+	position, err := qtx.DeletePlaylistBeat(ctx, generated.DeletePlaylistBeatParams{PlaylistID: playlistID, BeatID: beatID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.ModelError{Err: model.ErrBeatNotInPlaylist}
+		}
+		s.log.Error("failed to delete playlist beat", sl.Err(err))
+		return err
+	}
+
+	if err = qtx.ShiftPlaylistBeats(ctx, generated.ShiftPlaylistBeatsParams{
+		Delta:        -1,
+		PlaylistID:   playlistID,
+		FromPosition: position + 1,
+	}); err != nil {
+		s.log.Error("failed to shift playlist beats", sl.Err(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ReorderPlaylistBeats puts the beats of the playlist in the order of beatIDs, which must
+// list every beat of the playlist exactly once.
+func (s *BeatStore) ReorderPlaylistBeats(ctx context.Context, playlistID uuid.UUID, beatIDs []uuid.UUID) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+	if _, err = qtx.GetPlaylistForUpdate(ctx, playlistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.ModelError{Err: model.ErrPlaylistNotFound}
+		}
+		s.log.Error("failed to lock playlist", sl.Err(err))
+		return err
+	}
+
+	current, err := qtx.GetPlaylistBeatIDs(ctx, playlistID)
+	if err != nil {
+		s.log.Error("failed to get playlist beat ids", sl.Err(err))
+		return err
+	}
+
+	if !isPermutation(current, beatIDs) {
+		return model.NewErr(model.ErrValidationFailed, "beat ids must list every beat of the playlist exactly once")
+	}
+
+	if err = qtx.ReorderPlaylistBeats(ctx, generated.ReorderPlaylistBeatsParams{BeatIds: beatIDs, PlaylistID: playlistID}); err != nil {
+		s.log.Error("failed to reorder playlist beats", sl.Err(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func isPermutation(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	seen := make(map[uuid.UUID]int, len(a))
+	for _, id := range a {
+		seen[id]++
+	}
+	for _, id := range b {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+
+	return true
+}
@@ -0,0 +1,103 @@
+package beat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/camelot"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetSimilarBeats returns up to limit other beats ordered by their similarity to beat.
+// The names of beat must not be localized, the returned beats have them in locales.
+func (s *BeatStore) GetSimilarBeats(ctx context.Context, beat model.Beat, weights model.SimilarityWeights, limit uint64, locales []string) ([]model.Beat, error) {
+	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	score, args := similarityScore(beat, weights)
+
+	query := beatsQuery(builder, locales).
+		Where("b.id <> ?", beat.ID).
+		OrderByClause(score+" desc", args...).
+		OrderBy("b.created_at desc").
+		Limit(limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, sql, args...)
+	if err != nil {
+		s.log.Error("failed to get similar beats", sl.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	beats, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.Beat])
+	if err != nil {
+		s.log.Error("failed to collect similar beats", sl.Err(err))
+		return nil, err
+	}
+
+	return beats, nil
+}
+
+// similarityScore builds the weighted sum of bpm closeness, key compatibility,
+// shared genres, moods and tags and the same beatmaker of a beat in beatsQuery to beat.
+func similarityScore(beat model.Beat, w model.SimilarityWeights) (string, []any) {
+	var (
+		parts []string
+		args  []any
+	)
+
+	if w.Bpm != 0 && w.BpmRange > 0 {
+		parts = append(parts, "?::float8 * greatest(0, 1 - abs(b.bpm - ?)::float8 / ?)")
+		args = append(args, w.Bpm, beat.Bpm, w.BpmRange)
+	}
+
+	if w.Key != 0 && beat.NoteName != nil && beat.NoteScale != nil {
+		if key, ok := camelot.FromNote(*beat.NoteName, *beat.NoteScale); ok {
+			neighbours := key.Neighbours()
+			in := make([]string, 0, len(neighbours))
+			args = append(args, w.Key, *beat.NoteName, *beat.NoteScale)
+			for _, k := range neighbours {
+				name, scale := k.Note()
+				in = append(in, "(?, ?)")
+				args = append(args, name, scale)
+			}
+			parts = append(parts, fmt.Sprintf("?::float8 * case when (n.name, bn.scale::text) = (?, ?) then 1 when (n.name, bn.scale::text) in (%s) then 0.5 else 0 end", strings.Join(in, ", ")))
+		}
+	}
+
+	for _, attr := range []struct {
+		link, table, column string
+		names               []string
+		weight              float64
+	}{
+		{"beats_genres", "genres", "genre_id", beat.Genres, w.Genres},
+		{"beats_moods", "moods", "mood_id", beat.Moods, w.Moods},
+		{"beats_tags", "tags", "tag_id", beat.Tags, w.Tags},
+	} {
+		if attr.weight == 0 || len(attr.names) == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("?::float8 * (select count(distinct fa.name) from %s fl join %s fa on fl.%s = fa.id where fl.beat_id = b.id and fa.name = any(?))::float8 / ?", attr.link, attr.table, attr.column))
+		args = append(args, attr.weight, attr.names, len(attr.names))
+	}
+
+	if w.Beatmaker != 0 {
+		parts = append(parts, "?::float8 * (b.beatmaker_id = ?)::int")
+		args = append(args, w.Beatmaker, beat.BeatmakerID)
+	}
+
+	if len(parts) == 0 {
+		return "0", nil
+	}
+
+	return "(" + strings.Join(parts, " + ") + ")", args
+}
@@ -0,0 +1,107 @@
+package beat
+
+import (
+	"context"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetSuggestions returns up to params.Limit beats, beatmakers, genres and tags whose names
+// start with the typed prefix, shortest names first. Every query is served by a prefix
+// index of migration 000009 and all of them go to the database in one round trip.
+func (s *BeatStore) GetSuggestions(ctx context.Context, params model.GetSuggestionsParams) (*model.Suggestions, error) {
+	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	genre, genreArgs := localizedName(model.TaxonomyGenres, "a", params.Locales)
+	tag, tagArgs := localizedName(model.TaxonomyTags, "a", params.Locales)
+
+	queries := []sq.SelectBuilder{
+		builder.Select("b.id", "b.name").
+			From("beats b").
+			Where("b.is_deleted = false").
+			Where("lower(b.name) like ?", params.NamePattern).
+			OrderBy("length(b.name)", "b.name").
+			Limit(params.Limit),
+		builder.Select("bm.id", "bm.username", "bm.pseudonym").
+			From("beatmakers bm").
+			Where(sq.Or{
+				sq.Expr("lower(bm.pseudonym) like ?", params.NamePattern),
+				sq.Expr("lower(bm.username) like ?", params.NamePattern),
+			}).
+			Where("exists (select 1 from beats b where b.beatmaker_id = bm.id and b.is_deleted = false)").
+			OrderBy("length(bm.pseudonym)", "bm.pseudonym").
+			Limit(params.Limit),
+		builder.Select("a.id").Column(sq.Expr(genre, genreArgs...)).Column("a.slug").
+			From("genres a").
+			Where("a.is_archived = false").
+			Where(sq.Or{
+				sq.Expr("lower(a.name) like ?", params.NamePattern),
+				sq.Expr("a.slug like ?", params.SlugPattern),
+				sq.Expr("exists (select 1 from genres_translations tr where tr.genre_id = a.id and lower(tr.name) like ?)", params.NamePattern),
+			}).
+			OrderBy("a.position", "a.name").
+			Limit(params.Limit),
+		builder.Select("a.id").Column(sq.Expr(tag, tagArgs...)).Column("a.slug").
+			From("tags a").
+			Where("a.is_archived = false").
+			Where(sq.Or{
+				sq.Expr("lower(a.name) like ?", params.NamePattern),
+				sq.Expr("a.slug like ?", params.SlugPattern),
+				sq.Expr("exists (select 1 from tags_translations tr where tr.tag_id = a.id and lower(tr.name) like ?)", params.NamePattern),
+			}).
+			OrderBy("length(a.name)", "a.name").
+			Limit(params.Limit),
+	}
+
+	batch := &pgx.Batch{}
+	for _, q := range queries {
+		sql, args, err := q.ToSql()
+		if err != nil {
+			s.log.Error("failed to convert to sql", sl.Err(err))
+			return nil, err
+		}
+		batch.Queue(sql, args...)
+	}
+
+	br := s.DB.SendBatch(ctx, batch)
+	defer br.Close()
+
+	res := new(model.Suggestions)
+
+	rows, err := br.Query()
+	if err != nil {
+		s.log.Error("failed to suggest beats", sl.Err(err))
+		return nil, err
+	}
+	if res.Beats, err = pgx.CollectRows(rows, pgx.RowToStructByPos[model.BeatSuggestion]); err != nil {
+		s.log.Error("failed to collect beat suggestions", sl.Err(err))
+		return nil, err
+	}
+
+	rows, err = br.Query()
+	if err != nil {
+		s.log.Error("failed to suggest beatmakers", sl.Err(err))
+		return nil, err
+	}
+	if res.Beatmakers, err = pgx.CollectRows(rows, pgx.RowToStructByPos[model.BeatmakerSuggestion]); err != nil {
+		s.log.Error("failed to collect beatmaker suggestions", sl.Err(err))
+		return nil, err
+	}
+
+	for _, dst := range []*[]model.TaxonomySuggestion{&res.Genres, &res.Tags} {
+		rows, err := br.Query()
+		if err != nil {
+			s.log.Error("failed to suggest taxonomy", sl.Err(err))
+			return nil, err
+		}
+		if *dst, err = pgx.CollectRows(rows, pgx.RowToStructByPos[model.TaxonomySuggestion]); err != nil {
+			s.log.Error("failed to collect taxonomy suggestions", sl.Err(err))
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
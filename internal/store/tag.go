@@ -0,0 +1,65 @@
+package beat
+
+import (
+	"context"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+// SetBeatTags replaces the tags of the beat with tags. A tag that matches an existing one
+// by slug or name is reused, the others are created. Archived tags can not be given.
+func (s *BeatStore) SetBeatTags(ctx context.Context, beatID uuid.UUID, tags []generated.SaveTagParams) ([]generated.Tag, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	res := make([]generated.Tag, 0, len(tags))
+	links := make([]generated.SaveTagsParams, 0, len(tags))
+	seen := make(map[uuid.UUID]struct{}, len(tags))
+	for _, t := range tags {
+		if err = qtx.SaveTag(ctx, t); err != nil {
+			s.log.Error("failed to save tag", sl.Err(err))
+			return nil, err
+		}
+
+		tag, err := qtx.GetTagBySlugOrName(ctx, generated.GetTagBySlugOrNameParams{Slug: t.Slug, Name: t.Name})
+		if err != nil {
+			s.log.Error("failed to get tag", sl.Err(err))
+			return nil, err
+		}
+
+		if tag.IsArchived {
+			return nil, model.NewErr(model.ErrTaxonomyArchived, tag.Name)
+		}
+
+		// Different slugs can still match a single tag by name.
+		if _, ok := seen[tag.ID]; ok {
+			continue
+		}
+		seen[tag.ID] = struct{}{}
+
+		res = append(res, tag)
+		links = append(links, generated.SaveTagsParams{BeatID: beatID, TagID: tag.ID})
+	}
+
+	if err = qtx.DeleteBeatTags(ctx, beatID); err != nil {
+		s.log.Error("failed to delete beat tags", sl.Err(err))
+		return nil, err
+	}
+
+	if _, err = qtx.SaveTags(ctx, links); err != nil {
+		s.log.Error("failed to save beat tags", sl.Err(err))
+		return nil, err
+	}
+
+	return res, tx.Commit(ctx)
+}
@@ -0,0 +1,297 @@
+package beat
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/drop-audio-streaming/internal/lib/logger"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// taxonomyLink is the table that links beats to the entries of a taxonomy and its column
+// that references the entry.
+type taxonomyLink struct {
+	table  string
+	column string
+}
+
+var taxonomyKinds = []model.TaxonomyKind{model.TaxonomyGenres, model.TaxonomyTags, model.TaxonomyMoods, model.TaxonomyNotes}
+
+var taxonomyLinks = map[model.TaxonomyKind]taxonomyLink{
+	model.TaxonomyGenres: {table: "beats_genres", column: "genre_id"},
+	model.TaxonomyTags:   {table: "beats_tags", column: "tag_id"},
+	model.TaxonomyMoods:  {table: "beats_moods", column: "mood_id"},
+	model.TaxonomyNotes:  {table: "beats_notes", column: "note_id"},
+}
+
+// taxonomyColumns selects an entry of kind as model.TaxonomyEntry.
+func taxonomyColumns(kind model.TaxonomyKind) string {
+	link := taxonomyLinks[kind]
+	return fmt.Sprintf(`id, name, slug, position, is_archived,
+	(select count(distinct l.beat_id) from %[1]s l where l.%[2]s = %[3]s.id) as beats,
+	(select coalesce(jsonb_object_agg(tr.locale, tr.name), '{}') from %[3]s_translations tr where tr.%[2]s = %[3]s.id) as translations`,
+		link.table, link.column, kind)
+}
+
+// localizedName returns the expression for the name of the entry of kind under alias in
+// the first of locales that has a translation. Without locales it is the name itself.
+func localizedName(kind model.TaxonomyKind, alias string, locales []string) (string, []any) {
+	if len(locales) == 0 {
+		return alias + ".name", nil
+	}
+	return fmt.Sprintf(`coalesce((select tr.name from %[1]s_translations tr where tr.%[2]s = %[3]s.id and tr.locale = any(?)
+	order by array_position(?::text[], tr.locale::text) limit 1), %[3]s.name)`, kind, taxonomyLinks[kind].column, alias), []any{locales, locales}
+}
+
+// getTranslatedNames returns the names of the taxonomy entries of all kinds in the first
+// of locales that has a translation by entry id. Entries without one are left out.
+func (s *BeatStore) getTranslatedNames(ctx context.Context, locales []string) (map[uuid.UUID]string, error) {
+	parts := make([]string, 0, len(taxonomyKinds))
+	for _, kind := range taxonomyKinds {
+		parts = append(parts, fmt.Sprintf("select %s as id, locale, name from %s_translations", taxonomyLinks[kind].column, kind))
+	}
+
+	rows, err := s.DB.Query(ctx, fmt.Sprintf(`select distinct on (tr.id) tr.id, tr.name from (%s) tr
+	where tr.locale = any($1) order by tr.id, array_position($1::text[], tr.locale::text)`, strings.Join(parts, " union all ")), locales)
+	if err != nil {
+		s.log.Error("failed to get translations", sl.Err(err))
+		return nil, err
+	}
+
+	type translation struct {
+		ID   uuid.UUID
+		Name string
+	}
+
+	translations, err := pgx.CollectRows(rows, pgx.RowToStructByPos[translation])
+	if err != nil {
+		s.log.Error("failed to collect translations", sl.Err(err))
+		return nil, err
+	}
+
+	names := make(map[uuid.UUID]string, len(translations))
+	for _, t := range translations {
+		names[t.ID] = t.Name
+	}
+	return names, nil
+}
+
+// translated returns the name of the entry id in names, or name if it has none.
+func translated(names map[uuid.UUID]string, id uuid.UUID, name string) string {
+	if t, ok := names[id]; ok {
+		return t
+	}
+	return name
+}
+
+// GetTaxonomy returns all entries of kind, archived ones included, in display order.
+func (s *BeatStore) GetTaxonomy(ctx context.Context, kind model.TaxonomyKind) ([]model.TaxonomyEntry, error) {
+	query, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(taxonomyColumns(kind)).
+		From(string(kind)).
+		OrderBy("position", "name").
+		ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, query, args...)
+	if err != nil {
+		s.log.Error("failed to get taxonomy", sl.Err(err))
+		return nil, err
+	}
+
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.TaxonomyEntry])
+	if err != nil {
+		s.log.Error("failed to collect taxonomy", sl.Err(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *BeatStore) SaveTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, entry model.SaveTaxonomyEntry) (*model.TaxonomyEntry, error) {
+	position := sq.Expr(fmt.Sprintf("coalesce(?::int, (select coalesce(max(position), 0) + 1 from %s))", kind), entry.Position)
+
+	query, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert(string(kind)).
+		Columns("name", "slug", "position").
+		Values(entry.Name, entry.Slug, position).
+		Suffix("returning " + taxonomyColumns(kind)).
+		ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, err
+	}
+
+	return s.collectTaxonomyEntry(ctx, query, args)
+}
+
+// UpdateTaxonomyEntry changes the fields of the entry that are not nil.
+func (s *BeatStore) UpdateTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, update model.UpdateTaxonomyEntry) (*model.TaxonomyEntry, error) {
+	set := map[string]any{}
+	if update.Name != nil {
+		set["name"] = *update.Name
+	}
+	if update.Slug != nil {
+		set["slug"] = *update.Slug
+	}
+	if update.Position != nil {
+		set["position"] = *update.Position
+	}
+	if update.IsArchived != nil {
+		set["is_archived"] = *update.IsArchived
+	}
+
+	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	var (
+		query string
+		args  []any
+		err   error
+	)
+	if len(set) == 0 {
+		query, args, err = builder.Select(taxonomyColumns(kind)).From(string(kind)).Where("id = ?", update.ID).ToSql()
+	} else {
+		query, args, err = builder.Update(string(kind)).SetMap(set).Where("id = ?", update.ID).Suffix("returning " + taxonomyColumns(kind)).ToSql()
+	}
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, err
+	}
+
+	return s.collectTaxonomyEntry(ctx, query, args)
+}
+
+func (s *BeatStore) collectTaxonomyEntry(ctx context.Context, query string, args []any) (*model.TaxonomyEntry, error) {
+	rows, err := s.DB.Query(ctx, query, args...)
+	if err != nil {
+		s.log.Error("failed to save taxonomy entry", sl.Err(err))
+		return nil, err
+	}
+
+	entry, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[model.TaxonomyEntry])
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &model.ModelError{Err: model.ErrTaxonomyNotFound}
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, model.NewErr(model.ErrTaxonomyExists, "name and slug must be unique")
+		}
+		s.log.Error("failed to save taxonomy entry", sl.Err(err))
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// DeleteTaxonomyEntry deletes an entry that no beat refers to. Entries in use can be
+// archived or merged into another entry instead.
+func (s *BeatStore) DeleteTaxonomyEntry(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID) error {
+	tag, err := s.DB.Exec(ctx, fmt.Sprintf("delete from %s where id = $1", kind), id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return model.NewErr(model.ErrTaxonomyInUse, "archive or merge it instead")
+		}
+		s.log.Error("failed to delete taxonomy entry", sl.Err(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return &model.ModelError{Err: model.ErrTaxonomyNotFound}
+	}
+
+	return nil
+}
+
+// MergeTaxonomyEntries moves the beats of the source entry to the target entry and
+// deletes the source entry. Beats that have both keep a single link to the target.
+// It returns the number of beats that had the source entry.
+func (s *BeatStore) MergeTaxonomyEntries(ctx context.Context, kind model.TaxonomyKind, sourceID, targetID uuid.UUID) (int64, error) {
+	link := taxonomyLinks[kind]
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to start transaction", sl.Err(err))
+		return 0, err
+	}
+
+	defer tx.Rollback(ctx) // nolint
+
+	rows, err := tx.Query(ctx, fmt.Sprintf("select id from %s where id = any($1) for update", kind), []uuid.UUID{sourceID, targetID})
+	if err != nil {
+		s.log.Error("failed to lock taxonomy entries", sl.Err(err))
+		return 0, err
+	}
+
+	locked, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		s.log.Error("failed to collect taxonomy entries", sl.Err(err))
+		return 0, err
+	}
+
+	if len(locked) != 2 {
+		return 0, &model.ModelError{Err: model.ErrTaxonomyNotFound}
+	}
+
+	duplicates, err := tx.Exec(ctx, fmt.Sprintf(
+		"delete from %[1]s where %[2]s = $1 and beat_id in (select beat_id from %[1]s where %[2]s = $2)",
+		link.table, link.column), sourceID, targetID)
+	if err != nil {
+		s.log.Error("failed to delete duplicate links", sl.Err(err))
+		return 0, err
+	}
+
+	moved, err := tx.Exec(ctx, fmt.Sprintf("update %s set %[2]s = $2 where %[2]s = $1", link.table, link.column), sourceID, targetID)
+	if err != nil {
+		s.log.Error("failed to move links", sl.Err(err))
+		return 0, err
+	}
+
+	if _, err = tx.Exec(ctx, fmt.Sprintf("delete from %s where id = $1", kind), sourceID); err != nil {
+		s.log.Error("failed to delete taxonomy entry", sl.Err(err))
+		return 0, err
+	}
+
+	return duplicates.RowsAffected() + moved.RowsAffected(), tx.Commit(ctx)
+}
+
+// SaveTaxonomyTranslation sets the name of the entry of kind in locale.
+func (s *BeatStore) SaveTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, locale, name string) error {
+	_, err := s.DB.Exec(ctx, fmt.Sprintf(`insert into %[1]s_translations (%[2]s, locale, name) values ($1, $2, $3)
+	on conflict (%[2]s, locale) do update set name = excluded.name`, kind, taxonomyLinks[kind].column), id, locale, name)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return &model.ModelError{Err: model.ErrTaxonomyNotFound}
+		}
+		s.log.Error("failed to save taxonomy translation", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *BeatStore) DeleteTaxonomyTranslation(ctx context.Context, kind model.TaxonomyKind, id uuid.UUID, locale string) error {
+	tag, err := s.DB.Exec(ctx, fmt.Sprintf("delete from %s_translations where %s = $1 and locale = $2", kind, taxonomyLinks[kind].column), id, locale)
+	if err != nil {
+		s.log.Error("failed to delete taxonomy translation", sl.Err(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return &model.ModelError{Err: model.ErrTranslationNotFound}
+	}
+
+	return nil
+}